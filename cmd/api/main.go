@@ -2,15 +2,27 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
 	"log/slog"
-	"net/http"
+	"net"
 	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
 
 	"go-api/internal/config"
 	"go-api/internal/di"
 	"go-api/internal/infrastructure/database"
+	"go-api/internal/logging"
+	"go-api/internal/metrics"
+	grpcapi "go-api/internal/presentation/grpc"
 	httpapi "go-api/internal/presentation/http"
+	"go-api/internal/presentation/http/middleware"
+	"go-api/internal/server"
+	"go-api/internal/tracing"
 )
 
 func main() {
@@ -20,27 +32,111 @@ func main() {
 }
 
 func run() error {
-	cfg := config.Load()
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	configPath := flag.String("config", os.Getenv("CONFIG_FILE"), "設定ファイル（.yaml/.yml/.toml）のパス")
+	printConfig := flag.Bool("print-config", false, "秘匿情報を伏せた設定を出力して終了する")
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return err
+	}
+	if *printConfig {
+		return config.Print(os.Stdout, cfg)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	logger := slog.New(logging.NewContextHandler(
+		slog.NewJSONHandler(os.Stdout, nil),
+		middleware.LogAttrs,
+		tracing.LogAttrs,
+	))
 
-	pool, err := database.Connect(context.Background(), cfg.Database)
+	tp, shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing)
 	if err != nil {
 		return err
 	}
-	defer pool.Close()
 
-	container := di.NewContainer(pool, logger)
+	// 起動時の接続の再試行も記録できるよう、レジストリは接続より先に生成する
+	var (
+		registry       *prometheus.Registry
+		connectMetrics database.ConnectMetrics
+	)
+	if cfg.Metrics.Enabled {
+		registry = metrics.NewRegistry()
+		connectMetrics = metrics.NewDatabaseConnect(registry)
+	}
 
-	h := httpapi.NewRouter(container)
+	// 保存先のデータベースにだけ接続する（使わないものは nil のまま）
+	// 接続は停止処理の最後に閉じる（処理中のリクエストとバックグラウンド処理が使い終えた後）
+	var dbs di.Databases
+	switch cfg.Storage {
+	case "postgres":
+		dbs.Primary, err = database.Connect(ctx, cfg.Database, tp, logger, connectMetrics)
+		if err != nil {
+			return err
+		}
+		defer dbs.Primary.Close()
+		dbs.Replicas, err = database.ConnectReplicas(ctx, cfg.Database, tp)
+		if err != nil {
+			return err
+		}
+		for _, replica := range dbs.Replicas {
+			defer replica.Close()
+		}
+	case "sqlite":
+		dbs.SQLite, err = database.OpenSQLite(ctx, cfg.SQLite)
+		if err != nil {
+			return err
+		}
+		defer dbs.SQLite.Close()
+	default:
+		logger.WarnContext(ctx, "using in-memory storage; data will be lost on exit", "storage", cfg.Storage)
+	}
+
+	container, err := di.NewContainer(cfg, dbs, registry, tp, logger)
+	if err != nil {
+		return err
+	}
+
+	servers, err := server.Listen(cfg.Server, httpapi.NewRouter(container), logger)
+	if err != nil {
+		return err
+	}
+	if cfg.Server.AdminAddr != "" {
+		ln, err := net.Listen("tcp", cfg.Server.AdminAddr)
+		if err != nil {
+			return err
+		}
+		servers = append(servers, server.Server{
+			Name:     "admin",
+			HTTP:     server.NewHTTPServer(httpapi.NewAdminRouter(container), cfg.Server),
+			Listener: ln,
+		})
+	}
 
-	server := &http.Server{
-		Addr:         ":" + cfg.Server.Port,
-		Handler:      h,
-		ReadTimeout:  cfg.Server.ReadTimeout,
-		WriteTimeout: cfg.Server.WriteTimeout,
-		IdleTimeout:  cfg.Server.IdleTimeout,
+	if cfg.Server.GRPCAddr != "" {
+		creds, err := server.NewGRPCCredentials(cfg.Server, logger)
+		if err != nil {
+			return err
+		}
+		ln, err := net.Listen("tcp", cfg.Server.GRPCAddr)
+		if err != nil {
+			return err
+		}
+		servers = append(servers, server.Server{
+			Name:     "grpc",
+			GRPC:     grpcapi.NewServer(container, grpc.Creds(creds)),
+			Listener: ln,
+		})
 	}
 
-	logger.Info("server starting", "addr", server.Addr)
-	return server.ListenAndServe()
+	return server.Run(ctx, servers, server.Options{
+		Readiness:       container.Readiness(),
+		DrainDelay:      cfg.Server.ShutdownDrainDelay,
+		ShutdownTimeout: cfg.Server.ShutdownTimeout,
+		Stop:            []func(context.Context) error{container.Close, shutdownTracing},
+		Logger:          logger,
+	})
 }
@@ -0,0 +1,37 @@
+-- ユーザーの変更履歴。seq を SSE の Last-Event-ID として使い、再接続時に続きから配信する
+CREATE TABLE user_events (
+    seq         BIGSERIAL    PRIMARY KEY,
+    type        TEXT         NOT NULL,
+    user_id     UUID         NOT NULL,
+    name        VARCHAR(100) NOT NULL,
+    email       VARCHAR(255) NOT NULL,
+    occurred_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+-- users の変更を user_events に記録するトリガー。削除は削除前の値を記録する
+CREATE OR REPLACE FUNCTION record_user_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        INSERT INTO user_events (type, user_id, name, email)
+        VALUES ('user.deleted', OLD.id, OLD.name, OLD.email);
+        RETURN OLD;
+    END IF;
+
+    INSERT INTO user_events (type, user_id, name, email)
+    VALUES (CASE TG_OP WHEN 'INSERT' THEN 'user.created' ELSE 'user.updated' END, NEW.id, NEW.name, NEW.email);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER users_record_event
+    AFTER INSERT OR DELETE ON users
+    FOR EACH ROW
+    EXECUTE FUNCTION record_user_event();
+
+-- 値が変わらない更新（同じ内容での保存）はイベントにしない
+CREATE TRIGGER users_record_update_event
+    AFTER UPDATE ON users
+    FOR EACH ROW
+    WHEN (OLD.name IS DISTINCT FROM NEW.name OR OLD.email IS DISTINCT FROM NEW.email)
+    EXECUTE FUNCTION record_user_event();
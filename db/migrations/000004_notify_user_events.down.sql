@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS user_events_notify ON user_events;
+DROP FUNCTION IF EXISTS notify_user_event();
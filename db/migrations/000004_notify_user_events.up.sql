@@ -0,0 +1,18 @@
+-- user_events に記録された変更を user_events チャネルで通知する。
+-- NOTIFY はトランザクションのコミット時に配送されるため、ロールバックした変更は通知されない
+CREATE OR REPLACE FUNCTION notify_user_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('user_events', json_build_object(
+        'seq', NEW.seq,
+        'type', NEW.type,
+        'user_id', NEW.user_id
+    )::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER user_events_notify
+    AFTER INSERT ON user_events
+    FOR EACH ROW
+    EXECUTE FUNCTION notify_user_event();
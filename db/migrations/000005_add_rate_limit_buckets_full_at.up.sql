@@ -0,0 +1,5 @@
+-- バケットが満杯に戻る時刻。これを過ぎたバケットは削除しても判定に影響しないため、定期的に削除する
+ALTER TABLE rate_limit_buckets
+    ADD COLUMN full_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+CREATE INDEX rate_limit_buckets_full_at_idx ON rate_limit_buckets (full_at);
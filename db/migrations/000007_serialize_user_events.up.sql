@@ -0,0 +1,20 @@
+-- seq は BIGSERIAL のため、同時に書き込むトランザクションでは採番の順とコミットの順が入れ替わりうる。
+-- 配信は seq の順に続きを取得するため、後の seq が先にコミットされると前の seq を読み飛ばす。
+-- イベントを記録するトランザクションをアドバイザリロック（トランザクションの終了まで保持）で直列化し、
+-- seq の順にコミットされるようにする
+CREATE OR REPLACE FUNCTION record_user_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtext('user_events'));
+
+    IF TG_OP = 'DELETE' THEN
+        INSERT INTO user_events (type, user_id, name, email)
+        VALUES ('user.deleted', OLD.id, OLD.name, OLD.email);
+        RETURN OLD;
+    END IF;
+
+    INSERT INTO user_events (type, user_id, name, email)
+    VALUES (CASE TG_OP WHEN 'INSERT' THEN 'user.created' ELSE 'user.updated' END, NEW.id, NEW.name, NEW.email);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,20 @@
+CREATE OR REPLACE FUNCTION record_user_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtext('user_events'));
+
+    IF TG_OP = 'DELETE' THEN
+        INSERT INTO user_events (type, user_id, name, email)
+        VALUES ('user.deleted', OLD.id, OLD.name, OLD.email);
+        RETURN OLD;
+    END IF;
+
+    INSERT INTO user_events (type, user_id, name, email)
+    VALUES (CASE TG_OP WHEN 'INSERT' THEN 'user.created' ELSE 'user.updated' END, NEW.id, NEW.name, NEW.email);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX IF EXISTS idx_user_events_txid_seq;
+
+ALTER TABLE user_events DROP COLUMN txid;
@@ -0,0 +1,22 @@
+-- 000007 のアドバイザリロックはイベントを記録する全ての書き込みを1つずつ待たせるため外し、読み込み側でコミットの順を扱う。
+-- txid はイベントを記録したトランザクションのID。読み込みは (txid, seq) の順に、実行中の最も古いトランザクションより
+-- 前の txid のイベントだけを返す。後からコミットされるトランザクションの txid はそれ以上になるため、返したイベントより前に入らない。
+-- xid8 は sqlc で型が決まらないため bigint で持つ
+ALTER TABLE user_events ADD COLUMN txid BIGINT NOT NULL DEFAULT pg_current_xact_id()::text::bigint;
+
+CREATE INDEX idx_user_events_txid_seq ON user_events (txid, seq);
+
+CREATE OR REPLACE FUNCTION record_user_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        INSERT INTO user_events (type, user_id, name, email)
+        VALUES ('user.deleted', OLD.id, OLD.name, OLD.email);
+        RETURN OLD;
+    END IF;
+
+    INSERT INTO user_events (type, user_id, name, email)
+    VALUES (CASE TG_OP WHEN 'INSERT' THEN 'user.created' ELSE 'user.updated' END, NEW.id, NEW.name, NEW.email);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,44 @@
+// Package migrations はマイグレーションファイルを埋め込み、アプリケーションが前提とするスキーマのバージョンを提供する。
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+// FS はマイグレーションファイル（golang-migrate 形式）。
+//
+//go:embed *.sql
+var FS embed.FS
+
+// LatestVersion は埋め込まれたマイグレーションの最新バージョンを返す。
+func LatestVersion() (uint, error) {
+	return latestVersion(FS)
+}
+
+func latestVersion(fsys fs.FS) (uint, error) {
+	names, err := fs.Glob(fsys, "*.up.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	var latest uint
+	for _, name := range names {
+		prefix, _, ok := strings.Cut(name, "_")
+		if !ok {
+			return 0, fmt.Errorf("invalid migration file name %q", name)
+		}
+		v, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid migration file name %q: %w", name, err)
+		}
+		latest = max(latest, uint(v))
+	}
+	if latest == 0 {
+		return 0, fmt.Errorf("no migrations found")
+	}
+	return latest, nil
+}
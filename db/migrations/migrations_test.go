@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestVersion(t *testing.T) {
+	t.Run("埋め込まれたマイグレーションの最新バージョンを返す", func(t *testing.T) {
+		v, err := LatestVersion()
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, v, uint(2))
+	})
+
+	t.Run("upファイルの番号の最大値を返す", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"000001_a.up.sql":   {},
+			"000001_a.down.sql": {},
+			"000010_b.up.sql":   {},
+			"000003_c.up.sql":   {},
+		}
+		v, err := latestVersion(fsys)
+		require.NoError(t, err)
+		assert.Equal(t, uint(10), v)
+	})
+
+	t.Run("マイグレーションがない場合はエラー", func(t *testing.T) {
+		_, err := latestVersion(fstest.MapFS{})
+		assert.Error(t, err)
+	})
+
+	t.Run("番号のないファイル名はエラー", func(t *testing.T) {
+		_, err := latestVersion(fstest.MapFS{"init.up.sql": {}})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,24 @@
+-- name: ListUserEventsAfter :many
+-- 実行中の最も古いトランザクションより前の txid のイベントだけを返す（マイグレーション 000009）
+SELECT seq, type, user_id, name, email, occurred_at, txid
+FROM user_events
+WHERE (txid, seq) > (@txid::bigint, @seq::bigint)
+  AND txid < pg_snapshot_xmin(pg_current_snapshot())::text::bigint
+ORDER BY txid, seq
+LIMIT @max_rows;
+
+-- name: GetLatestUserEventPosition :one
+SELECT txid, seq
+FROM user_events
+WHERE txid < pg_snapshot_xmin(pg_current_snapshot())::text::bigint
+ORDER BY txid DESC, seq DESC
+LIMIT 1;
+
+-- name: GetUserEventTxid :one
+SELECT txid
+FROM user_events
+WHERE seq = $1;
+
+-- name: DeleteUserEventsBefore :execrows
+DELETE FROM user_events
+WHERE occurred_at < $1;
@@ -6,12 +6,36 @@ WHERE id = $1;
 -- name: ListUsers :many
 SELECT id, name, email, created_at, updated_at
 FROM users
-ORDER BY created_at DESC;
+ORDER BY created_at DESC, id DESC;
 
--- name: CreateUser :exec
-INSERT INTO users (id, name, email)
-VALUES ($1, $2, $3);
+-- name: ListUsersFirstPage :many
+SELECT id, name, email, created_at, updated_at
+FROM users
+ORDER BY created_at DESC, id DESC
+LIMIT $1;
+
+-- name: ListUsersPageAfter :many
+SELECT id, name, email, created_at, updated_at
+FROM users
+WHERE (created_at, id) < (@created_at::timestamptz, @id::uuid)
+ORDER BY created_at DESC, id DESC
+LIMIT @max_rows;
+
+-- name: CountUsers :one
+SELECT COUNT(*)
+FROM users;
+
+-- name: ListUsersByIDs :many
+SELECT id, name, email, created_at, updated_at
+FROM users
+WHERE id = ANY(@ids::uuid[]);
 
 -- name: DeleteUser :exec
 DELETE FROM users
 WHERE id = $1;
+
+-- name: UpsertUser :exec
+INSERT INTO users (id, name, email)
+VALUES ($1, $2, $3)
+ON CONFLICT (id) DO UPDATE
+SET name = EXCLUDED.name, email = EXCLUDED.email;
@@ -0,0 +1,9 @@
+CREATE TABLE users (
+    id         TEXT     PRIMARY KEY,
+    name       TEXT     NOT NULL CHECK (length(name) <= 100),
+    email      TEXT     NOT NULL UNIQUE CHECK (length(email) <= 255),
+    created_at DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now'))
+);
+
+CREATE INDEX idx_users_created_at ON users (created_at);
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS users_record_delete_event;
+DROP TRIGGER IF EXISTS users_record_update_event;
+DROP TRIGGER IF EXISTS users_record_insert_event;
+DROP TABLE IF EXISTS user_events;
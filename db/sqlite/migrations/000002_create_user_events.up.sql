@@ -0,0 +1,36 @@
+-- ユーザーの変更履歴。seq を SSE の Last-Event-ID として使い、再接続時に続きから配信する
+CREATE TABLE user_events (
+    seq         INTEGER  PRIMARY KEY AUTOINCREMENT,
+    type        TEXT     NOT NULL,
+    user_id     TEXT     NOT NULL,
+    name        TEXT     NOT NULL,
+    email       TEXT     NOT NULL,
+    occurred_at DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now'))
+);
+
+-- users の変更を user_events に記録するトリガー。削除は削除前の値を記録する
+CREATE TRIGGER users_record_insert_event
+    AFTER INSERT ON users
+    FOR EACH ROW
+BEGIN
+    INSERT INTO user_events (type, user_id, name, email)
+    VALUES ('user.created', NEW.id, NEW.name, NEW.email);
+END;
+
+-- 値が変わらない更新（同じ内容での保存）はイベントにしない
+CREATE TRIGGER users_record_update_event
+    AFTER UPDATE OF name, email ON users
+    FOR EACH ROW
+    WHEN OLD.name IS NOT NEW.name OR OLD.email IS NOT NEW.email
+BEGIN
+    INSERT INTO user_events (type, user_id, name, email)
+    VALUES ('user.updated', NEW.id, NEW.name, NEW.email);
+END;
+
+CREATE TRIGGER users_record_delete_event
+    AFTER DELETE ON users
+    FOR EACH ROW
+BEGIN
+    INSERT INTO user_events (type, user_id, name, email)
+    VALUES ('user.deleted', OLD.id, OLD.name, OLD.email);
+END;
@@ -0,0 +1,2 @@
+-- ユーザー一覧のページング（作成日時の新しい順、同じ場合はIDの降順）をキーセットで辿るための索引
+CREATE INDEX idx_users_created_at_id ON users (created_at DESC, id DESC);
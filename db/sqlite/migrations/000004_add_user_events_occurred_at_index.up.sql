@@ -0,0 +1,2 @@
+-- 保持期間を過ぎたイベントを発生日時で削除するための索引
+CREATE INDEX idx_user_events_occurred_at ON user_events (occurred_at);
@@ -0,0 +1,10 @@
+// Package migrations はSQLite用のマイグレーションファイルを埋め込む。
+// PostgreSQL用（db/migrations）とはスキーマの書き方が異なるため、バージョンも別に管理する。
+package migrations
+
+import "embed"
+
+// FS はマイグレーションファイル（golang-migrate 形式）。起動時に database.OpenSQLite が適用する。
+//
+//go:embed *.sql
+var FS embed.FS
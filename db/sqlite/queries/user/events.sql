@@ -0,0 +1,19 @@
+-- name: ListUserEventsAfter :many
+SELECT seq, type, user_id, name, email, occurred_at
+FROM user_events
+WHERE seq > ?
+ORDER BY seq
+LIMIT ?;
+
+-- name: GetLatestUserEventSeq :one
+SELECT CAST(COALESCE(MAX(seq), 0) AS INTEGER) AS seq
+FROM user_events;
+
+-- name: GetUserEventSeq :one
+SELECT seq
+FROM user_events
+WHERE seq = ?;
+
+-- name: DeleteUserEventsBefore :execrows
+DELETE FROM user_events
+WHERE occurred_at < CAST(sqlc.arg(before) AS TEXT);
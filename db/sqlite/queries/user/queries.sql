@@ -0,0 +1,43 @@
+-- name: GetUser :one
+SELECT id, name, email, created_at, updated_at
+FROM users
+WHERE id = ?;
+
+-- name: ListUsers :many
+SELECT id, name, email, created_at, updated_at
+FROM users
+ORDER BY created_at DESC, id DESC;
+
+-- name: ListUsersFirstPage :many
+SELECT id, name, email, created_at, updated_at
+FROM users
+ORDER BY created_at DESC, id DESC
+LIMIT ?;
+
+-- name: ListUsersPageAfter :many
+SELECT id, name, email, created_at, updated_at
+FROM users
+WHERE (created_at, id) < (CAST(sqlc.arg(created_at) AS TEXT), sqlc.arg(id))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(max_rows);
+
+-- name: CountUsers :one
+SELECT COUNT(*)
+FROM users;
+
+-- name: ListUsersByIDs :many
+SELECT id, name, email, created_at, updated_at
+FROM users
+WHERE id IN (sqlc.slice('ids'));
+
+-- name: DeleteUser :exec
+DELETE FROM users
+WHERE id = ?;
+
+-- name: UpsertUser :exec
+INSERT INTO users (id, name, email)
+VALUES (?, ?, ?)
+ON CONFLICT (id) DO UPDATE
+SET name = excluded.name,
+    email = excluded.email,
+    updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now');
@@ -0,0 +1,39 @@
+// Package application はユースケース層の共通機能を提供する。
+package application
+
+import "context"
+
+// Observer はユースケースの実行を観測するインターフェース。
+// メトリクスやトレースなど、横断的な計測をユースケース本体から切り離すために使用する。
+type Observer interface {
+	// Start はユースケースの開始時に呼ばれ、終了時に結果のエラーを渡して呼ぶ関数を返す。
+	Start(ctx context.Context, usecase string) (context.Context, func(err error))
+}
+
+// NopObserver は何も計測しない Observer。
+type NopObserver struct{}
+
+// Start は Observer を実装する。
+func (NopObserver) Start(ctx context.Context, _ string) (context.Context, func(error)) {
+	return ctx, func(error) {}
+}
+
+// Observers は複数の Observer を順に呼び出す Observer を返す。
+func Observers(observers ...Observer) Observer {
+	return multiObserver(observers)
+}
+
+type multiObserver []Observer
+
+func (m multiObserver) Start(ctx context.Context, usecase string) (context.Context, func(error)) {
+	ends := make([]func(error), len(m))
+	for i, o := range m {
+		ctx, ends[i] = o.Start(ctx, usecase)
+	}
+	return ctx, func(err error) {
+		// 開始と逆順に終了させ、入れ子の計測（スパン等）を正しく閉じる
+		for i := len(ends) - 1; i >= 0; i-- {
+			ends[i](err)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-api/internal/application"
+)
+
+type recordingObserver struct {
+	name  string
+	calls *[]string
+}
+
+func (o recordingObserver) Start(ctx context.Context, usecase string) (context.Context, func(error)) {
+	*o.calls = append(*o.calls, o.name+":start:"+usecase)
+	return ctx, func(err error) {
+		*o.calls = append(*o.calls, o.name+":end:"+err.Error())
+	}
+}
+
+func TestObservers(t *testing.T) {
+	t.Run("開始順に開始し逆順に終了する", func(t *testing.T) {
+		var calls []string
+		o := application.Observers(
+			recordingObserver{name: "a", calls: &calls},
+			recordingObserver{name: "b", calls: &calls},
+		)
+
+		_, end := o.Start(context.Background(), "GetUser")
+		end(errors.New("boom"))
+
+		assert.Equal(t, []string{
+			"a:start:GetUser",
+			"b:start:GetUser",
+			"b:end:boom",
+			"a:end:boom",
+		}, calls)
+	})
+}
@@ -2,16 +2,17 @@ package user
 
 import (
 	"context"
-	"fmt"
 
+	"go-api/internal/application"
 	"go-api/internal/domain/user"
 	"go-api/internal/domain/user/valueobject"
 )
 
 // CreateUserInput はユーザー作成の入力。
+// 値オブジェクトで受け取るため、入力の検証は入力元（ハンドラー等）での生成時に済んでいる。
 type CreateUserInput struct {
-	Name  string
-	Email string
+	Name  valueobject.UserName
+	Email valueobject.Email
 }
 
 // CreateUserOutput はユーザー作成の出力。
@@ -21,29 +22,22 @@ type CreateUserOutput struct {
 
 // CreateUserUsecase はユーザー作成のユースケース。
 type CreateUserUsecase struct {
-	repo user.UserRepository
+	repo     user.UserRepository
+	observer application.Observer
 }
 
 // NewCreateUserUsecase は CreateUserUsecase を生成する。
-func NewCreateUserUsecase(repo user.UserRepository) *CreateUserUsecase {
-	return &CreateUserUsecase{repo: repo}
+func NewCreateUserUsecase(repo user.UserRepository, opts ...Option) *CreateUserUsecase {
+	o := newOptions(opts)
+	return &CreateUserUsecase{repo: repo, observer: o.observer}
 }
 
 // Execute はユーザーを作成する。
-// 入力バリデーションはハンドラー層で実施済みの前提。
-// VO生成エラーは防御的チェックとして扱い、発生時はシステムエラーとする。
-func (uc *CreateUserUsecase) Execute(ctx context.Context, input CreateUserInput) (*CreateUserOutput, error) {
-	name, err := valueobject.NewUserName(input.Name)
-	if err != nil {
-		return nil, fmt.Errorf("unexpected name validation error: %w", err)
-	}
-
-	email, err := valueobject.NewEmail(input.Email)
-	if err != nil {
-		return nil, fmt.Errorf("unexpected email validation error: %w", err)
-	}
+func (uc *CreateUserUsecase) Execute(ctx context.Context, input CreateUserInput) (_ *CreateUserOutput, err error) {
+	ctx, end := uc.observer.Start(ctx, "CreateUser")
+	defer func() { end(err) }()
 
-	u := user.NewUser(name, email)
+	u := user.NewUser(input.Name, input.Email)
 
 	if err := uc.repo.Save(ctx, u); err != nil {
 		return nil, err
@@ -3,22 +3,28 @@ package user
 import (
 	"context"
 
+	"go-api/internal/application"
 	"go-api/internal/domain/user"
 	"go-api/internal/domain/user/valueobject"
 )
 
 // DeleteUserUsecase はユーザー削除のユースケース。
 type DeleteUserUsecase struct {
-	repo user.UserRepository
+	repo     user.UserRepository
+	observer application.Observer
 }
 
 // NewDeleteUserUsecase は DeleteUserUsecase を生成する。
-func NewDeleteUserUsecase(repo user.UserRepository) *DeleteUserUsecase {
-	return &DeleteUserUsecase{repo: repo}
+func NewDeleteUserUsecase(repo user.UserRepository, opts ...Option) *DeleteUserUsecase {
+	o := newOptions(opts)
+	return &DeleteUserUsecase{repo: repo, observer: o.observer}
 }
 
 // Execute はユーザーを削除する。
-func (uc *DeleteUserUsecase) Execute(ctx context.Context, id string) error {
+func (uc *DeleteUserUsecase) Execute(ctx context.Context, id string) (err error) {
+	ctx, end := uc.observer.Start(ctx, "DeleteUser")
+	defer func() { end(err) }()
+
 	userID, err := valueobject.ParseUserID(id)
 	if err != nil {
 		return err
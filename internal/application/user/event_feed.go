@@ -0,0 +1,335 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"go-api/internal/application"
+	"go-api/internal/domain/user"
+	"go-api/internal/domain/user/valueobject"
+)
+
+// 購読が終了した理由。
+var (
+	// ErrSlowSubscriber は購読者の受信が遅れ、送信待ちのイベントが上限を超えたことを表す。
+	// 購読者は最後に受け取ったイベントの位置から Replay で再開できる。
+	ErrSlowSubscriber = errors.New("subscriber is too slow")
+	// ErrFeedStopped は EventFeed が停止したことを表す。
+	ErrFeedStopped = errors.New("event feed stopped")
+)
+
+// EventFeed のデフォルト値。
+const (
+	DefaultEventPollInterval = time.Second
+	DefaultEventBatchSize    = 100
+)
+
+// EventDTO はユーザーの変更イベントの出力。
+type EventDTO struct {
+	Seq        int64
+	Position   user.EventPosition
+	Type       string
+	User       UserDTO
+	OccurredAt time.Time
+}
+
+// EventFilter は配信するイベントの条件。空の項目は全てのイベントに一致する。
+type EventFilter struct {
+	UserIDs []valueobject.UserID
+	Types   []valueobject.EventType
+}
+
+// Match はイベントが条件に一致するかどうかを返す。
+func (f EventFilter) Match(e *user.Event) bool {
+	if len(f.Types) > 0 && !slices.Contains(f.Types, e.Type) {
+		return false
+	}
+	if len(f.UserIDs) > 0 && !slices.ContainsFunc(f.UserIDs, e.User.ID().Equal) {
+		return false
+	}
+	return true
+}
+
+// ReplayOutput は記録済みのイベントの取得の出力。
+type ReplayOutput struct {
+	// Events は条件に一致したイベント。
+	Events []EventDTO
+	// Last は条件に一致しなかったものを含め、取得した最後のイベントの位置。次の取得はここから続ける。
+	Last user.EventPosition
+	// More は続きのイベントがある可能性があるかどうか。
+	More bool
+}
+
+// FeedOptions は EventFeed の設定。
+type FeedOptions struct {
+	// PollInterval は新しいイベントを取得する間隔。Wake で間隔を待たずに取得させられる。
+	PollInterval time.Duration
+	// BatchSize は1回に取得するイベントの件数の上限。
+	BatchSize int
+	// Logger はイベントの取得の失敗を記録する。nil の場合は記録しない。
+	Logger *slog.Logger
+}
+
+// EventFeed はユーザーの変更イベントを取得し、購読者に配信する。
+// 記録済みのイベントを配信の順（user.EventPosition）に取得するため、配信が途切れても Replay で続きから再開できる。
+type EventFeed struct {
+	repo     user.EventRepository
+	opts     FeedOptions
+	observer application.Observer
+	wake     chan struct{}
+
+	// cursor は配信済みの最後の位置。Run のゴルーチンだけが更新する
+	cursor user.EventPosition
+	ready  bool
+
+	mu      sync.Mutex
+	subs    map[*Subscription]struct{}
+	stopped bool
+}
+
+// NewEventFeed は EventFeed を生成する。配信は Run を呼ぶと開始する。
+func NewEventFeed(repo user.EventRepository, feedOpts FeedOptions, opts ...Option) *EventFeed {
+	o := newOptions(opts)
+	if feedOpts.PollInterval <= 0 {
+		feedOpts.PollInterval = DefaultEventPollInterval
+	}
+	if feedOpts.BatchSize <= 0 {
+		feedOpts.BatchSize = DefaultEventBatchSize
+	}
+	return &EventFeed{
+		repo:     repo,
+		opts:     feedOpts,
+		observer: o.observer,
+		wake:     make(chan struct{}, 1),
+		subs:     make(map[*Subscription]struct{}),
+	}
+}
+
+// Run は ctx が終了するまで新しいイベントを取得して購読者に配信する。
+// 終了時に全ての購読を ErrFeedStopped で終了する。
+func (f *EventFeed) Run(ctx context.Context) {
+	ticker := time.NewTicker(f.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		f.poll(ctx)
+		select {
+		case <-ctx.Done():
+			f.stop()
+			return
+		case <-ticker.C:
+		case <-f.wake:
+		}
+	}
+}
+
+// Wake は PollInterval を待たずに新しいイベントを取得させる。
+// 変更の通知を受けた場合等に呼び、配信の遅れを短くする。
+func (f *EventFeed) Wake() {
+	select {
+	case f.wake <- struct{}{}:
+	default: // 既に取得が予約されている
+	}
+}
+
+// Subscribe は after より後で filter に一致するイベントの購読を開始する。
+// 送信待ちのイベントが buffer 件を超えた場合、購読は ErrSlowSubscriber で終了する。
+// 購読者は不要になったら Close を呼ぶ。
+func (f *EventFeed) Subscribe(after user.EventPosition, filter EventFilter, buffer int) *Subscription {
+	s := &Subscription{
+		feed:   f,
+		after:  after,
+		filter: filter,
+		events: make(chan EventDTO, max(buffer, 1)),
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.stopped {
+		s.err = ErrFeedStopped
+		close(s.events)
+		return s
+	}
+	f.subs[s] = struct{}{}
+	return s
+}
+
+// Replay は after より後の記録済みのイベントを配信の順に最大 BatchSize 件取得し、filter に一致するものを返す。
+func (f *EventFeed) Replay(ctx context.Context, after user.EventPosition, filter EventFilter) (_ *ReplayOutput, err error) {
+	ctx, end := f.observer.Start(ctx, "ReplayUserEvents")
+	defer func() { end(err) }()
+
+	events, err := f.repo.FindAfter(ctx, after, f.opts.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ReplayOutput{Last: after, More: len(events) == f.opts.BatchSize}
+	for _, e := range events {
+		output.Last = e.Position()
+		if filter.Match(e) {
+			output.Events = append(output.Events, toEventDTO(e))
+		}
+	}
+	return output, nil
+}
+
+// Latest は最新のイベントの位置を返す。イベントがない場合はゼロ値を返す。
+func (f *EventFeed) Latest(ctx context.Context) (_ user.EventPosition, err error) {
+	ctx, end := f.observer.Start(ctx, "GetLatestUserEventPosition")
+	defer func() { end(err) }()
+
+	return f.repo.Latest(ctx)
+}
+
+// Position は Seq が seq のイベントの位置を返す。seq が 0 の場合は最初のイベントより前の位置を返す。
+// イベントがない場合は false を返す。
+func (f *EventFeed) Position(ctx context.Context, seq int64) (_ user.EventPosition, _ bool, err error) {
+	if seq == 0 {
+		return user.EventPosition{}, true, nil
+	}
+
+	ctx, end := f.observer.Start(ctx, "FindUserEventPosition")
+	defer func() { end(err) }()
+
+	return f.repo.FindPosition(ctx, seq)
+}
+
+// poll は配信済みより後のイベントを全て取得して配信する。
+func (f *EventFeed) poll(ctx context.Context) {
+	if !f.ready && !f.init(ctx) {
+		return
+	}
+
+	for {
+		events, err := f.repo.FindAfter(ctx, f.cursor, f.opts.BatchSize)
+		if err != nil {
+			f.logError(ctx, "failed to fetch user events", err)
+			return
+		}
+		if len(events) == 0 {
+			return
+		}
+		f.broadcast(events)
+		f.cursor = events[len(events)-1].Position()
+		if len(events) < f.opts.BatchSize {
+			return
+		}
+	}
+}
+
+// init は配信を始める位置を決める。起動前に購読を始めた購読者がいれば、その購読者が必要とする位置から配信する。
+func (f *EventFeed) init(ctx context.Context) bool {
+	latest, err := f.repo.Latest(ctx)
+	if err != nil {
+		f.logError(ctx, "failed to fetch latest user event", err)
+		return false
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for s := range f.subs {
+		if s.after.Before(latest) {
+			latest = s.after
+		}
+	}
+	f.cursor, f.ready = latest, true
+	return true
+}
+
+// broadcast はイベントを購読者に配信する。送信待ちが上限を超えた購読者は終了させ、他の購読者への配信を遅らせない。
+func (f *EventFeed) broadcast(events []*user.Event) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for s := range f.subs {
+		for _, e := range events {
+			if !s.after.Before(e.Position()) || !s.filter.Match(e) {
+				continue
+			}
+			select {
+			case s.events <- toEventDTO(e):
+			default:
+				f.end(s, ErrSlowSubscriber)
+			}
+			if s.err != nil {
+				break
+			}
+		}
+	}
+}
+
+// stop は全ての購読を終了し、以降の購読を受け付けない。
+func (f *EventFeed) stop() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.stopped = true
+	for s := range f.subs {
+		f.end(s, ErrFeedStopped)
+	}
+}
+
+// end は購読を終了する。f.mu を保持して呼ぶ。
+func (f *EventFeed) end(s *Subscription, err error) {
+	if _, ok := f.subs[s]; !ok {
+		return
+	}
+	delete(f.subs, s)
+	s.err = err
+	close(s.events)
+}
+
+func (f *EventFeed) logError(ctx context.Context, msg string, err error) {
+	// 停止に伴うキャンセルは失敗として扱わない
+	if ctx.Err() != nil || f.opts.Logger == nil {
+		return
+	}
+	f.opts.Logger.ErrorContext(ctx, msg, "error", err.Error())
+}
+
+// Subscription はイベントの購読。
+type Subscription struct {
+	feed   *EventFeed
+	after  user.EventPosition
+	filter EventFilter
+	events chan EventDTO
+	err    error // feed.mu で保護する
+}
+
+// Events はイベントを受け取るチャネルを返す。購読が終了すると閉じられる。
+func (s *Subscription) Events() <-chan EventDTO {
+	return s.events
+}
+
+// Err は購読が終了した理由を返す。購読中か、Close で終了した場合は nil を返す。
+func (s *Subscription) Err() error {
+	s.feed.mu.Lock()
+	defer s.feed.mu.Unlock()
+	return s.err
+}
+
+// Close は購読を終了する。
+func (s *Subscription) Close() {
+	s.feed.mu.Lock()
+	defer s.feed.mu.Unlock()
+	s.feed.end(s, nil)
+}
+
+func toEventDTO(e *user.Event) EventDTO {
+	return EventDTO{
+		Seq:      e.Seq,
+		Position: e.Position(),
+		Type:     e.Type.String(),
+		User: UserDTO{
+			ID:    e.User.ID().String(),
+			Name:  e.User.Name().String(),
+			Email: e.User.Email().String(),
+		},
+		OccurredAt: e.OccurredAt,
+	}
+}
@@ -0,0 +1,255 @@
+package user_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	usecase "go-api/internal/application/user"
+	"go-api/internal/domain/user"
+	"go-api/internal/domain/user/mocks"
+	"go-api/internal/domain/user/valueobject"
+	"go-api/internal/testutil/factory"
+)
+
+// eventLog は記録済みのイベントを保持し、EventRepository のモックから返す。
+type eventLog struct {
+	mu     sync.Mutex
+	events []*user.Event
+}
+
+func newEventLog(t *testing.T, events ...*user.Event) (*eventLog, *mocks.MockEventRepository) {
+	l := &eventLog{events: events}
+	repo := mocks.NewMockEventRepository(t)
+	repo.EXPECT().FindAfter(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(l.findAfter).Maybe()
+	repo.EXPECT().Latest(mock.Anything).RunAndReturn(l.latest).Maybe()
+	return l, repo
+}
+
+func (l *eventLog) append(events ...*user.Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, events...)
+}
+
+func (l *eventLog) findAfter(_ context.Context, after user.EventPosition, limit int) ([]*user.Event, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var found []*user.Event
+	for _, e := range l.events {
+		if after.Before(e.Position()) && len(found) < limit {
+			found = append(found, e)
+		}
+	}
+	return found, nil
+}
+
+func (l *eventLog) latest(context.Context) (user.EventPosition, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.events) == 0 {
+		return user.EventPosition{}, nil
+	}
+	return l.events[len(l.events)-1].Position(), nil
+}
+
+// at は Seq の順にコミットされる保存先での、Seq が seq のイベントの位置を返す。
+func at(seq int64) user.EventPosition {
+	return user.EventPosition{Seq: seq}
+}
+
+// runFeed は EventFeed を起動し、停止する関数を返す。
+func runFeed(t *testing.T, feed *usecase.EventFeed) func() {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		feed.Run(ctx)
+		close(done)
+	}()
+	stop := func() {
+		cancel()
+		<-done
+	}
+	t.Cleanup(stop)
+	return stop
+}
+
+// receiveSeqs は購読が終了するか n 件受け取るまでイベントを受け取り、Seq を返す。
+func receiveSeqs(t *testing.T, sub *usecase.Subscription, n int) []int64 {
+	t.Helper()
+	var seqs []int64
+	for len(seqs) < n {
+		select {
+		case e, ok := <-sub.Events():
+			if !ok {
+				return seqs
+			}
+			seqs = append(seqs, e.Seq)
+		case <-time.After(time.Second):
+			t.Fatalf("イベントを受け取れない（受け取り済み: %v）", seqs)
+		}
+	}
+	return seqs
+}
+
+func TestEventFeed(t *testing.T) {
+	feedOpts := usecase.FeedOptions{PollInterval: time.Hour, BatchSize: 2}
+
+	t.Run("新しいイベントを購読者に配信する", func(t *testing.T) {
+		u := factory.NewUser(factory.WithName("test"))
+		log, repo := newEventLog(t, factory.NewEvent(1, valueobject.EventUserCreated, u))
+		feed := usecase.NewEventFeed(repo, feedOpts)
+		sub := feed.Subscribe(at(1), usecase.EventFilter{}, 10)
+		defer sub.Close()
+		runFeed(t, feed)
+
+		log.append(factory.NewEvent(2, valueobject.EventUserUpdated, u))
+		feed.Wake()
+
+		select {
+		case e := <-sub.Events():
+			assert.Equal(t, int64(2), e.Seq)
+			assert.Equal(t, "user.updated", e.Type)
+			assert.Equal(t, u.ID().String(), e.User.ID)
+			assert.Equal(t, "test", e.User.Name)
+		case <-time.After(time.Second):
+			t.Fatal("イベントが配信されない")
+		}
+	})
+
+	t.Run("起動前に購読を始めた位置から配信する", func(t *testing.T) {
+		u := factory.NewUser()
+		_, repo := newEventLog(t,
+			factory.NewEvent(1, valueobject.EventUserCreated, u),
+			factory.NewEvent(2, valueobject.EventUserUpdated, u),
+			factory.NewEvent(3, valueobject.EventUserUpdated, u),
+		)
+		feed := usecase.NewEventFeed(repo, feedOpts)
+		sub := feed.Subscribe(at(1), usecase.EventFilter{}, 10)
+		defer sub.Close()
+		runFeed(t, feed)
+
+		assert.Equal(t, []int64{2, 3}, receiveSeqs(t, sub, 2))
+	})
+
+	t.Run("Seqの順とコミットの順が入れ替わったイベントも購読を始めた位置より後なら配信する", func(t *testing.T) {
+		u := factory.NewUser()
+		first := factory.NewEvent(2, valueobject.EventUserCreated, u)
+		first.TxID = 10
+		log, repo := newEventLog(t, first)
+		feed := usecase.NewEventFeed(repo, feedOpts)
+		sub := feed.Subscribe(first.Position(), usecase.EventFilter{}, 10)
+		defer sub.Close()
+		runFeed(t, feed)
+
+		// 先に採番したトランザクションが後からコミットした
+		late := factory.NewEvent(1, valueobject.EventUserUpdated, u)
+		late.TxID = 11
+		log.append(late)
+		feed.Wake()
+
+		assert.Equal(t, []int64{1}, receiveSeqs(t, sub, 1))
+	})
+
+	t.Run("条件に一致するイベントだけを配信する", func(t *testing.T) {
+		a := factory.NewUser()
+		b := factory.NewUser()
+		_, repo := newEventLog(t,
+			factory.NewEvent(1, valueobject.EventUserCreated, a),
+			factory.NewEvent(2, valueobject.EventUserCreated, b),
+			factory.NewEvent(3, valueobject.EventUserDeleted, b),
+			factory.NewEvent(4, valueobject.EventUserDeleted, a),
+		)
+		feed := usecase.NewEventFeed(repo, feedOpts)
+		sub := feed.Subscribe(at(0), usecase.EventFilter{
+			UserIDs: []valueobject.UserID{a.ID()},
+			Types:   []valueobject.EventType{valueobject.EventUserDeleted},
+		}, 10)
+		defer sub.Close()
+		runFeed(t, feed)
+
+		assert.Equal(t, []int64{4}, receiveSeqs(t, sub, 1))
+	})
+
+	t.Run("送信待ちが上限を超えた購読はErrSlowSubscriberで終了し、他の購読者には配信を続ける", func(t *testing.T) {
+		u := factory.NewUser()
+		log, repo := newEventLog(t)
+		feed := usecase.NewEventFeed(repo, feedOpts)
+		slow := feed.Subscribe(at(0), usecase.EventFilter{}, 1)
+		fast := feed.Subscribe(at(0), usecase.EventFilter{}, 10)
+		defer fast.Close()
+		runFeed(t, feed)
+
+		log.append(
+			factory.NewEvent(1, valueobject.EventUserCreated, u),
+			factory.NewEvent(2, valueobject.EventUserUpdated, u),
+			factory.NewEvent(3, valueobject.EventUserUpdated, u),
+		)
+		feed.Wake()
+
+		assert.Equal(t, []int64{1, 2, 3}, receiveSeqs(t, fast, 3))
+		assert.Equal(t, []int64{1}, receiveSeqs(t, slow, 3), "送信待ちの上限までのイベントを受け取ってから終了するべき")
+		assert.ErrorIs(t, slow.Err(), usecase.ErrSlowSubscriber)
+	})
+
+	t.Run("停止すると購読をErrFeedStoppedで終了する", func(t *testing.T) {
+		_, repo := newEventLog(t)
+		feed := usecase.NewEventFeed(repo, feedOpts)
+		sub := feed.Subscribe(at(0), usecase.EventFilter{}, 10)
+		stop := runFeed(t, feed)
+
+		stop()
+
+		assert.Empty(t, receiveSeqs(t, sub, 1))
+		assert.ErrorIs(t, sub.Err(), usecase.ErrFeedStopped)
+
+		after := feed.Subscribe(at(0), usecase.EventFilter{}, 10)
+		assert.Empty(t, receiveSeqs(t, after, 1), "停止後の購読はすぐに終了するべき")
+		assert.ErrorIs(t, after.Err(), usecase.ErrFeedStopped)
+	})
+
+	t.Run("Closeで終了した購読はErrがnil", func(t *testing.T) {
+		_, repo := newEventLog(t)
+		feed := usecase.NewEventFeed(repo, feedOpts)
+		sub := feed.Subscribe(at(0), usecase.EventFilter{}, 10)
+
+		sub.Close()
+		sub.Close()
+
+		assert.Empty(t, receiveSeqs(t, sub, 1))
+		assert.NoError(t, sub.Err())
+	})
+}
+
+func TestEventFeed_Replay(t *testing.T) {
+	t.Run("記録済みのイベントを1回の取得件数ずつ条件で絞り込んで取得する", func(t *testing.T) {
+		a := factory.NewUser()
+		b := factory.NewUser()
+		_, repo := newEventLog(t,
+			factory.NewEvent(1, valueobject.EventUserCreated, a),
+			factory.NewEvent(2, valueobject.EventUserCreated, b),
+			factory.NewEvent(3, valueobject.EventUserUpdated, a),
+		)
+		feed := usecase.NewEventFeed(repo, usecase.FeedOptions{BatchSize: 2})
+		filter := usecase.EventFilter{UserIDs: []valueobject.UserID{a.ID()}}
+
+		output, err := feed.Replay(context.Background(), at(0), filter)
+		require.NoError(t, err)
+		require.Len(t, output.Events, 1)
+		assert.Equal(t, int64(1), output.Events[0].Seq)
+		assert.Equal(t, at(2), output.Last, "一致しなかったイベントも含めて取得した位置を返すべき")
+		assert.True(t, output.More)
+
+		output, err = feed.Replay(context.Background(), output.Last, filter)
+		require.NoError(t, err)
+		require.Len(t, output.Events, 1)
+		assert.Equal(t, int64(3), output.Events[0].Seq)
+		assert.False(t, output.More)
+	})
+}
@@ -0,0 +1,66 @@
+package user
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go-api/internal/application"
+	"go-api/internal/domain/user"
+)
+
+// DefaultEventPruneInterval は保持期間を過ぎたイベントを削除する間隔のデフォルト値。
+const DefaultEventPruneInterval = time.Hour
+
+// RetentionOptions は EventRetention の設定。
+type RetentionOptions struct {
+	// Retention はイベントを保持する期間。これより前に発生したイベントは削除し、
+	// それより前の位置からは配信を再開できなくなる。
+	Retention time.Duration
+	// Interval は削除する間隔。
+	Interval time.Duration
+	// Logger は削除の失敗を記録する。nil の場合は記録しない。
+	Logger *slog.Logger
+}
+
+// EventRetention は保持期間を過ぎたユーザーの変更イベントを削除する。
+type EventRetention struct {
+	repo     user.EventRepository
+	opts     RetentionOptions
+	observer application.Observer
+	now      func() time.Time
+}
+
+// NewEventRetention は EventRetention を生成する。削除は Run を呼ぶと開始する。
+func NewEventRetention(repo user.EventRepository, retentionOpts RetentionOptions, opts ...Option) *EventRetention {
+	o := newOptions(opts)
+	if retentionOpts.Interval <= 0 {
+		retentionOpts.Interval = DefaultEventPruneInterval
+	}
+	return &EventRetention{repo: repo, opts: retentionOpts, observer: o.observer, now: time.Now}
+}
+
+// Run は ctx が終了するまで Interval ごとに Prune を呼ぶ。
+func (r *EventRetention) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.Prune(ctx); err != nil && ctx.Err() == nil && r.opts.Logger != nil {
+			r.opts.Logger.WarnContext(ctx, "failed to prune user events", "error", err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Prune は保持期間より前に発生したイベントを削除し、削除した件数を返す。
+func (r *EventRetention) Prune(ctx context.Context) (_ int64, err error) {
+	ctx, end := r.observer.Start(ctx, "PruneUserEvents")
+	defer func() { end(err) }()
+
+	return r.repo.DeleteBefore(ctx, r.now().Add(-r.opts.Retention))
+}
@@ -0,0 +1,58 @@
+package user_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	usecase "go-api/internal/application/user"
+	"go-api/internal/domain/user/mocks"
+)
+
+func TestEventRetention(t *testing.T) {
+	t.Run("保持期間より前に発生したイベントを削除する", func(t *testing.T) {
+		repo := mocks.NewMockEventRepository(t)
+		start := time.Now()
+		repo.EXPECT().DeleteBefore(mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, before time.Time) (int64, error) {
+				assert.WithinRange(t, before, start.Add(-time.Hour), time.Now().Add(-time.Hour))
+				return 3, nil
+			})
+
+		r := usecase.NewEventRetention(repo, usecase.RetentionOptions{Retention: time.Hour})
+		n, err := r.Prune(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), n)
+	})
+
+	t.Run("Runは開始時と間隔ごとに削除する", func(t *testing.T) {
+		repo := mocks.NewMockEventRepository(t)
+		pruned := make(chan struct{}, 10)
+		repo.EXPECT().DeleteBefore(mock.Anything, mock.Anything).
+			RunAndReturn(func(context.Context, time.Time) (int64, error) {
+				pruned <- struct{}{}
+				return 0, nil
+			})
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			usecase.NewEventRetention(repo, usecase.RetentionOptions{Retention: time.Hour, Interval: 10 * time.Millisecond}).Run(ctx)
+			close(done)
+		}()
+
+		for range 2 {
+			select {
+			case <-pruned:
+			case <-time.After(time.Second):
+				t.Fatal("削除されない")
+			}
+		}
+		cancel()
+		<-done
+	})
+}
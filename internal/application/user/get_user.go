@@ -3,6 +3,7 @@ package user
 import (
 	"context"
 
+	"go-api/internal/application"
 	"go-api/internal/domain/user"
 	"go-api/internal/domain/user/valueobject"
 )
@@ -14,16 +15,21 @@ type GetUserOutput struct {
 
 // GetUserUsecase はユーザー取得のユースケース。
 type GetUserUsecase struct {
-	repo user.UserRepository
+	repo     user.UserRepository
+	observer application.Observer
 }
 
 // NewGetUserUsecase は GetUserUsecase を生成する。
-func NewGetUserUsecase(repo user.UserRepository) *GetUserUsecase {
-	return &GetUserUsecase{repo: repo}
+func NewGetUserUsecase(repo user.UserRepository, opts ...Option) *GetUserUsecase {
+	o := newOptions(opts)
+	return &GetUserUsecase{repo: repo, observer: o.observer}
 }
 
 // Execute はユーザーを取得する。
-func (uc *GetUserUsecase) Execute(ctx context.Context, id string) (*GetUserOutput, error) {
+func (uc *GetUserUsecase) Execute(ctx context.Context, id string) (_ *GetUserOutput, err error) {
+	ctx, end := uc.observer.Start(ctx, "GetUser")
+	defer func() { end(err) }()
+
 	userID, err := valueobject.ParseUserID(id)
 	if err != nil {
 		return nil, err
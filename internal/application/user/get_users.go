@@ -0,0 +1,50 @@
+package user
+
+import (
+	"context"
+
+	"go-api/internal/application"
+	"go-api/internal/domain/user"
+	"go-api/internal/domain/user/valueobject"
+)
+
+// GetUsersOutput は複数ユーザー取得の出力。
+type GetUsersOutput struct {
+	// Users は見つかったユーザー。見つからないIDは含まず、順序は入力と一致しない。
+	Users []UserDTO
+}
+
+// GetUsersUsecase は複数ユーザーをまとめて取得するユースケース。
+// GraphQL のローダーのように、個別の取得をまとめて1回の問い合わせにする用途で使う。
+type GetUsersUsecase struct {
+	repo     user.UserRepository
+	observer application.Observer
+}
+
+// NewGetUsersUsecase は GetUsersUsecase を生成する。
+func NewGetUsersUsecase(repo user.UserRepository, opts ...Option) *GetUsersUsecase {
+	o := newOptions(opts)
+	return &GetUsersUsecase{repo: repo, observer: o.observer}
+}
+
+// Execute は ids のユーザーをまとめて取得する。
+func (uc *GetUsersUsecase) Execute(ctx context.Context, ids []valueobject.UserID) (_ *GetUsersOutput, err error) {
+	ctx, end := uc.observer.Start(ctx, "GetUsers")
+	defer func() { end(err) }()
+
+	users, err := uc.repo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]UserDTO, len(users))
+	for i, u := range users {
+		dtos[i] = UserDTO{
+			ID:    u.ID().String(),
+			Name:  u.Name().String(),
+			Email: u.Email().String(),
+		}
+	}
+
+	return &GetUsersOutput{Users: dtos}, nil
+}
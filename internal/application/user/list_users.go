@@ -3,6 +3,7 @@ package user
 import (
 	"context"
 
+	"go-api/internal/application"
 	"go-api/internal/domain/user"
 )
 
@@ -20,16 +21,21 @@ type ListUsersOutput struct {
 
 // ListUsersUsecase はユーザー一覧取得のユースケース。
 type ListUsersUsecase struct {
-	repo user.UserRepository
+	repo     user.UserRepository
+	observer application.Observer
 }
 
 // NewListUsersUsecase は ListUsersUsecase を生成する。
-func NewListUsersUsecase(repo user.UserRepository) *ListUsersUsecase {
-	return &ListUsersUsecase{repo: repo}
+func NewListUsersUsecase(repo user.UserRepository, opts ...Option) *ListUsersUsecase {
+	o := newOptions(opts)
+	return &ListUsersUsecase{repo: repo, observer: o.observer}
 }
 
 // Execute はユーザー一覧を取得する。
-func (uc *ListUsersUsecase) Execute(ctx context.Context) (*ListUsersOutput, error) {
+func (uc *ListUsersUsecase) Execute(ctx context.Context) (_ *ListUsersOutput, err error) {
+	ctx, end := uc.observer.Start(ctx, "ListUsers")
+	defer func() { end(err) }()
+
 	users, err := uc.repo.FindAll(ctx)
 	if err != nil {
 		return nil, err
@@ -46,3 +52,58 @@ func (uc *ListUsersUsecase) Execute(ctx context.Context) (*ListUsersOutput, erro
 
 	return &ListUsersOutput{Users: dtos}, nil
 }
+
+// ListUsersPageInput はユーザー一覧のページ取得の入力。
+type ListUsersPageInput struct {
+	// After はこの位置より後のユーザーを取得する。nil の場合は先頭から取得する
+	After *user.PageCursor
+	// First は取得する件数
+	First int
+}
+
+// UserEdge はページ内のユーザーと、その一覧での位置。
+type UserEdge struct {
+	User   UserDTO
+	Cursor user.PageCursor
+}
+
+// ListUsersPageOutput はユーザー一覧のページ取得の出力。
+type ListUsersPageOutput struct {
+	Edges []UserEdge
+	// HasNextPage はこのページより後にユーザーがいるかどうか
+	HasNextPage bool
+	// TotalCount は一覧全体の件数
+	TotalCount int
+}
+
+// ExecutePage はユーザー一覧を作成日時の新しい順に1ページ分取得する。
+// 一覧全体は読み込まず、位置（user.PageCursor）より後を First 件だけ取得する。
+func (uc *ListUsersUsecase) ExecutePage(ctx context.Context, in ListUsersPageInput) (_ *ListUsersPageOutput, err error) {
+	ctx, end := uc.observer.Start(ctx, "ListUsersPage")
+	defer func() { end(err) }()
+
+	// 1件多く取得して次のページの有無を判定する
+	users, err := uc.repo.FindPage(ctx, in.After, in.First+1)
+	if err != nil {
+		return nil, err
+	}
+	total, err := uc.repo.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ListUsersPageOutput{HasNextPage: len(users) > in.First, TotalCount: total}
+	users = users[:min(len(users), in.First)]
+	output.Edges = make([]UserEdge, len(users))
+	for i, u := range users {
+		output.Edges[i] = UserEdge{
+			User: UserDTO{
+				ID:    u.ID().String(),
+				Name:  u.Name().String(),
+				Email: u.Email().String(),
+			},
+			Cursor: user.CursorOf(u),
+		}
+	}
+	return output, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -12,6 +13,7 @@ import (
 	usecase "go-api/internal/application/user"
 	"go-api/internal/domain/user"
 	"go-api/internal/domain/user/mocks"
+	"go-api/internal/domain/user/valueobject"
 	"go-api/internal/testutil/factory"
 )
 
@@ -52,3 +54,48 @@ func TestListUsersUsecase_Execute(t *testing.T) {
 		assert.Nil(t, output)
 	})
 }
+
+func TestListUsersUsecase_ExecutePage(t *testing.T) {
+	t.Run("1件多く取得して次のページの有無を判定する", func(t *testing.T) {
+		a, b, c := factory.NewUser(factory.WithName("a")), factory.NewUser(factory.WithName("b")), factory.NewUser()
+		after := &user.PageCursor{CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), ID: valueobject.NewUserID()}
+
+		repo := mocks.NewMockUserRepository(t)
+		repo.EXPECT().FindPage(mock.Anything, after, 3).Return([]*user.User{a, b, c}, nil)
+		repo.EXPECT().Count(mock.Anything).Return(10, nil)
+
+		uc := usecase.NewListUsersUsecase(repo)
+		output, err := uc.ExecutePage(context.Background(), usecase.ListUsersPageInput{After: after, First: 2})
+
+		require.NoError(t, err)
+		require.Len(t, output.Edges, 2)
+		assert.Equal(t, "a", output.Edges[0].User.Name)
+		assert.Equal(t, user.CursorOf(b), output.Edges[1].Cursor)
+		assert.True(t, output.HasNextPage)
+		assert.Equal(t, 10, output.TotalCount)
+	})
+
+	t.Run("最後のページは次のページがない", func(t *testing.T) {
+		repo := mocks.NewMockUserRepository(t)
+		repo.EXPECT().FindPage(mock.Anything, (*user.PageCursor)(nil), 3).Return([]*user.User{factory.NewUser()}, nil)
+		repo.EXPECT().Count(mock.Anything).Return(1, nil)
+
+		uc := usecase.NewListUsersUsecase(repo)
+		output, err := uc.ExecutePage(context.Background(), usecase.ListUsersPageInput{First: 2})
+
+		require.NoError(t, err)
+		assert.Len(t, output.Edges, 1)
+		assert.False(t, output.HasNextPage)
+	})
+
+	t.Run("リポジトリがエラーを返した場合はエラーを返す", func(t *testing.T) {
+		repo := mocks.NewMockUserRepository(t)
+		repo.EXPECT().FindPage(mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+
+		uc := usecase.NewListUsersUsecase(repo)
+		output, err := uc.ExecutePage(context.Background(), usecase.ListUsersPageInput{First: 2})
+
+		assert.Error(t, err)
+		assert.Nil(t, output)
+	})
+}
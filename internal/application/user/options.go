@@ -0,0 +1,23 @@
+package user
+
+import "go-api/internal/application"
+
+// Option はユースケースの生成オプション。
+type Option func(*options)
+
+type options struct {
+	observer application.Observer
+}
+
+// WithObserver はユースケースの実行を観測する Observer を設定する。
+func WithObserver(o application.Observer) Option {
+	return func(opts *options) { opts.observer = o }
+}
+
+func newOptions(opts []Option) options {
+	o := options{observer: application.NopObserver{}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
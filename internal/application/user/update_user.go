@@ -2,16 +2,17 @@ package user
 
 import (
 	"context"
-	"fmt"
 
+	"go-api/internal/application"
 	"go-api/internal/domain/user"
 	"go-api/internal/domain/user/valueobject"
 )
 
 // UpdateUserInput はユーザー更新の入力。
+// 値オブジェクトで受け取るため、入力の検証は入力元（ハンドラー等）での生成時に済んでいる。
 type UpdateUserInput struct {
-	Name  string
-	Email string
+	Name  valueobject.UserName
+	Email valueobject.Email
 }
 
 // UpdateUserOutput はユーザー更新の出力。
@@ -21,16 +22,21 @@ type UpdateUserOutput struct {
 
 // UpdateUserUsecase はユーザー更新のユースケース。
 type UpdateUserUsecase struct {
-	repo user.UserRepository
+	repo     user.UserRepository
+	observer application.Observer
 }
 
 // NewUpdateUserUsecase は UpdateUserUsecase を生成する。
-func NewUpdateUserUsecase(repo user.UserRepository) *UpdateUserUsecase {
-	return &UpdateUserUsecase{repo: repo}
+func NewUpdateUserUsecase(repo user.UserRepository, opts ...Option) *UpdateUserUsecase {
+	o := newOptions(opts)
+	return &UpdateUserUsecase{repo: repo, observer: o.observer}
 }
 
 // Execute はユーザーを更新する。
-func (uc *UpdateUserUsecase) Execute(ctx context.Context, id string, input UpdateUserInput) (*UpdateUserOutput, error) {
+func (uc *UpdateUserUsecase) Execute(ctx context.Context, id string, input UpdateUserInput) (_ *UpdateUserOutput, err error) {
+	ctx, end := uc.observer.Start(ctx, "UpdateUser")
+	defer func() { end(err) }()
+
 	userID, err := valueobject.ParseUserID(id)
 	if err != nil {
 		return nil, err
@@ -41,18 +47,8 @@ func (uc *UpdateUserUsecase) Execute(ctx context.Context, id string, input Updat
 		return nil, err
 	}
 
-	name, err := valueobject.NewUserName(input.Name)
-	if err != nil {
-		return nil, fmt.Errorf("unexpected name validation error: %w", err)
-	}
-
-	email, err := valueobject.NewEmail(input.Email)
-	if err != nil {
-		return nil, fmt.Errorf("unexpected email validation error: %w", err)
-	}
-
-	u.ChangeName(name)
-	u.ChangeEmail(email)
+	u.ChangeName(input.Name)
+	u.ChangeEmail(input.Email)
 
 	if err := uc.repo.Save(ctx, u); err != nil {
 		return nil, err
@@ -0,0 +1,47 @@
+// Package backoff は再試行の間隔（ジッター付きの指数バックオフ）を提供する。
+package backoff
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff は再試行の間隔の設定。
+type Backoff struct {
+	Min time.Duration // 初回の再試行までの待ち時間の基準
+	Max time.Duration // 待ち時間の上限
+}
+
+// Delay は attempt 回目（0 始まり）の再試行までの待ち時間を返す。
+// Min * 2^attempt（Max で頭打ち）の半分から全体までの範囲で無作為に決め、複数のプロセスの再試行が揃わないようにする。
+func (b Backoff) Delay(attempt int) time.Duration {
+	d := b.Min
+	for i := 0; i < attempt && d < b.Max; i++ {
+		d *= 2
+	}
+	d = min(d, b.Max)
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + rand.N(d-half+1)
+}
+
+// Wait は attempt 回目の再試行まで待つ。ctx が先に終了した場合は ctx.Err() を返す。
+func (b Backoff) Wait(ctx context.Context, attempt int) error {
+	return Sleep(ctx, b.Delay(attempt))
+}
+
+// Sleep は d だけ待つ。ctx が先に終了した場合は ctx.Err() を返す。
+// Delay で決めた待ち時間を記録してから待つ場合に使う。
+func Sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
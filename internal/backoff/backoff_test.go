@@ -0,0 +1,62 @@
+package backoff
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff_Delay(t *testing.T) {
+	b := Backoff{Min: 100 * time.Millisecond, Max: time.Second}
+
+	t.Run("再試行ごとに倍にした値の半分から全体の範囲で決める", func(t *testing.T) {
+		for range 100 {
+			assert.InDelta(t, 75*time.Millisecond, b.Delay(0), float64(25*time.Millisecond))
+			assert.InDelta(t, 150*time.Millisecond, b.Delay(1), float64(50*time.Millisecond))
+			assert.InDelta(t, 300*time.Millisecond, b.Delay(2), float64(100*time.Millisecond))
+		}
+	})
+
+	t.Run("Maxで頭打ちにする", func(t *testing.T) {
+		for range 100 {
+			d := b.Delay(50)
+			assert.GreaterOrEqual(t, d, 500*time.Millisecond)
+			assert.LessOrEqual(t, d, time.Second)
+		}
+	})
+
+	t.Run("Minが0の場合は待たない", func(t *testing.T) {
+		assert.Zero(t, Backoff{}.Delay(3))
+	})
+}
+
+func TestBackoff_Wait(t *testing.T) {
+	t.Run("ctxが終了したら待つのをやめる", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := Backoff{Min: time.Hour, Max: time.Hour}.Wait(ctx, 0)
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestSleep(t *testing.T) {
+	t.Run("指定した時間だけ待つ", func(t *testing.T) {
+		start := time.Now()
+
+		err := Sleep(context.Background(), 10*time.Millisecond)
+
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+	})
+
+	t.Run("ctxが終了したら待つのをやめる", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.ErrorIs(t, Sleep(ctx, time.Hour), context.Canceled)
+	})
+}
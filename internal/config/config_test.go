@@ -0,0 +1,524 @@
+package config_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/config"
+)
+
+// writeFile は一時ディレクトリにファイルを作成してパスを返す。
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("設定がない場合はデフォルト値を返す", func(t *testing.T) {
+		cfg, err := config.Load("")
+		require.NoError(t, err)
+		assert.Equal(t, config.Default(), cfg)
+	})
+
+	t.Run("環境変数で上書きできる", func(t *testing.T) {
+		t.Setenv("SERVER_READ_TIMEOUT", "3s")
+		t.Setenv("DATABASE_MAX_CONNS", "20")
+		t.Setenv("SERVER_TRUSTED_PROXIES", "10.0.0.0/8, 192.168.0.1")
+		t.Setenv("RATE_LIMIT_USERS_WRITE_BURST", "3")
+
+		cfg, err := config.Load("")
+		require.NoError(t, err)
+		assert.Equal(t, 3*time.Second, cfg.Server.ReadTimeout)
+		assert.Equal(t, int32(20), cfg.Database.MaxConns)
+		assert.Equal(t, []string{"10.0.0.0/8", "192.168.0.1"}, cfg.Server.TrustedProxies)
+		assert.Equal(t, config.RateLimitRule{Rate: 5, Burst: 3}, cfg.RateLimit.Groups["users_write"])
+	})
+
+	t.Run("解釈できない値と制約違反を全てまとめてエラーにする", func(t *testing.T) {
+		t.Setenv("SERVER_READ_TIMEOUT", "abc")
+		t.Setenv("DATABASE_MAX_CONNS", "x")
+		t.Setenv("DATABASE_MIN_CONNS", "20")
+		t.Setenv("TRACING_EXPORTER", "zipkin")
+
+		_, err := config.Load("")
+		require.Error(t, err)
+		assert.ErrorContains(t, err, `SERVER_READ_TIMEOUT: invalid duration "abc"`)
+		assert.ErrorContains(t, err, `DATABASE_MAX_CONNS: invalid 32-bit integer "x"`)
+		assert.ErrorContains(t, err, "database.min_conns: must not exceed database.max_conns (10), got 20")
+		assert.ErrorContains(t, err, `tracing.exporter: must be one of none, otlp, stdout, file, got "zipkin"`)
+	})
+
+	t.Run("YAMLファイルを読み込み環境変数で上書きする", func(t *testing.T) {
+		path := writeFile(t, "config.yaml", `
+server:
+  port: "9000"
+  read_timeout: 5s
+database:
+  max_conns: 30
+rate_limit:
+  groups:
+    users_read:
+      rate: 1
+      burst: 2
+`)
+		t.Setenv("SERVER_PORT", "9001")
+
+		cfg, err := config.Load(path)
+		require.NoError(t, err)
+		assert.Equal(t, "9001", cfg.Server.Port)
+		assert.Equal(t, 5*time.Second, cfg.Server.ReadTimeout)
+		assert.Equal(t, 10*time.Second, cfg.Server.WriteTimeout)
+		assert.Equal(t, int32(30), cfg.Database.MaxConns)
+		assert.Equal(t, config.RateLimitRule{Rate: 1, Burst: 2}, cfg.RateLimit.Groups["users_read"])
+		assert.Equal(t, config.RateLimitRule{Rate: 5, Burst: 10}, cfg.RateLimit.Groups["users_write"])
+	})
+
+	t.Run("ルートグループの一部のフィールドだけを指定した場合は他のフィールドの値を残す", func(t *testing.T) {
+		path := writeFile(t, "config.yaml", `
+rate_limit:
+  groups:
+    users_read:
+      rate: 1
+    users_write:
+      burst: 3
+    export:
+      rate: 2
+      burst: 4
+`)
+		t.Setenv("RATE_LIMIT_USERS_WRITE_RATE", "7")
+
+		cfg, err := config.Load(path)
+		require.NoError(t, err)
+		assert.Equal(t, config.RateLimitRule{Rate: 1, Burst: 40}, cfg.RateLimit.Groups["users_read"])
+		assert.Equal(t, config.RateLimitRule{Rate: 7, Burst: 3}, cfg.RateLimit.Groups["users_write"])
+		assert.Equal(t, config.RateLimitRule{Rate: 10, Burst: 20}, cfg.RateLimit.Groups["graphql"])
+		assert.Equal(t, config.RateLimitRule{Rate: 2, Burst: 4}, cfg.RateLimit.Groups["export"])
+	})
+
+	t.Run("TOMLでもルートグループの一部のフィールドだけを上書きする", func(t *testing.T) {
+		path := writeFile(t, "config.toml", `
+[rate_limit.groups.users_read]
+burst = 5
+`)
+
+		cfg, err := config.Load(path)
+		require.NoError(t, err)
+		assert.Equal(t, config.RateLimitRule{Rate: 20, Burst: 5}, cfg.RateLimit.Groups["users_read"])
+	})
+
+	t.Run("TOMLファイルを読み込む", func(t *testing.T) {
+		path := writeFile(t, "config.toml", `
+[server]
+port = "9000"
+read_timeout = "5s"
+
+[tracing]
+exporter = "stdout"
+`)
+
+		cfg, err := config.Load(path)
+		require.NoError(t, err)
+		assert.Equal(t, "9000", cfg.Server.Port)
+		assert.Equal(t, 5*time.Second, cfg.Server.ReadTimeout)
+		assert.Equal(t, "stdout", cfg.Tracing.Exporter)
+	})
+
+	t.Run("設定ファイルの未知のキーはエラー", func(t *testing.T) {
+		yamlPath := writeFile(t, "config.yaml", "server:\n  prot: \"9000\"\n")
+		_, err := config.Load(yamlPath)
+		assert.ErrorContains(t, err, "prot")
+
+		tomlPath := writeFile(t, "config.toml", "[server]\nprot = \"9000\"\n")
+		_, err = config.Load(tomlPath)
+		assert.ErrorContains(t, err, "server.prot")
+	})
+
+	t.Run("未対応の拡張子はエラー", func(t *testing.T) {
+		path := writeFile(t, "config.json", "{}")
+		_, err := config.Load(path)
+		assert.ErrorContains(t, err, "unsupported format")
+	})
+
+	t.Run("_FILEで指定したファイルの内容を値にする", func(t *testing.T) {
+		url := "postgres://app:s3cret@db:5432/go_api"
+		t.Setenv("DATABASE_URL_FILE", writeFile(t, "database_url", url+"\n"))
+		t.Setenv("SERVER_ADMIN_TOKEN_FILE", writeFile(t, "admin_token", "token"))
+
+		cfg, err := config.Load("")
+		require.NoError(t, err)
+		assert.Equal(t, url, cfg.Database.URL)
+		assert.Equal(t, "token", cfg.Server.AdminToken)
+	})
+
+	t.Run("KEYとKEY_FILEの両方が設定されている場合はエラー", func(t *testing.T) {
+		t.Setenv("SERVER_ADMIN_TOKEN", "token")
+		t.Setenv("SERVER_ADMIN_TOKEN_FILE", writeFile(t, "admin_token", "token"))
+
+		_, err := config.Load("")
+		assert.ErrorContains(t, err, "both SERVER_ADMIN_TOKEN and SERVER_ADMIN_TOKEN_FILE are set")
+	})
+
+	t.Run("_FILEのファイルが読めない場合はエラー", func(t *testing.T) {
+		t.Setenv("DATABASE_URL_FILE", filepath.Join(t.TempDir(), "missing"))
+
+		_, err := config.Load("")
+		assert.ErrorContains(t, err, "DATABASE_URL_FILE")
+	})
+}
+
+func TestPrint(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{
+			name: "URL形式のパスワードを伏せる",
+			url:  "postgres://app:s3cret@db:5432/go_api?sslmode=disable",
+			want: "postgres://app:REDACTED@db:5432/go_api?sslmode=disable",
+		},
+		{
+			name: "クエリパラメータのパスワードを伏せる",
+			url:  "postgres://db:5432/go_api?password=s3cret",
+			want: "postgres://db:5432/go_api?password=REDACTED",
+		},
+		{
+			name: "キーワード形式のパスワードを伏せる",
+			url:  "host=db user=app password='s3 cret' dbname=go_api",
+			want: "host=db user=app password=REDACTED dbname=go_api",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Default()
+			cfg.Database.URL = tt.url
+			cfg.Database.ReplicaURLs = []string{tt.url}
+			cfg.Server.AdminToken = "token"
+
+			var buf bytes.Buffer
+			require.NoError(t, config.Print(&buf, cfg))
+
+			out := buf.String()
+			assert.Contains(t, out, tt.want)
+			assert.NotContains(t, out, "s3")
+			assert.Contains(t, out, "admin_token: REDACTED")
+			// 元の設定は変更しない
+			assert.Equal(t, tt.url, cfg.Database.URL)
+			assert.Equal(t, []string{tt.url}, cfg.Database.ReplicaURLs)
+		})
+	}
+}
+
+func TestLoad_CORS(t *testing.T) {
+	t.Run("環境変数で許可するオリジンを設定できる", func(t *testing.T) {
+		t.Setenv("CORS_ALLOWED_ORIGINS", "https://app.example.com,https://*.example.org")
+		t.Setenv("CORS_ALLOW_CREDENTIALS", "true")
+
+		cfg, err := config.Load("")
+		require.NoError(t, err)
+		assert.True(t, cfg.CORS.Enabled())
+		assert.Equal(t, []string{"https://app.example.com", "https://*.example.org"}, cfg.CORS.AllowedOrigins)
+		assert.True(t, cfg.CORS.AllowCredentials)
+	})
+
+	t.Run("不正なオリジンはエラー", func(t *testing.T) {
+		t.Setenv("CORS_ALLOWED_ORIGINS", "app.example.com,https://app.example.com/path,https://a.*.example.org")
+
+		_, err := config.Load("")
+		assert.ErrorContains(t, err, `invalid origin "app.example.com"`)
+		assert.ErrorContains(t, err, `invalid origin "https://app.example.com/path"`)
+		assert.ErrorContains(t, err, `invalid origin "https://a.*.example.org"`)
+	})
+
+	t.Run("全オリジン許可と認証情報の許可は併用できない", func(t *testing.T) {
+		t.Setenv("CORS_ALLOWED_ORIGINS", "*")
+		t.Setenv("CORS_ALLOW_CREDENTIALS", "true")
+
+		_, err := config.Load("")
+		assert.ErrorContains(t, err, "cannot be combined with cors.allow_credentials")
+	})
+}
+
+func TestLoad_Errors(t *testing.T) {
+	t.Run("デフォルトは従来形式", func(t *testing.T) {
+		cfg, err := config.Load("")
+		require.NoError(t, err)
+		assert.Equal(t, "legacy", cfg.Errors.Format)
+		assert.Equal(t, "/problems/", cfg.Errors.ProblemTypeBaseURI)
+	})
+
+	t.Run("環境変数で Problem Details を指定できる", func(t *testing.T) {
+		t.Setenv("ERRORS_FORMAT", "problem")
+		t.Setenv("ERRORS_PROBLEM_TYPE_BASE_URI", "https://docs.example.com/problems/")
+
+		cfg, err := config.Load("")
+		require.NoError(t, err)
+		assert.Equal(t, "problem", cfg.Errors.Format)
+		assert.Equal(t, "https://docs.example.com/problems/", cfg.Errors.ProblemTypeBaseURI)
+	})
+
+	t.Run("不明な形式はエラー", func(t *testing.T) {
+		t.Setenv("ERRORS_FORMAT", "xml")
+
+		_, err := config.Load("")
+		assert.ErrorContains(t, err, `errors.format: must be one of legacy, problem, got "xml"`)
+	})
+}
+
+func TestLoad_GraphQL(t *testing.T) {
+	t.Run("環境変数でクエリの上限を指定できる", func(t *testing.T) {
+		t.Setenv("GRAPHQL_MAX_DEPTH", "5")
+		t.Setenv("GRAPHQL_MAX_COMPLEXITY", "0")
+		t.Setenv("GRAPHQL_GRAPHIQL", "true")
+
+		cfg, err := config.Load("")
+		require.NoError(t, err)
+		assert.Equal(t, 5, cfg.GraphQL.MaxDepth)
+		assert.Equal(t, 0, cfg.GraphQL.MaxComplexity)
+		assert.True(t, cfg.GraphQL.GraphiQL)
+	})
+
+	t.Run("負の上限はエラー", func(t *testing.T) {
+		t.Setenv("GRAPHQL_MAX_DEPTH", "-1")
+
+		_, err := config.Load("")
+		assert.ErrorContains(t, err, "graphql.max_depth: must not be negative, got -1")
+	})
+}
+
+func TestLoad_Events(t *testing.T) {
+	t.Run("環境変数で配信の設定を指定できる", func(t *testing.T) {
+		t.Setenv("EVENTS_POLL_INTERVAL", "500ms")
+		t.Setenv("EVENTS_HEARTBEAT_INTERVAL", "30s")
+		t.Setenv("EVENTS_BUFFER_SIZE", "16")
+
+		cfg, err := config.Load("")
+		require.NoError(t, err)
+		assert.Equal(t, 500*time.Millisecond, cfg.Events.PollInterval)
+		assert.Equal(t, 30*time.Second, cfg.Events.HeartbeatInterval)
+		assert.Equal(t, 16, cfg.Events.BufferSize)
+		assert.Equal(t, 100, cfg.Events.BatchSize)
+	})
+
+	t.Run("環境変数でイベントの保持期間を指定できる", func(t *testing.T) {
+		t.Setenv("EVENTS_RETENTION", "72h")
+		t.Setenv("EVENTS_PRUNE_INTERVAL", "10m")
+
+		cfg, err := config.Load("")
+		require.NoError(t, err)
+		assert.Equal(t, 72*time.Hour, cfg.Events.Retention)
+		assert.Equal(t, 10*time.Minute, cfg.Events.PruneInterval)
+	})
+
+	t.Run("負の保持期間はエラー", func(t *testing.T) {
+		t.Setenv("EVENTS_RETENTION", "-1h")
+
+		_, err := config.Load("")
+		assert.ErrorContains(t, err, "events.retention: must not be negative")
+	})
+
+	t.Run("0以下の送信待ちの上限はエラー", func(t *testing.T) {
+		t.Setenv("EVENTS_BUFFER_SIZE", "0")
+
+		_, err := config.Load("")
+		assert.ErrorContains(t, err, "events.buffer_size: must be positive, got 0")
+	})
+}
+
+func TestLoad_Notify(t *testing.T) {
+	t.Run("環境変数で通知の設定を指定できる", func(t *testing.T) {
+		t.Setenv("NOTIFY_ENABLED", "false")
+		t.Setenv("NOTIFY_RECONNECT_MIN_BACKOFF", "1s")
+		t.Setenv("NOTIFY_RECONNECT_MAX_BACKOFF", "1m")
+
+		cfg, err := config.Load("")
+		require.NoError(t, err)
+		assert.False(t, cfg.Notify.Enabled)
+		assert.Equal(t, time.Second, cfg.Notify.ReconnectMinBackoff)
+		assert.Equal(t, time.Minute, cfg.Notify.ReconnectMaxBackoff)
+	})
+
+	t.Run("再接続の待ち時間の上限が基準より短い場合はエラー", func(t *testing.T) {
+		t.Setenv("NOTIFY_RECONNECT_MIN_BACKOFF", "10s")
+		t.Setenv("NOTIFY_RECONNECT_MAX_BACKOFF", "1s")
+
+		_, err := config.Load("")
+		assert.ErrorContains(t, err, "notify.reconnect_max_backoff: must not be less than notify.reconnect_min_backoff (10s), got 1s")
+	})
+}
+
+func TestLoad_Cache(t *testing.T) {
+	t.Run("環境変数でキャッシュの設定を指定できる", func(t *testing.T) {
+		t.Setenv("CACHE_ENABLED", "true")
+		t.Setenv("CACHE_SIZE", "500")
+		t.Setenv("CACHE_TTL", "30s")
+
+		cfg, err := config.Load("")
+		require.NoError(t, err)
+		assert.True(t, cfg.Cache.Enabled)
+		assert.Equal(t, 500, cfg.Cache.Size)
+		assert.Equal(t, 30*time.Second, cfg.Cache.TTL)
+		assert.Equal(t, 5*time.Second, cfg.Cache.NegativeTTL)
+	})
+
+	t.Run("読み込みをまとめる設定は環境変数で無効にできる", func(t *testing.T) {
+		t.Setenv("COALESCE_ENABLED", "false")
+
+		cfg, err := config.Load("")
+		require.NoError(t, err)
+		assert.False(t, cfg.Coalesce.Enabled)
+	})
+
+	t.Run("まとめた問い合わせの時間の上限は環境変数で上書きでき、0はエラー", func(t *testing.T) {
+		t.Setenv("COALESCE_TIMEOUT", "3s")
+
+		cfg, err := config.Load("")
+		require.NoError(t, err)
+		assert.Equal(t, 3*time.Second, cfg.Coalesce.Timeout)
+
+		t.Setenv("COALESCE_TIMEOUT", "0s")
+		_, err = config.Load("")
+		assert.ErrorContains(t, err, "coalesce.timeout")
+	})
+
+	t.Run("0件の上限はエラー", func(t *testing.T) {
+		t.Setenv("CACHE_SIZE", "0")
+
+		_, err := config.Load("")
+		assert.ErrorContains(t, err, "cache.size: must be at least 1, got 0")
+	})
+}
+
+func TestLoad_TrustedProxies(t *testing.T) {
+	t.Run("旧名の RATE_LIMIT_TRUSTED_PROXIES も読み込む", func(t *testing.T) {
+		t.Setenv("RATE_LIMIT_TRUSTED_PROXIES", "10.0.0.0/8")
+
+		cfg, err := config.Load("")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"10.0.0.0/8"}, cfg.Server.TrustedProxies)
+	})
+
+	t.Run("両方ある場合は SERVER_TRUSTED_PROXIES を優先する", func(t *testing.T) {
+		t.Setenv("RATE_LIMIT_TRUSTED_PROXIES", "10.0.0.0/8")
+		t.Setenv("SERVER_TRUSTED_PROXIES", "192.168.0.0/16")
+
+		cfg, err := config.Load("")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"192.168.0.0/16"}, cfg.Server.TrustedProxies)
+	})
+}
+
+func TestLoad_DatabaseRetry(t *testing.T) {
+	t.Run("環境変数で接続と読み込みの再試行を設定できる", func(t *testing.T) {
+		t.Setenv("DATABASE_CONNECT_TIMEOUT", "3s")
+		t.Setenv("DATABASE_CONNECT_MAX_ATTEMPTS", "20")
+		t.Setenv("DATABASE_CONNECT_MIN_BACKOFF", "1s")
+		t.Setenv("DATABASE_CONNECT_MAX_BACKOFF", "30s")
+		t.Setenv("DATABASE_READ_RETRY_MAX_ATTEMPTS", "1")
+		t.Setenv("DATABASE_READ_RETRY_MIN_BACKOFF", "10ms")
+		t.Setenv("DATABASE_READ_RETRY_MAX_BACKOFF", "100ms")
+
+		cfg, err := config.Load("")
+		require.NoError(t, err)
+		assert.Equal(t, 3*time.Second, cfg.Database.ConnectTimeout)
+		assert.Equal(t, 20, cfg.Database.ConnectMaxAttempts)
+		assert.Equal(t, time.Second, cfg.Database.ConnectMinBackoff)
+		assert.Equal(t, 30*time.Second, cfg.Database.ConnectMaxBackoff)
+		assert.Equal(t, 1, cfg.Database.ReadRetryMaxAttempts)
+		assert.Equal(t, 10*time.Millisecond, cfg.Database.ReadRetryMinBackoff)
+		assert.Equal(t, 100*time.Millisecond, cfg.Database.ReadRetryMaxBackoff)
+	})
+
+	t.Run("不正な値はエラー", func(t *testing.T) {
+		t.Setenv("DATABASE_CONNECT_MAX_ATTEMPTS", "0")
+		t.Setenv("DATABASE_READ_RETRY_MIN_BACKOFF", "2s")
+		t.Setenv("DATABASE_READ_RETRY_MAX_BACKOFF", "1s")
+
+		_, err := config.Load("")
+		assert.ErrorContains(t, err, "database.connect_max_attempts: must be at least 1, got 0")
+		assert.ErrorContains(t, err,
+			"database.read_retry_max_backoff: must not be less than database.read_retry_min_backoff (2s), got 1s")
+	})
+}
+
+func TestLoad_Replicas(t *testing.T) {
+	t.Run("環境変数でレプリカと振り分けの設定を指定できる", func(t *testing.T) {
+		t.Setenv("DATABASE_REPLICA_URLS", "postgres://replica1/go_api, postgres://replica2/go_api")
+		t.Setenv("DATABASE_MAX_REPLICA_LAG", "3s")
+		t.Setenv("DATABASE_REPLICA_CHECK_INTERVAL", "500ms")
+		t.Setenv("DATABASE_READ_YOUR_WRITES_WINDOW", "4s")
+
+		cfg, err := config.Load("")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"postgres://replica1/go_api", "postgres://replica2/go_api"}, cfg.Database.ReplicaURLs)
+		assert.Equal(t, 3*time.Second, cfg.Database.MaxReplicaLag)
+		assert.Equal(t, 500*time.Millisecond, cfg.Database.ReplicaCheckInterval)
+		assert.Equal(t, 4*time.Second, cfg.Database.ReadYourWritesWindow)
+	})
+
+	t.Run("書き込み後にプライマリから読む期間は許容する遅延より短くできない", func(t *testing.T) {
+		t.Setenv("DATABASE_MAX_REPLICA_LAG", "5s")
+		t.Setenv("DATABASE_READ_YOUR_WRITES_WINDOW", "1s")
+
+		_, err := config.Load("")
+		assert.ErrorContains(t, err, "database.read_your_writes_window: must be at least database.max_replica_lag (5s), got 1s")
+	})
+
+	t.Run("postgres以外の保存先ではレプリカを使えない", func(t *testing.T) {
+		t.Setenv("STORAGE", "memory")
+		t.Setenv("DATABASE_REPLICA_URLS", "postgres://replica1/go_api")
+
+		_, err := config.Load("")
+		assert.ErrorContains(t, err, `database.replica_urls: requires storage postgres, got storage "memory"`)
+	})
+}
+
+func TestLoad_Storage(t *testing.T) {
+	t.Run("環境変数で保存先をmemoryにできる", func(t *testing.T) {
+		t.Setenv("STORAGE", "memory")
+
+		cfg, err := config.Load("")
+		require.NoError(t, err)
+		assert.Equal(t, "memory", cfg.Storage)
+	})
+
+	t.Run("未知の保存先はエラー", func(t *testing.T) {
+		t.Setenv("STORAGE", "mysql")
+
+		_, err := config.Load("")
+		assert.ErrorContains(t, err, `storage: must be one of postgres, sqlite, memory, got "mysql"`)
+	})
+
+	t.Run("環境変数で保存先をsqliteにしてファイルのパスを指定できる", func(t *testing.T) {
+		t.Setenv("STORAGE", "sqlite")
+		t.Setenv("SQLITE_PATH", "/var/lib/go-api/users.db")
+
+		cfg, err := config.Load("")
+		require.NoError(t, err)
+		assert.Equal(t, "sqlite", cfg.Storage)
+		assert.Equal(t, "/var/lib/go-api/users.db", cfg.SQLite.Path)
+	})
+
+	t.Run("sqliteではファイルのパスが必須", func(t *testing.T) {
+		path := writeFile(t, "config.yaml", "storage: sqlite\nsqlite:\n  path: \"\"\n")
+
+		_, err := config.Load(path)
+		assert.ErrorContains(t, err, "sqlite.path: must not be empty")
+	})
+
+	t.Run("memoryではレート制限をpostgresに保存できない", func(t *testing.T) {
+		t.Setenv("STORAGE", "memory")
+		t.Setenv("RATE_LIMIT_BACKEND", "postgres")
+
+		_, err := config.Load("")
+		assert.ErrorContains(t, err, `rate_limit.backend: postgres requires storage postgres, got storage "memory"`)
+	})
+}
@@ -0,0 +1,206 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// loadEnv は環境変数で設定を上書きする。解釈できない値は全てエラーとして返す。
+func loadEnv(cfg *Config, e *env) []error {
+	e.string("STORAGE", &cfg.Storage)
+	e.string("SQLITE_PATH", &cfg.SQLite.Path)
+
+	e.string("SERVER_PORT", &cfg.Server.Port)
+	e.duration("SERVER_READ_TIMEOUT", &cfg.Server.ReadTimeout)
+	e.duration("SERVER_WRITE_TIMEOUT", &cfg.Server.WriteTimeout)
+	e.duration("SERVER_IDLE_TIMEOUT", &cfg.Server.IdleTimeout)
+	// RATE_LIMIT_TRUSTED_PROXIES は SERVER_TRUSTED_PROXIES の旧名。両方ある場合は新しい名前を優先する
+	e.list("RATE_LIMIT_TRUSTED_PROXIES", &cfg.Server.TrustedProxies)
+	e.list("SERVER_TRUSTED_PROXIES", &cfg.Server.TrustedProxies)
+	e.string("SERVER_ADMIN_ADDR", &cfg.Server.AdminAddr)
+	e.string("SERVER_GRPC_ADDR", &cfg.Server.GRPCAddr)
+	e.string("SERVER_ADMIN_TOKEN", &cfg.Server.AdminToken)
+	e.duration("SERVER_SHUTDOWN_DRAIN_DELAY", &cfg.Server.ShutdownDrainDelay)
+	e.duration("SERVER_SHUTDOWN_TIMEOUT", &cfg.Server.ShutdownTimeout)
+	e.string("SERVER_TLS_CERT_FILE", &cfg.Server.TLS.CertFile)
+	e.string("SERVER_TLS_KEY_FILE", &cfg.Server.TLS.KeyFile)
+	e.string("SERVER_TLS_CLIENT_CA_FILE", &cfg.Server.TLS.ClientCAFile)
+	e.duration("SERVER_TLS_RELOAD_INTERVAL", &cfg.Server.TLS.ReloadInterval)
+	e.bool("SERVER_H2C", &cfg.Server.H2C)
+	e.string("SERVER_UNIX_SOCKET", &cfg.Server.UnixSocket)
+	e.string("SERVER_UNIX_SOCKET_MODE", &cfg.Server.UnixSocketMode)
+
+	e.string("DATABASE_URL", &cfg.Database.URL)
+	e.int32("DATABASE_MAX_CONNS", &cfg.Database.MaxConns)
+	e.int32("DATABASE_MIN_CONNS", &cfg.Database.MinConns)
+	e.duration("DATABASE_MAX_CONN_LIFETIME", &cfg.Database.MaxConnLifetime)
+	e.duration("DATABASE_CONNECT_TIMEOUT", &cfg.Database.ConnectTimeout)
+	e.int("DATABASE_CONNECT_MAX_ATTEMPTS", &cfg.Database.ConnectMaxAttempts)
+	e.duration("DATABASE_CONNECT_MIN_BACKOFF", &cfg.Database.ConnectMinBackoff)
+	e.duration("DATABASE_CONNECT_MAX_BACKOFF", &cfg.Database.ConnectMaxBackoff)
+	e.int("DATABASE_READ_RETRY_MAX_ATTEMPTS", &cfg.Database.ReadRetryMaxAttempts)
+	e.duration("DATABASE_READ_RETRY_MIN_BACKOFF", &cfg.Database.ReadRetryMinBackoff)
+	e.duration("DATABASE_READ_RETRY_MAX_BACKOFF", &cfg.Database.ReadRetryMaxBackoff)
+	e.list("DATABASE_REPLICA_URLS", &cfg.Database.ReplicaURLs)
+	e.duration("DATABASE_MAX_REPLICA_LAG", &cfg.Database.MaxReplicaLag)
+	e.duration("DATABASE_REPLICA_CHECK_INTERVAL", &cfg.Database.ReplicaCheckInterval)
+	e.duration("DATABASE_READ_YOUR_WRITES_WINDOW", &cfg.Database.ReadYourWritesWindow)
+
+	e.bool("RATE_LIMIT_ENABLED", &cfg.RateLimit.Enabled)
+	e.string("RATE_LIMIT_BACKEND", &cfg.RateLimit.Backend)
+	// グループごとの上限は RATE_LIMIT_<グループ名>_RATE / _BURST で上書きする
+	for _, name := range slices.Sorted(maps.Keys(cfg.RateLimit.Groups)) {
+		rule := cfg.RateLimit.Groups[name]
+		prefix := "RATE_LIMIT_" + strings.ToUpper(name)
+		e.float(prefix+"_RATE", &rule.Rate)
+		e.int(prefix+"_BURST", &rule.Burst)
+		cfg.RateLimit.Groups[name] = rule
+	}
+
+	e.float("ACCESS_LOG_SUCCESS_SAMPLE_RATE", &cfg.AccessLog.SuccessSampleRate)
+	e.bool("METRICS_ENABLED", &cfg.Metrics.Enabled)
+
+	e.string("TRACING_EXPORTER", &cfg.Tracing.Exporter)
+	e.string("TRACING_OTLP_ENDPOINT", &cfg.Tracing.OTLPEndpoint)
+	e.bool("TRACING_OTLP_INSECURE", &cfg.Tracing.OTLPInsecure)
+	e.string("TRACING_FILE_PATH", &cfg.Tracing.FilePath)
+	e.float("TRACING_SAMPLE_RATIO", &cfg.Tracing.SampleRatio)
+	e.string("TRACING_SERVICE_NAME", &cfg.Tracing.ServiceName)
+
+	e.duration("HEALTH_CHECK_TIMEOUT", &cfg.Health.CheckTimeout)
+	e.duration("HEALTH_CACHE_TTL", &cfg.Health.CacheTTL)
+
+	e.list("CORS_ALLOWED_ORIGINS", &cfg.CORS.AllowedOrigins)
+	e.list("CORS_ALLOWED_METHODS", &cfg.CORS.AllowedMethods)
+	e.list("CORS_ALLOWED_HEADERS", &cfg.CORS.AllowedHeaders)
+	e.list("CORS_EXPOSED_HEADERS", &cfg.CORS.ExposedHeaders)
+	e.bool("CORS_ALLOW_CREDENTIALS", &cfg.CORS.AllowCredentials)
+	e.duration("CORS_MAX_AGE", &cfg.CORS.MaxAge)
+
+	e.string("ERRORS_FORMAT", &cfg.Errors.Format)
+	e.string("ERRORS_PROBLEM_TYPE_BASE_URI", &cfg.Errors.ProblemTypeBaseURI)
+
+	e.int("GRAPHQL_MAX_DEPTH", &cfg.GraphQL.MaxDepth)
+	e.int("GRAPHQL_MAX_COMPLEXITY", &cfg.GraphQL.MaxComplexity)
+	e.bool("GRAPHQL_GRAPHIQL", &cfg.GraphQL.GraphiQL)
+
+	e.duration("EVENTS_POLL_INTERVAL", &cfg.Events.PollInterval)
+	e.int("EVENTS_BATCH_SIZE", &cfg.Events.BatchSize)
+	e.duration("EVENTS_HEARTBEAT_INTERVAL", &cfg.Events.HeartbeatInterval)
+	e.int("EVENTS_BUFFER_SIZE", &cfg.Events.BufferSize)
+	e.duration("EVENTS_WRITE_TIMEOUT", &cfg.Events.WriteTimeout)
+	e.duration("EVENTS_RETENTION", &cfg.Events.Retention)
+	e.duration("EVENTS_PRUNE_INTERVAL", &cfg.Events.PruneInterval)
+
+	e.bool("NOTIFY_ENABLED", &cfg.Notify.Enabled)
+	e.duration("NOTIFY_RECONNECT_MIN_BACKOFF", &cfg.Notify.ReconnectMinBackoff)
+	e.duration("NOTIFY_RECONNECT_MAX_BACKOFF", &cfg.Notify.ReconnectMaxBackoff)
+
+	e.bool("CACHE_ENABLED", &cfg.Cache.Enabled)
+	e.int("CACHE_SIZE", &cfg.Cache.Size)
+	e.duration("CACHE_TTL", &cfg.Cache.TTL)
+	e.duration("CACHE_NEGATIVE_TTL", &cfg.Cache.NegativeTTL)
+
+	e.bool("COALESCE_ENABLED", &cfg.Coalesce.Enabled)
+	e.duration("COALESCE_TIMEOUT", &cfg.Coalesce.Timeout)
+
+	return e.errs
+}
+
+// env は環境変数を参照し、解釈できなかった値をエラーとして蓄積する。
+// KEY_FILE が設定されている場合はそのファイルの内容（末尾の改行を除く）を KEY の値とする。
+type env struct {
+	lookup   func(string) (string, bool)
+	readFile func(string) ([]byte, error)
+	errs     []error
+}
+
+func newEnv() *env {
+	return &env{lookup: os.LookupEnv, readFile: os.ReadFile}
+}
+
+// get は KEY または KEY_FILE から値を取得する。空文字列は未設定として扱う。
+func (e *env) get(key string) (string, bool) {
+	v, _ := e.lookup(key)
+	path, _ := e.lookup(key + "_FILE")
+
+	switch {
+	case v != "" && path != "":
+		e.errs = append(e.errs, fmt.Errorf("%s: both %s and %s_FILE are set", key, key, key))
+		return "", false
+	case path != "":
+		b, err := e.readFile(path)
+		if err != nil {
+			e.errs = append(e.errs, fmt.Errorf("%s_FILE: %w", key, err))
+			return "", false
+		}
+		return strings.TrimRight(string(b), "\r\n"), true
+	default:
+		return v, v != ""
+	}
+}
+
+func (e *env) string(key string, dst *string) {
+	if v, ok := e.get(key); ok {
+		*dst = v
+	}
+}
+
+func (e *env) duration(key string, dst *time.Duration) {
+	parseEnv(e, key, dst, "duration", time.ParseDuration)
+}
+
+func (e *env) bool(key string, dst *bool) {
+	parseEnv(e, key, dst, "boolean", strconv.ParseBool)
+}
+
+func (e *env) int(key string, dst *int) {
+	parseEnv(e, key, dst, "integer", strconv.Atoi)
+}
+
+func (e *env) int32(key string, dst *int32) {
+	parseEnv(e, key, dst, "32-bit integer", func(s string) (int32, error) {
+		i, err := strconv.ParseInt(s, 10, 32)
+		return int32(i), err
+	})
+}
+
+func (e *env) float(key string, dst *float64) {
+	parseEnv(e, key, dst, "number", func(s string) (float64, error) {
+		return strconv.ParseFloat(s, 64)
+	})
+}
+
+// list はカンマ区切りの値をスライスとして読み込む。
+func (e *env) list(key string, dst *[]string) {
+	v, ok := e.get(key)
+	if !ok {
+		return
+	}
+	var list []string
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			list = append(list, s)
+		}
+	}
+	*dst = list
+}
+
+// parseEnv は環境変数を parse で解釈して dst に設定する。失敗した場合は dst を変更せずエラーを蓄積する。
+func parseEnv[T any](e *env, key string, dst *T, kind string, parse func(string) (T, error)) {
+	v, ok := e.get(key)
+	if !ok {
+		return
+	}
+	parsed, err := parse(v)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s: invalid %s %q", key, kind, v))
+		return
+	}
+	*dst = parsed
+}
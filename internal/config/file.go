@@ -0,0 +1,96 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// loadFile は設定ファイルの値で cfg を上書きする。形式は拡張子（.yaml/.yml/.toml）で判定する。
+// 未知のキーは設定ミスとみなしてエラーにする。
+func loadFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	groups := maps.Clone(cfg.RateLimit.Groups)
+	var partial rateLimitGroupsFile
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		if err := decodeYAML(data, cfg); err != nil {
+			return err
+		}
+		_ = yaml.Unmarshal(data, &partial)
+	case ".toml":
+		if err := decodeTOML(data, cfg); err != nil {
+			return err
+		}
+		_, _ = toml.Decode(string(data), &partial)
+	default:
+		return fmt.Errorf("config file %s: unsupported format %q (use .yaml, .yml or .toml)", path, ext)
+	}
+	cfg.RateLimit.Groups = partial.merge(groups)
+	return nil
+}
+
+// rateLimitGroupsFile は設定ファイルの rate_limit.groups のうち、指定されたフィールドだけを表す。
+// マップの要素はデコード時に丸ごと置き換わるため、指定のないフィールドを元の値に戻すのに使う。
+type rateLimitGroupsFile struct {
+	RateLimit struct {
+		Groups map[string]struct {
+			Rate  *float64 `yaml:"rate" toml:"rate"`
+			Burst *int     `yaml:"burst" toml:"burst"`
+		} `yaml:"groups" toml:"groups"`
+	} `yaml:"rate_limit" toml:"rate_limit"`
+}
+
+// merge は groups（読み込み前の値）に設定ファイルで指定されたフィールドだけを上書きしたものを返す。
+func (f rateLimitGroupsFile) merge(groups map[string]RateLimitRule) map[string]RateLimitRule {
+	if groups == nil {
+		groups = make(map[string]RateLimitRule, len(f.RateLimit.Groups))
+	}
+	for name, g := range f.RateLimit.Groups {
+		rule := groups[name]
+		if g.Rate != nil {
+			rule.Rate = *g.Rate
+		}
+		if g.Burst != nil {
+			rule.Burst = *g.Burst
+		}
+		groups[name] = rule
+	}
+	return groups
+}
+
+func decodeYAML(data []byte, cfg *Config) error {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("parse config file: %w", err)
+	}
+	return nil
+}
+
+func decodeTOML(data []byte, cfg *Config) error {
+	md, err := toml.Decode(string(data), cfg)
+	if err != nil {
+		return fmt.Errorf("parse config file: %w", err)
+	}
+	if undecoded := md.Undecoded(); len(undecoded) > 0 {
+		keys := make([]string, len(undecoded))
+		for i, k := range undecoded {
+			keys[i] = k.String()
+		}
+		return fmt.Errorf("parse config file: unknown keys %s", strings.Join(keys, ", "))
+	}
+	return nil
+}
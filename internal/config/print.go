@@ -0,0 +1,57 @@
+package config
+
+import (
+	"io"
+	"net/url"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// redacted は秘匿情報を伏せた値。
+const redacted = "REDACTED"
+
+// Print は秘匿情報（DBのパスワード、管理用トークン）を伏せた設定をYAMLで書き出す。
+func Print(w io.Writer, cfg *Config) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(cfg.Redacted()); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// Redacted は秘匿情報を伏せた設定のコピーを返す。
+func (c *Config) Redacted() *Config {
+	r := *c
+	r.Database.URL = redactDatabaseURL(c.Database.URL)
+	if len(c.Database.ReplicaURLs) > 0 {
+		r.Database.ReplicaURLs = make([]string, len(c.Database.ReplicaURLs))
+		for i, u := range c.Database.ReplicaURLs {
+			r.Database.ReplicaURLs[i] = redactDatabaseURL(u)
+		}
+	}
+	if r.Server.AdminToken != "" {
+		r.Server.AdminToken = redacted
+	}
+	return &r
+}
+
+// dsnPassword はキーワード形式の接続文字列（host=... password=...）のパスワード。
+var dsnPassword = regexp.MustCompile(`(password\s*=\s*)('(?:[^'\\]|\\.)*'|\S+)`)
+
+// redactDatabaseURL は接続文字列のパスワードを伏せる。URL形式とキーワード形式の両方に対応する。
+func redactDatabaseURL(s string) string {
+	if u, err := url.Parse(s); err == nil && u.Scheme != "" {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), redacted)
+		}
+		q := u.Query()
+		if q.Has("password") {
+			q.Set("password", redacted)
+			u.RawQuery = q.Encode()
+		}
+		return u.String()
+	}
+	return dsnPassword.ReplaceAllString(s, "${1}"+redacted)
+}
@@ -0,0 +1,151 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// validate は値の範囲や項目間の整合性を検証し、見つかった全ての問題を返す。
+func (c *Config) validate() []error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+	nonNegative := func(name string, d time.Duration) {
+		check(d >= 0, "%s: must not be negative, got %s", name, d)
+	}
+	ratio := func(name string, v float64) {
+		check(v >= 0 && v <= 1, "%s: must be between 0 and 1, got %v", name, v)
+	}
+	// backoff は prefix_min_backoff・prefix_max_backoff の組を検証する
+	backoff := func(prefix string, minBackoff, maxBackoff time.Duration) {
+		check(minBackoff > 0, "%s_min_backoff: must be positive, got %s", prefix, minBackoff)
+		check(maxBackoff >= minBackoff,
+			"%s_max_backoff: must not be less than %s_min_backoff (%s), got %s", prefix, prefix, minBackoff, maxBackoff)
+	}
+
+	check(slices.Contains([]string{"postgres", "sqlite", "memory"}, c.Storage),
+		"storage: must be one of postgres, sqlite, memory, got %q", c.Storage)
+	check(c.Storage != "sqlite" || c.SQLite.Path != "", "sqlite.path: must not be empty")
+
+	port, err := strconv.Atoi(c.Server.Port)
+	check(err == nil && port > 0 && port <= 65535, "server.port: must be a port number between 1 and 65535, got %q", c.Server.Port)
+	nonNegative("server.read_timeout", c.Server.ReadTimeout)
+	nonNegative("server.write_timeout", c.Server.WriteTimeout)
+	nonNegative("server.idle_timeout", c.Server.IdleTimeout)
+	nonNegative("server.shutdown_drain_delay", c.Server.ShutdownDrainDelay)
+	check(c.Server.ShutdownTimeout > 0, "server.shutdown_timeout: must be positive, got %s", c.Server.ShutdownTimeout)
+	if tls := c.Server.TLS; tls.CertFile != "" || tls.KeyFile != "" {
+		check(tls.CertFile != "" && tls.KeyFile != "", "server.tls: cert_file and key_file must be set together")
+		check(tls.ReloadInterval > 0, "server.tls.reload_interval: must be positive, got %s", tls.ReloadInterval)
+	}
+	check(c.Server.TLS.ClientCAFile == "" || c.Server.TLS.Enabled(), "server.tls.client_ca_file: requires server.tls.cert_file")
+	_, err = strconv.ParseUint(c.Server.UnixSocketMode, 8, 32)
+	check(err == nil, "server.unix_socket_mode: must be an octal file mode, got %q", c.Server.UnixSocketMode)
+	for _, p := range c.Server.TrustedProxies {
+		_, prefixErr := netip.ParsePrefix(p)
+		_, addrErr := netip.ParseAddr(p)
+		check(prefixErr == nil || addrErr == nil, "server.trusted_proxies: invalid CIDR or IP %q", p)
+	}
+
+	check(c.Database.URL != "", "database.url: must not be empty")
+	check(c.Database.MaxConns >= 1, "database.max_conns: must be at least 1, got %d", c.Database.MaxConns)
+	check(c.Database.MinConns >= 0, "database.min_conns: must not be negative, got %d", c.Database.MinConns)
+	check(c.Database.MinConns <= c.Database.MaxConns,
+		"database.min_conns: must not exceed database.max_conns (%d), got %d", c.Database.MaxConns, c.Database.MinConns)
+	nonNegative("database.max_conn_lifetime", c.Database.MaxConnLifetime)
+	check(c.Database.ConnectTimeout > 0, "database.connect_timeout: must be positive, got %s", c.Database.ConnectTimeout)
+	check(c.Database.ConnectMaxAttempts >= 1,
+		"database.connect_max_attempts: must be at least 1, got %d", c.Database.ConnectMaxAttempts)
+	backoff("database.connect", c.Database.ConnectMinBackoff, c.Database.ConnectMaxBackoff)
+	check(c.Database.ReadRetryMaxAttempts >= 1,
+		"database.read_retry_max_attempts: must be at least 1, got %d", c.Database.ReadRetryMaxAttempts)
+	backoff("database.read_retry", c.Database.ReadRetryMinBackoff, c.Database.ReadRetryMaxBackoff)
+	for i, u := range c.Database.ReplicaURLs {
+		check(u != "", "database.replica_urls[%d]: must not be empty", i)
+	}
+	check(len(c.Database.ReplicaURLs) == 0 || c.Storage == "postgres",
+		"database.replica_urls: requires storage postgres, got storage %q", c.Storage)
+	check(c.Database.MaxReplicaLag > 0, "database.max_replica_lag: must be positive, got %s", c.Database.MaxReplicaLag)
+	check(c.Database.ReplicaCheckInterval > 0,
+		"database.replica_check_interval: must be positive, got %s", c.Database.ReplicaCheckInterval)
+	// 振り分け先に残るレプリカの遅延より短いと、書き込んだ直後の読み込みで古い値を返しうる
+	check(c.Database.ReadYourWritesWindow >= c.Database.MaxReplicaLag,
+		"database.read_your_writes_window: must be at least database.max_replica_lag (%s), got %s",
+		c.Database.MaxReplicaLag, c.Database.ReadYourWritesWindow)
+
+	check(slices.Contains([]string{"memory", "postgres"}, c.RateLimit.Backend),
+		"rate_limit.backend: must be one of memory, postgres, got %q", c.RateLimit.Backend)
+	check(c.RateLimit.Backend != "postgres" || c.Storage == "postgres",
+		"rate_limit.backend: postgres requires storage postgres, got storage %q", c.Storage)
+	for _, name := range slices.Sorted(maps.Keys(c.RateLimit.Groups)) {
+		rule := c.RateLimit.Groups[name]
+		check(rule.Rate > 0, "rate_limit.groups.%s.rate: must be positive, got %v", name, rule.Rate)
+		check(rule.Burst >= 1, "rate_limit.groups.%s.burst: must be at least 1, got %d", name, rule.Burst)
+	}
+
+	ratio("access_log.success_sample_rate", c.AccessLog.SuccessSampleRate)
+
+	check(slices.Contains([]string{"none", "otlp", "stdout", "file"}, c.Tracing.Exporter),
+		"tracing.exporter: must be one of none, otlp, stdout, file, got %q", c.Tracing.Exporter)
+	check(c.Tracing.Exporter != "file" || c.Tracing.FilePath != "", "tracing.file_path: must not be empty when tracing.exporter is file")
+	ratio("tracing.sample_ratio", c.Tracing.SampleRatio)
+	check(c.Tracing.ServiceName != "", "tracing.service_name: must not be empty")
+
+	check(c.Health.CheckTimeout > 0, "health.check_timeout: must be positive, got %s", c.Health.CheckTimeout)
+	nonNegative("health.cache_ttl", c.Health.CacheTTL)
+
+	for _, o := range c.CORS.AllowedOrigins {
+		if o == "*" {
+			check(!c.CORS.AllowCredentials, "cors.allowed_origins: \"*\" cannot be combined with cors.allow_credentials")
+			continue
+		}
+		check(validOriginPattern(o), "cors.allowed_origins: invalid origin %q (use scheme://host[:port] or scheme://*.domain)", o)
+	}
+	check(!c.CORS.Enabled() || len(c.CORS.AllowedMethods) > 0, "cors.allowed_methods: must not be empty")
+	nonNegative("cors.max_age", c.CORS.MaxAge)
+
+	check(slices.Contains([]string{"legacy", "problem"}, c.Errors.Format),
+		"errors.format: must be one of legacy, problem, got %q", c.Errors.Format)
+	check(c.Errors.ProblemTypeBaseURI != "", "errors.problem_type_base_uri: must not be empty")
+
+	check(c.GraphQL.MaxDepth >= 0, "graphql.max_depth: must not be negative, got %d", c.GraphQL.MaxDepth)
+	check(c.GraphQL.MaxComplexity >= 0, "graphql.max_complexity: must not be negative, got %d", c.GraphQL.MaxComplexity)
+
+	check(c.Events.PollInterval > 0, "events.poll_interval: must be positive, got %s", c.Events.PollInterval)
+	check(c.Events.BatchSize > 0, "events.batch_size: must be positive, got %d", c.Events.BatchSize)
+	check(c.Events.HeartbeatInterval > 0, "events.heartbeat_interval: must be positive, got %s", c.Events.HeartbeatInterval)
+	check(c.Events.BufferSize > 0, "events.buffer_size: must be positive, got %d", c.Events.BufferSize)
+	check(c.Events.WriteTimeout > 0, "events.write_timeout: must be positive, got %s", c.Events.WriteTimeout)
+	nonNegative("events.retention", c.Events.Retention)
+	check(c.Events.PruneInterval > 0, "events.prune_interval: must be positive, got %s", c.Events.PruneInterval)
+
+	backoff("notify.reconnect", c.Notify.ReconnectMinBackoff, c.Notify.ReconnectMaxBackoff)
+
+	check(c.Cache.Size >= 1, "cache.size: must be at least 1, got %d", c.Cache.Size)
+	check(c.Cache.TTL > 0, "cache.ttl: must be positive, got %s", c.Cache.TTL)
+	check(c.Cache.NegativeTTL > 0, "cache.negative_ttl: must be positive, got %s", c.Cache.NegativeTTL)
+
+	check(c.Coalesce.Timeout > 0, "coalesce.timeout: must be positive, got %s", c.Coalesce.Timeout)
+
+	return errs
+}
+
+// validOriginPattern はオリジン（scheme://host[:port]）またはサブドメインのワイルドカード（scheme://*.domain）かを返す。
+func validOriginPattern(s string) bool {
+	scheme, host, ok := strings.Cut(s, "://")
+	if !ok || scheme == "" || host == "" || strings.ContainsAny(host, "/?#@") {
+		return false
+	}
+	if strings.Contains(host, "*") {
+		return strings.HasPrefix(host, "*.") && !strings.Contains(host[1:], "*")
+	}
+	return true
+}
@@ -2,20 +2,137 @@
 package di
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
+
+	"go-api/db/migrations"
+	"go-api/internal/application"
+	usecase "go-api/internal/application/user"
+	"go-api/internal/config"
+	"go-api/internal/domain/user"
+	"go-api/internal/health"
+	"go-api/internal/infrastructure/database"
+	"go-api/internal/infrastructure/notify"
+	"go-api/internal/infrastructure/ratelimit"
+	"go-api/internal/metrics"
+	httperrors "go-api/internal/presentation/http/errors"
+	"go-api/internal/presentation/http/middleware"
+	"go-api/internal/tracing"
 )
 
 // Container は依存関係のコンテナ。
 type Container struct {
+	cfg    *config.Config
 	pool   *pgxpool.Pool
 	logger *slog.Logger
+
+	rateLimiter ratelimit.Limiter
+	clientIPs   *middleware.ClientIPResolver
+
+	registry    *prometheus.Registry
+	httpMetrics *metrics.HTTP
+	observers   []application.Observer
+
+	tracerProvider trace.TracerProvider
+
+	readiness *health.Readiness
+	health    *health.Registry
+
+	storage    storage
+	users      user.UserRepository
+	userEvents *usecase.EventFeed
+	notifier   *notify.Listener
+	// closers は Close で逆順に実行されるバックグラウンド処理の停止関数。
+	closers []func(context.Context) error
 }
 
-// NewContainer はコンテナを生成する。
-func NewContainer(pool *pgxpool.Pool, logger *slog.Logger) *Container {
-	return &Container{pool: pool, logger: logger}
+// NewContainer はコンテナを生成する。registry はメトリクスを登録するレジストリで、メトリクスが無効な場合は nil。
+func NewContainer(cfg *config.Config, dbs Databases, registry *prometheus.Registry, tp trace.TracerProvider, logger *slog.Logger) (*Container, error) {
+	pool := dbs.Primary
+	c := &Container{
+		cfg:            cfg,
+		pool:           pool,
+		logger:         logger,
+		registry:       registry,
+		tracerProvider: tp,
+		readiness:      health.NewReadiness(),
+	}
+
+	store, err := newStorage(cfg.Storage, dbs, database.RouterOptions{
+		MaxLag:               cfg.Database.MaxReplicaLag,
+		CheckInterval:        cfg.Database.ReplicaCheckInterval,
+		ReadYourWritesWindow: cfg.Database.ReadYourWritesWindow,
+		Logger:               logger,
+	}, c.newRetryPolicy())
+	if err != nil {
+		return nil, err
+	}
+	c.storage = store
+
+	limiter, err := newRateLimiter(cfg.RateLimit, pool, logger)
+	if err != nil {
+		return nil, err
+	}
+	c.rateLimiter = limiter
+	if l, ok := limiter.(*ratelimit.PostgresLimiter); ok {
+		c.goBackground(l.Run)
+	}
+
+	migrationVersion, err := migrations.LatestVersion()
+	if err != nil {
+		return nil, err
+	}
+	c.health = health.NewRegistry(c.readiness, health.Options{
+		Timeout:  cfg.Health.CheckTimeout,
+		CacheTTL: cfg.Health.CacheTTL,
+	})
+	if pool != nil {
+		c.health.Register("database", database.PingCheck(pool))
+		c.health.Register("migrations", database.MigrationCheck(pool, migrationVersion))
+	}
+	if dbs.SQLite != nil {
+		c.health.Register("database", database.SQLitePingCheck(dbs.SQLite))
+	}
+
+	clientIPs, err := middleware.NewClientIPResolver(cfg.Server.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+	c.clientIPs = clientIPs
+
+	if cfg.Tracing.Enabled() {
+		c.observers = append(c.observers, tracing.NewUsecase(tp, httperrors.CodeFromError))
+	}
+
+	if c.registry != nil {
+		if pool != nil {
+			c.registry.MustRegister(metrics.NewPoolCollector(pool))
+		}
+		if store.router != nil {
+			c.registry.MustRegister(metrics.NewReplicaCollector(store.router))
+		}
+		c.httpMetrics = metrics.NewHTTP(c.registry)
+		c.observers = append(c.observers, metrics.NewUsecase(c.registry, httperrors.CodeFromError))
+	}
+
+	if store.router != nil {
+		c.goBackground(store.router.Run)
+	}
+	c.startUserEventFeed()
+	// 他のレプリカと共有するのはPostgreSQLだけのため、それ以外の保存先では通知を受けない
+	if cfg.Notify.Enabled && cfg.Storage == "postgres" {
+		c.newNotifier()
+	}
+	c.users = c.newUserRepository()
+	c.startNotifier()
+
+	return c, nil
 }
 
 // Logger はロガーを返す。
@@ -27,3 +144,56 @@ func (c *Container) Logger() *slog.Logger {
 func (c *Container) Pool() *pgxpool.Pool {
 	return c.pool
 }
+
+// Readiness はトラフィックを受け付けられるかどうかの状態を返す。
+func (c *Container) Readiness() *health.Readiness {
+	return c.readiness
+}
+
+// Close はコンテナが起動したバックグラウンド処理を生成と逆の順に停止する。
+// データベースの接続は呼び出し元が所有するため閉じない。
+func (c *Container) Close(ctx context.Context) error {
+	var errs []error
+	for i := len(c.closers) - 1; i >= 0; i-- {
+		errs = append(errs, c.closers[i](ctx))
+	}
+	return errors.Join(errs...)
+}
+
+// goBackground は run をゴルーチンで実行し、Close で ctx をキャンセルして終了を待つよう登録する。
+func (c *Container) goBackground(run func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		run(ctx)
+	}()
+	c.closers = append(c.closers, func(ctx context.Context) error {
+		cancel()
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// observer はユースケースに渡す Observer を返す。
+func (c *Container) observer() application.Observer {
+	return application.Observers(c.observers...)
+}
+
+func newRateLimiter(cfg config.RateLimitConfig, pool *pgxpool.Pool, logger *slog.Logger) (ratelimit.Limiter, error) {
+	switch cfg.Backend {
+	case "memory":
+		return ratelimit.NewMemoryLimiter(), nil
+	case "postgres":
+		if pool == nil {
+			return nil, errors.New("postgres rate limit backend requires a database pool")
+		}
+		return ratelimit.NewPostgresLimiter(pool, logger), nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit backend %q", cfg.Backend)
+	}
+}
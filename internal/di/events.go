@@ -0,0 +1,36 @@
+package di
+
+import (
+	usecase "go-api/internal/application/user"
+	userhandler "go-api/internal/presentation/http/handler/user"
+)
+
+// startUserEventFeed はユーザーの変更イベントの配信と、保持期間を過ぎたイベントの削除を開始し、Close で停止するよう登録する。
+func (c *Container) startUserEventFeed() {
+	c.userEvents = usecase.NewEventFeed(c.storage.events, usecase.FeedOptions{
+		PollInterval: c.cfg.Events.PollInterval,
+		BatchSize:    c.cfg.Events.BatchSize,
+		Logger:       c.logger,
+	}, usecase.WithObserver(c.observer()))
+	c.goBackground(c.userEvents.Run)
+
+	if c.cfg.Events.Retention > 0 {
+		retention := usecase.NewEventRetention(c.storage.events, usecase.RetentionOptions{
+			Retention: c.cfg.Events.Retention,
+			Interval:  c.cfg.Events.PruneInterval,
+			Logger:    c.logger,
+		}, usecase.WithObserver(c.observer()))
+		c.goBackground(retention.Run)
+	}
+}
+
+// UserEventsHandler はユーザーの変更イベントの配信ハンドラーを生成する。
+// 停止処理の開始（準備完了の取り下げ）で配信中の接続を終了する。
+func (c *Container) UserEventsHandler() *userhandler.EventsHandler {
+	return userhandler.NewEventsHandler(c.userEvents, userhandler.EventsOptions{
+		HeartbeatInterval: c.cfg.Events.HeartbeatInterval,
+		BufferSize:        c.cfg.Events.BufferSize,
+		WriteTimeout:      c.cfg.Events.WriteTimeout,
+		Shutdown:          c.readiness.Done(),
+	}, c.logger)
+}
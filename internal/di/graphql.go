@@ -0,0 +1,25 @@
+package di
+
+import (
+	usecase "go-api/internal/application/user"
+	graphqlapi "go-api/internal/presentation/graphql"
+)
+
+// GraphQLHandler はGraphQLのハンドラーを生成する。
+func (c *Container) GraphQLHandler() *graphqlapi.Handler {
+	repo := c.users
+	opt := usecase.WithObserver(c.observer())
+	return graphqlapi.NewHandler(graphqlapi.Usecases{
+		GetMany: usecase.NewGetUsersUsecase(repo, opt),
+		List:    usecase.NewListUsersUsecase(repo, opt),
+		Create:  usecase.NewCreateUserUsecase(repo, opt),
+		Update:  usecase.NewUpdateUserUsecase(repo, opt),
+		Delete:  usecase.NewDeleteUserUsecase(repo, opt),
+	}, graphqlapi.Options{
+		Limits: graphqlapi.Limits{
+			MaxDepth:      c.cfg.GraphQL.MaxDepth,
+			MaxComplexity: c.cfg.GraphQL.MaxComplexity,
+		},
+		GraphiQL: c.cfg.GraphQL.GraphiQL,
+	}, c.logger)
+}
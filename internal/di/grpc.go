@@ -0,0 +1,45 @@
+package di
+
+import (
+	usecase "go-api/internal/application/user"
+	"go-api/internal/presentation/grpc/gen/userv1"
+	"go-api/internal/presentation/grpc/interceptor"
+	healthservice "go-api/internal/presentation/grpc/service/health"
+	userservice "go-api/internal/presentation/grpc/service/user"
+)
+
+// UserService はユーザーのgRPCサービスを生成する。
+func (c *Container) UserService() *userservice.Service {
+	repo := c.users
+	opt := usecase.WithObserver(c.observer())
+	return userservice.NewService(userservice.Usecases{
+		Get:    usecase.NewGetUserUsecase(repo, opt),
+		List:   usecase.NewListUsersUsecase(repo, opt),
+		Create: usecase.NewCreateUserUsecase(repo, opt),
+		Update: usecase.NewUpdateUserUsecase(repo, opt),
+		Delete: usecase.NewDeleteUserUsecase(repo, opt),
+	}, c.logger)
+}
+
+// HealthService はgRPCのヘルスチェックサービスを生成する。/readyz と同じチェックを使う。
+func (c *Container) HealthService() *healthservice.Service {
+	return healthservice.NewService(c.health, userv1.UserService_ServiceDesc.ServiceName)
+}
+
+// GRPCAccessLog はgRPCのアクセスログのインターセプターを生成する。
+func (c *Container) GRPCAccessLog() interceptor.Interceptor {
+	return interceptor.AccessLog(c.logger, interceptor.AccessLogOptions{
+		SuccessSampleRate: c.cfg.AccessLog.SuccessSampleRate,
+		ClientIPs:         c.clientIPs,
+	})
+}
+
+// GRPCAdminAuth は services のメソッドを管理用トークンで認証するインターセプターを生成する。
+func (c *Container) GRPCAdminAuth(services ...string) interceptor.Interceptor {
+	return interceptor.AdminAuth(c.cfg.Server.AdminToken, c.logger, services...)
+}
+
+// GRPCReadYourWrites は書き込んだ呼び出し元の読み込みをプライマリに送るため、最後の書き込みの時刻をメタデータで受け渡すインターセプターを生成する。
+func (c *Container) GRPCReadYourWrites() interceptor.Interceptor {
+	return interceptor.ReadYourWrites()
+}
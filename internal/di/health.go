@@ -0,0 +1,20 @@
+package di
+
+import (
+	healthhandler "go-api/internal/presentation/http/handler/health"
+)
+
+// LiveHandler は /livez のハンドラーを生成する。
+func (c *Container) LiveHandler() *healthhandler.LiveHandler {
+	return healthhandler.NewLiveHandler()
+}
+
+// ReadyHandler は /readyz のハンドラーを生成する。
+func (c *Container) ReadyHandler() *healthhandler.ReadyHandler {
+	return healthhandler.NewReadyHandler(c.health)
+}
+
+// HealthDetailHandler は /health?verbose のハンドラーを生成する。
+func (c *Container) HealthDetailHandler() *healthhandler.DetailHandler {
+	return healthhandler.NewDetailHandler(c.health)
+}
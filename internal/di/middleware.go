@@ -0,0 +1,101 @@
+package di
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+
+	"go-api/internal/infrastructure/ratelimit"
+	"go-api/internal/metrics"
+	httperrors "go-api/internal/presentation/http/errors"
+	"go-api/internal/presentation/http/middleware"
+)
+
+// AccessLog はアクセスログのミドルウェアを生成する。
+func (c *Container) AccessLog() func(http.Handler) http.Handler {
+	return middleware.AccessLog(c.logger, middleware.AccessLogOptions{
+		SuccessSampleRate: c.cfg.AccessLog.SuccessSampleRate,
+		ClientIPs:         c.clientIPs,
+	})
+}
+
+// RateLimit はルートグループ用のレート制限ミドルウェアを生成する。
+// 無効化されている場合や設定のないグループは素通しのミドルウェアを返す。
+func (c *Container) RateLimit(group string) func(http.Handler) http.Handler {
+	rule, ok := c.cfg.RateLimit.Groups[group]
+	if !c.cfg.RateLimit.Enabled || !ok {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	return middleware.RateLimit(c.rateLimiter, middleware.RateLimitOptions{
+		Group:   group,
+		Limit:   ratelimit.Limit{Rate: rule.Rate, Burst: rule.Burst},
+		KeyFunc: middleware.RateLimitKey(c.clientIPs),
+	}, c.logger)
+}
+
+// Metrics はHTTPメトリクスを記録するミドルウェアを生成する。
+func (c *Container) Metrics() func(http.Handler) http.Handler {
+	if c.httpMetrics == nil {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	return middleware.Metrics(c.httpMetrics)
+}
+
+// Tracing はリクエストごとにサーバースパンを開始するミドルウェアを生成する。
+func (c *Container) Tracing() func(http.Handler) http.Handler {
+	if !c.cfg.Tracing.Enabled() {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	return middleware.Tracing(middleware.TracingOptions{
+		TracerProvider: c.tracerProvider,
+		Propagator:     otel.GetTextMapPropagator(),
+		ClientIPs:      c.clientIPs,
+	})
+}
+
+// CORS はCORSのミドルウェアを生成する。許可するオリジンがない場合は素通しのミドルウェアを返す。
+func (c *Container) CORS() func(http.Handler) http.Handler {
+	if !c.cfg.CORS.Enabled() {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	return middleware.CORS(middleware.CORSOptions{
+		AllowedOrigins:   c.cfg.CORS.AllowedOrigins,
+		AllowedMethods:   c.cfg.CORS.AllowedMethods,
+		AllowedHeaders:   c.cfg.CORS.AllowedHeaders,
+		ExposedHeaders:   c.cfg.CORS.ExposedHeaders,
+		AllowCredentials: c.cfg.CORS.AllowCredentials,
+		MaxAge:           c.cfg.CORS.MaxAge,
+	})
+}
+
+// ErrorFormat はエラーレスポンスの形式の設定をリクエストに付与するミドルウェアを生成する。
+func (c *Container) ErrorFormat() func(http.Handler) http.Handler {
+	return middleware.ErrorFormat(httperrors.Options{
+		Format:             httperrors.Format(c.cfg.Errors.Format),
+		ProblemTypeBaseURI: c.cfg.Errors.ProblemTypeBaseURI,
+	})
+}
+
+// MetricsHandler は /metrics のハンドラーを返す。メトリクスが無効の場合は nil を返す。
+func (c *Container) MetricsHandler() http.Handler {
+	if c.registry == nil {
+		return nil
+	}
+	return metrics.Handler(c.registry)
+}
+
+// AdminAuth は管理用トークンで認証するミドルウェアを生成する。
+func (c *Container) AdminAuth() func(http.Handler) http.Handler {
+	return middleware.AdminAuth(c.cfg.Server.AdminToken, c.logger)
+}
+
+// SeparateAdminListener は管理用エンドポイントを別リスナーで公開するかどうかを返す。
+func (c *Container) SeparateAdminListener() bool {
+	return c.cfg.Server.AdminAddr != ""
+}
+
+// ReadYourWrites は書き込んだ呼び出し元の読み込みをプライマリに送るため、最後の書き込みの時刻をクッキーで受け渡すミドルウェアを生成する。
+func (c *Container) ReadYourWrites() func(http.Handler) http.Handler {
+	return middleware.ReadYourWrites(c.cfg.Database.ReadYourWritesWindow)
+}
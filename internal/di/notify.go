@@ -0,0 +1,28 @@
+package di
+
+import (
+	"go-api/internal/backoff"
+	"go-api/internal/infrastructure/notify"
+)
+
+// newNotifier はユーザーの変更の通知を受信する Listener を生成する。受信は購読者を登録し終えてから startNotifier で開始する。
+// 通知を受けるとイベントの配信に即座に取得させ、ポーリングの間隔を待たずに他のレプリカの変更を配信する。
+func (c *Container) newNotifier() {
+	c.notifier = notify.NewListener(c.pool.Config().ConnConfig, c.storage.events, notify.Options{
+		Backoff: backoff.Backoff{
+			Min: c.cfg.Notify.ReconnectMinBackoff,
+			Max: c.cfg.Notify.ReconnectMaxBackoff,
+		},
+		CatchUpBatchSize: c.cfg.Events.BatchSize,
+		Logger:           c.logger,
+	})
+	c.notifier.Subscribe(func(notify.Change) { c.userEvents.Wake() })
+}
+
+// startNotifier は通知の受信を開始し、Close で停止するよう登録する。
+// 開始直後に届いた通知や再接続を取りこぼさないよう、キャッシュ等の購読者を全て登録した後に呼ぶ。
+func (c *Container) startNotifier() {
+	if c.notifier != nil {
+		c.goBackground(c.notifier.Run)
+	}
+}
@@ -0,0 +1,94 @@
+package di
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"go-api/internal/backoff"
+	"go-api/internal/domain/user"
+	"go-api/internal/infrastructure/database"
+	"go-api/internal/infrastructure/repository/memory"
+	"go-api/internal/infrastructure/repository/postgres"
+	"go-api/internal/infrastructure/repository/sqlite"
+	"go-api/internal/metrics"
+	"go-api/internal/sqlc/sqliteuser"
+	sqlcuser "go-api/internal/sqlc/user"
+)
+
+// Databases はコンテナが使うデータベースの接続。保存先で使うものだけを設定し、それ以外は nil でよい。
+// 接続は呼び出し元が所有し、コンテナの停止後に閉じる。
+type Databases struct {
+	// Primary はPostgreSQLの接続プール。保存先が postgres の場合は必須。
+	Primary *pgxpool.Pool
+	// Replicas は読み込みに使うPostgreSQLのレプリカの接続プール。
+	Replicas []*pgxpool.Pool
+	// SQLite は保存先が sqlite の場合に使う。
+	SQLite *sql.DB
+}
+
+// storage はデータの保存先のリポジトリ。キャッシュ等のデコレーターを適用する前のもの。
+type storage struct {
+	users  user.UserRepository
+	events user.EventRepository
+	// router はレプリカがある場合の読み込みの振り分け。Run で遅延を確認する
+	router *database.Router
+}
+
+// newStorage は kind の保存先のリポジトリを生成する。
+// PostgreSQLの読み込みは routerOpts に従ってレプリカに振り分け、一時的なエラーは retry に従って再試行する。
+func newStorage(kind string, dbs Databases, routerOpts database.RouterOptions, retry *database.RetryPolicy) (storage, error) {
+	switch kind {
+	case "postgres":
+		pool := dbs.Primary
+		if pool == nil {
+			return storage{}, errors.New("postgres storage requires a database pool")
+		}
+		queries := sqlcuser.New(pool)
+		// 変更の通知で起こされた配信がすぐに読めるよう、イベントはプライマリから読む
+		events := postgres.NewUserEventRepository(queries)
+		if len(dbs.Replicas) == 0 {
+			return storage{users: postgres.NewUserRepository(queries, postgres.WithRetry(retry)), events: events}, nil
+		}
+		router := database.NewRouter(pool, dbs.Replicas, routerOpts)
+		return storage{
+			users: postgres.NewUserRepository(sqlcuser.New(router.Writer()),
+				postgres.WithReader(sqlcuser.New(router.Reader())), postgres.WithRetry(retry)),
+			events: events,
+			router: router,
+		}, nil
+	case "sqlite":
+		sqliteDB := dbs.SQLite
+		if sqliteDB == nil {
+			return storage{}, errors.New("sqlite storage requires a database")
+		}
+		queries := sqliteuser.New(sqliteDB)
+		return storage{
+			users:  sqlite.NewUserRepository(queries),
+			events: sqlite.NewUserEventRepository(queries),
+		}, nil
+	case "memory":
+		users := memory.NewUserRepository()
+		return storage{
+			users:  users,
+			events: memory.NewUserEventRepository(users),
+		}, nil
+	default:
+		return storage{}, fmt.Errorf("unknown storage %q", kind)
+	}
+}
+
+// newRetryPolicy はPostgreSQLの読み込みの再試行の設定を生成する。
+func (c *Container) newRetryPolicy() *database.RetryPolicy {
+	opts := database.RetryOptions{
+		MaxAttempts: c.cfg.Database.ReadRetryMaxAttempts,
+		Backoff:     backoff.Backoff{Min: c.cfg.Database.ReadRetryMinBackoff, Max: c.cfg.Database.ReadRetryMaxBackoff},
+		Logger:      c.logger,
+	}
+	if c.registry != nil {
+		opts.Metrics = metrics.NewDatabaseRetry(c.registry)
+	}
+	return database.NewRetryPolicy(opts)
+}
@@ -2,47 +2,84 @@ package di
 
 import (
 	usecase "go-api/internal/application/user"
-	"go-api/internal/infrastructure/repository/postgres"
+	"go-api/internal/domain/user"
+	"go-api/internal/infrastructure/notify"
+	"go-api/internal/infrastructure/repository/cache"
+	"go-api/internal/infrastructure/repository/coalesce"
+	"go-api/internal/metrics"
 	userhandler "go-api/internal/presentation/http/handler/user"
-	sqlcuser "go-api/internal/sqlc/user"
 )
 
 // ListUserHandler はユーザー一覧取得ハンドラーを生成する。
 func (c *Container) ListUserHandler() *userhandler.ListHandler {
-	queries := sqlcuser.New(c.pool)
-	repo := postgres.NewUserRepository(queries)
-	uc := usecase.NewListUsersUsecase(repo)
+	repo := c.users
+	uc := usecase.NewListUsersUsecase(repo, usecase.WithObserver(c.observer()))
 	return userhandler.NewListHandler(uc, c.logger)
 }
 
 // CreateUserHandler はユーザー作成ハンドラーを生成する。
 func (c *Container) CreateUserHandler() *userhandler.CreateHandler {
-	queries := sqlcuser.New(c.pool)
-	repo := postgres.NewUserRepository(queries)
-	uc := usecase.NewCreateUserUsecase(repo)
+	repo := c.users
+	uc := usecase.NewCreateUserUsecase(repo, usecase.WithObserver(c.observer()))
 	return userhandler.NewCreateHandler(uc, c.logger)
 }
 
 // GetUserHandler はユーザー取得ハンドラーを生成する。
 func (c *Container) GetUserHandler() *userhandler.GetHandler {
-	queries := sqlcuser.New(c.pool)
-	repo := postgres.NewUserRepository(queries)
-	uc := usecase.NewGetUserUsecase(repo)
+	repo := c.users
+	uc := usecase.NewGetUserUsecase(repo, usecase.WithObserver(c.observer()))
 	return userhandler.NewGetHandler(uc, c.logger)
 }
 
 // UpdateUserHandler はユーザー更新ハンドラーを生成する。
 func (c *Container) UpdateUserHandler() *userhandler.UpdateHandler {
-	queries := sqlcuser.New(c.pool)
-	repo := postgres.NewUserRepository(queries)
-	uc := usecase.NewUpdateUserUsecase(repo)
+	repo := c.users
+	uc := usecase.NewUpdateUserUsecase(repo, usecase.WithObserver(c.observer()))
 	return userhandler.NewUpdateHandler(uc, c.logger)
 }
 
 // DeleteUserHandler はユーザー削除ハンドラーを生成する。
 func (c *Container) DeleteUserHandler() *userhandler.DeleteHandler {
-	queries := sqlcuser.New(c.pool)
-	repo := postgres.NewUserRepository(queries)
-	uc := usecase.NewDeleteUserUsecase(repo)
+	repo := c.users
+	uc := usecase.NewDeleteUserUsecase(repo, usecase.WithObserver(c.observer()))
 	return userhandler.NewDeleteHandler(uc, c.logger)
 }
+
+// newUserRepository はハンドラー間で共有するユーザーリポジトリを生成する。
+// 同時の同じ読み込みをまとめ、その前段でキャッシュする。書き込んだ直後のセッションの読み込みはどちらも経由しない。
+// キャッシュが有効な場合は、他のレプリカでの変更の通知でプロセス内のキャッシュを無効化する。
+func (c *Container) newUserRepository() user.UserRepository {
+	repo := c.storage.users
+	if c.cfg.Coalesce.Enabled {
+		opts := coalesce.Options{Timeout: c.cfg.Coalesce.Timeout}
+		if c.storage.router != nil {
+			opts.Bypass = c.storage.router.RecentlyWrote
+		}
+		if c.registry != nil {
+			opts.Metrics = metrics.NewCoalesce(c.registry)
+		}
+		repo = coalesce.NewUserRepository(repo, opts)
+	}
+	if !c.cfg.Cache.Enabled {
+		return repo
+	}
+
+	opts := cache.Options{
+		Size:        c.cfg.Cache.Size,
+		TTL:         c.cfg.Cache.TTL,
+		NegativeTTL: c.cfg.Cache.NegativeTTL,
+		Logger:      c.logger,
+	}
+	if c.storage.router != nil {
+		opts.Bypass = c.storage.router.RecentlyWrote
+	}
+	if c.registry != nil {
+		opts.Metrics = metrics.NewCache(c.registry)
+	}
+	cached := cache.NewUserRepository(repo, opts)
+	if c.notifier != nil {
+		c.notifier.Subscribe(func(change notify.Change) { cached.Invalidate(change.UserID) })
+		c.notifier.OnReconnect(cached.InvalidateAll)
+	}
+	return cached
+}
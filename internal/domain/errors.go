@@ -3,22 +3,22 @@ package domain
 
 import "errors"
 
-// センチネルエラー（errors.Is で判定可能）
+// センチネルエラー（errors.Is で判定可能）。公開するエラーは Define で種類を登録する
 var (
 	// ErrNotFound はリソースが見つからない場合のエラー。
-	ErrNotFound = errors.New("resource not found")
+	ErrNotFound = Define(errors.New("resource not found"), CategoryNotFound, "NOT_FOUND", "error.NOT_FOUND")
 
 	// ErrConflict は一意制約違反等の競合エラー。
-	ErrConflict = errors.New("resource conflict")
+	ErrConflict = Define(errors.New("resource conflict"), CategoryConflict, "CONFLICT", "error.CONFLICT")
 
 	// ErrInvalidInput は入力値が不正な場合のエラー。
-	ErrInvalidInput = errors.New("invalid input")
+	ErrInvalidInput = Define(errors.New("invalid input"), CategoryInvalidInput, "VALIDATION_ERROR", "error.VALIDATION_ERROR")
 
 	// ErrUnauthorized は認証が必要な場合のエラー。
-	ErrUnauthorized = errors.New("unauthorized")
+	ErrUnauthorized = Define(errors.New("unauthorized"), CategoryUnauthorized, "UNAUTHORIZED", "error.UNAUTHORIZED")
 
 	// ErrForbidden は権限がない場合のエラー。
-	ErrForbidden = errors.New("forbidden")
+	ErrForbidden = Define(errors.New("forbidden"), CategoryForbidden, "FORBIDDEN", "error.FORBIDDEN")
 )
 
 // DomainError はドメインエラーにコンテキストを付与するラッパー。
@@ -40,3 +40,28 @@ func TestDomainError(t *testing.T) {
 		assert.True(t, errors.Is(wrapped, domain.ErrNotFound))
 	})
 }
+
+func TestDefine(t *testing.T) {
+	t.Run("宣言したエラーの種類を登録して同じエラーを返す", func(t *testing.T) {
+		err := errors.New("test kind")
+
+		got := domain.Define(err, domain.CategoryConflict, "TEST_KIND", "error.TEST_KIND")
+
+		assert.Same(t, err, got)
+		assert.Contains(t, domain.Kinds(), domain.Kind{
+			Err: err, Category: domain.CategoryConflict, Code: "TEST_KIND", MessageKey: "error.TEST_KIND",
+		})
+	})
+
+	t.Run("共通のエラーは種類として登録されている", func(t *testing.T) {
+		var found bool
+		for _, k := range domain.Kinds() {
+			if k.Err == domain.ErrNotFound {
+				found = true
+				assert.Equal(t, domain.CategoryNotFound, k.Category)
+				assert.Equal(t, "NOT_FOUND", k.Code)
+			}
+		}
+		assert.True(t, found)
+	})
+}
@@ -0,0 +1,49 @@
+package domain
+
+import "sync"
+
+// Category はエラーの分類。表現層（HTTP・gRPC等）はこれを応答のステータスに変換する。
+type Category int
+
+// エラーの分類
+const (
+	CategoryInvalidInput Category = iota + 1 // 入力値が不正（HTTP 400）
+	CategoryUnauthorized                     // 認証が必要（HTTP 401）
+	CategoryForbidden                        // 権限がない（HTTP 403）
+	CategoryNotFound                         // リソースが見つからない（HTTP 404）
+	CategoryConflict                         // 一意制約違反等の競合（HTTP 409）
+)
+
+// Kind は利用者に公開するエラーの種類。
+type Kind struct {
+	Err      error
+	Category Category
+	// Code は機械可読なエラーコード（例: "NOT_FOUND"）。言語によらず一定。
+	Code string
+	// MessageKey は利用者向けメッセージを翻訳するためのカタログ（i18n）のキー。
+	// カタログにない場合は Err の文言を英語のメッセージとして使う。
+	MessageKey string
+}
+
+var (
+	kindsMu sync.RWMutex
+	kinds   []Kind
+)
+
+// Define は err を利用者に公開するエラーの種類として登録し、err をそのまま返す。
+// センチネルエラーの宣言で使い、表現層は Kinds から応答を決める。
+//
+//	var ErrNotFound = domain.Define(errors.New("resource not found"), domain.CategoryNotFound, "NOT_FOUND", "error.NOT_FOUND")
+func Define(err error, category Category, code, messageKey string) error {
+	kindsMu.Lock()
+	defer kindsMu.Unlock()
+	kinds = append(kinds, Kind{Err: err, Category: category, Code: code, MessageKey: messageKey})
+	return err
+}
+
+// Kinds は Define で登録されたエラーの種類を登録順に返す。
+func Kinds() []Kind {
+	kindsMu.RLock()
+	defer kindsMu.RUnlock()
+	return append([]Kind(nil), kinds...)
+}
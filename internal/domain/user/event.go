@@ -0,0 +1,55 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"go-api/internal/domain/user/valueobject"
+)
+
+// Event はユーザーの変更イベント。
+// Seq はイベントごとに増える連番で、配信を途中から再開する位置（SSE の Last-Event-ID）として使う。
+// 同時に書き込むトランザクションでは Seq の順とコミットの順が入れ替わりうるため、配信は Position の順に行う。
+type Event struct {
+	Seq int64
+	// TxID はイベントを記録したトランザクションのID。書き込みを直列化し Seq の順にコミットする保存先では 0。
+	TxID       int64
+	Type       valueobject.EventType
+	User       *User // 変更後のユーザー。削除の場合は削除前のユーザー
+	OccurredAt time.Time
+}
+
+// Position はイベントの配信の順序での位置を返す。
+func (e *Event) Position() EventPosition {
+	return EventPosition{TxID: e.TxID, Seq: e.Seq}
+}
+
+// EventPosition はイベントの配信の順序での位置。TxID、Seq の順に比較する。
+// ゼロ値は最初のイベントより前を表す。
+type EventPosition struct {
+	TxID int64
+	Seq  int64
+}
+
+// Before は p が other より前かどうかを返す。
+func (p EventPosition) Before(other EventPosition) bool {
+	if p.TxID != other.TxID {
+		return p.TxID < other.TxID
+	}
+	return p.Seq < other.Seq
+}
+
+// EventRepository はユーザーの変更イベントの読み出しインターフェース。
+// イベントはユーザーの保存・削除と同じトランザクションで記録される。
+type EventRepository interface {
+	// FindAfter は after より後のイベントを配信の順に最大 limit 件取得する。
+	// 後からコミットされるイベントが返したイベントより前に入らないよう、実行中のトランザクションより後のイベントは返さない。
+	FindAfter(ctx context.Context, after EventPosition, limit int) ([]*Event, error)
+	// Latest は FindAfter で取得できる最後のイベントの位置を返す。イベントがない場合はゼロ値を返す。
+	Latest(ctx context.Context) (EventPosition, error)
+	// FindPosition は Seq が seq のイベントの位置を返す。イベントがない場合は false を返す。
+	FindPosition(ctx context.Context, seq int64) (EventPosition, bool, error)
+	// DeleteBefore は before より前に発生したイベントを削除し、削除した件数を返す。
+	// 削除しても Seq は再利用しない。
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
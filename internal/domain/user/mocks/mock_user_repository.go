@@ -24,6 +24,62 @@ func (_m *MockUserRepository) EXPECT() *MockUserRepository_Expecter {
 	return &MockUserRepository_Expecter{mock: &_m.Mock}
 }
 
+// Count provides a mock function with given fields: ctx
+func (_m *MockUserRepository) Count(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepository_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type MockUserRepository_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockUserRepository_Expecter) Count(ctx interface{}) *MockUserRepository_Count_Call {
+	return &MockUserRepository_Count_Call{Call: _e.mock.On("Count", ctx)}
+}
+
+func (_c *MockUserRepository_Count_Call) Run(run func(ctx context.Context)) *MockUserRepository_Count_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_Count_Call) Return(_a0 int, _a1 error) *MockUserRepository_Count_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepository_Count_Call) RunAndReturn(run func(context.Context) (int, error)) *MockUserRepository_Count_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Delete provides a mock function with given fields: ctx, id
 func (_m *MockUserRepository) Delete(ctx context.Context, id valueobject.UserID) error {
 	ret := _m.Called(ctx, id)
@@ -188,6 +244,125 @@ func (_c *MockUserRepository_FindByID_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// FindByIDs provides a mock function with given fields: ctx, ids
+func (_m *MockUserRepository) FindByIDs(ctx context.Context, ids []valueobject.UserID) ([]*user.User, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByIDs")
+	}
+
+	var r0 []*user.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []valueobject.UserID) ([]*user.User, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []valueobject.UserID) []*user.User); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*user.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []valueobject.UserID) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepository_FindByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByIDs'
+type MockUserRepository_FindByIDs_Call struct {
+	*mock.Call
+}
+
+// FindByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []valueobject.UserID
+func (_e *MockUserRepository_Expecter) FindByIDs(ctx interface{}, ids interface{}) *MockUserRepository_FindByIDs_Call {
+	return &MockUserRepository_FindByIDs_Call{Call: _e.mock.On("FindByIDs", ctx, ids)}
+}
+
+func (_c *MockUserRepository_FindByIDs_Call) Run(run func(ctx context.Context, ids []valueobject.UserID)) *MockUserRepository_FindByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]valueobject.UserID))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_FindByIDs_Call) Return(_a0 []*user.User, _a1 error) *MockUserRepository_FindByIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepository_FindByIDs_Call) RunAndReturn(run func(context.Context, []valueobject.UserID) ([]*user.User, error)) *MockUserRepository_FindByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindPage provides a mock function with given fields: ctx, after, limit
+func (_m *MockUserRepository) FindPage(ctx context.Context, after *user.PageCursor, limit int) ([]*user.User, error) {
+	ret := _m.Called(ctx, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPage")
+	}
+
+	var r0 []*user.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *user.PageCursor, int) ([]*user.User, error)); ok {
+		return rf(ctx, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *user.PageCursor, int) []*user.User); ok {
+		r0 = rf(ctx, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*user.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *user.PageCursor, int) error); ok {
+		r1 = rf(ctx, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepository_FindPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPage'
+type MockUserRepository_FindPage_Call struct {
+	*mock.Call
+}
+
+// FindPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - after *user.PageCursor
+//   - limit int
+func (_e *MockUserRepository_Expecter) FindPage(ctx interface{}, after interface{}, limit interface{}) *MockUserRepository_FindPage_Call {
+	return &MockUserRepository_FindPage_Call{Call: _e.mock.On("FindPage", ctx, after, limit)}
+}
+
+func (_c *MockUserRepository_FindPage_Call) Run(run func(ctx context.Context, after *user.PageCursor, limit int)) *MockUserRepository_FindPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*user.PageCursor), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_FindPage_Call) Return(_a0 []*user.User, _a1 error) *MockUserRepository_FindPage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepository_FindPage_Call) RunAndReturn(run func(context.Context, *user.PageCursor, int) ([]*user.User, error)) *MockUserRepository_FindPage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Save provides a mock function with given fields: ctx, _a1
 func (_m *MockUserRepository) Save(ctx context.Context, _a1 *user.User) error {
 	ret := _m.Called(ctx, _a1)
@@ -0,0 +1,27 @@
+package user
+
+import (
+	"time"
+
+	"go-api/internal/domain/user/valueobject"
+)
+
+// PageCursor はユーザー一覧のページングでの位置。
+// 一覧は作成日時の新しい順に並べ、作成日時が同じ場合はIDの降順とする。
+type PageCursor struct {
+	CreatedAt time.Time
+	ID        valueobject.UserID
+}
+
+// CursorOf は u の一覧での位置を返す。
+func CursorOf(u *User) PageCursor {
+	return PageCursor{CreatedAt: u.CreatedAt(), ID: u.ID()}
+}
+
+// Before は一覧で c が other より前に並ぶかを返す。
+func (c PageCursor) Before(other PageCursor) bool {
+	if !c.CreatedAt.Equal(other.CreatedAt) {
+		return c.CreatedAt.After(other.CreatedAt)
+	}
+	return c.ID.String() > other.ID.String()
+}
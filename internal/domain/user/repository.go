@@ -10,8 +10,17 @@ import (
 
 // UserRepository はユーザーの永続化インターフェース。
 type UserRepository interface {
+	// Save はユーザーを保存する。既に存在するIDの場合は名前とメールアドレスを更新し、作成日時は変えない。
+	// 他のユーザーと同じメールアドレスの場合は domain.ErrConflict を返す。
 	Save(ctx context.Context, user *User) error
 	FindByID(ctx context.Context, id valueobject.UserID) (*User, error)
+	// FindByIDs は複数のユーザーをまとめて取得する。見つからないIDは結果に含めず、順序は保証しない。
+	FindByIDs(ctx context.Context, ids []valueobject.UserID) ([]*User, error)
 	FindAll(ctx context.Context) ([]*User, error)
+	// FindPage は一覧の並び順（PageCursor）で after より後のユーザーを最大 limit 件取得する。
+	// after が nil の場合は先頭から取得する。after のユーザーが削除されていても、その位置の続きを返す。
+	FindPage(ctx context.Context, after *PageCursor, limit int) ([]*User, error)
+	// Count はユーザーの件数を返す。
+	Count(ctx context.Context) (int, error)
 	Delete(ctx context.Context, id valueobject.UserID) error
 }
@@ -1,17 +1,21 @@
 package user
 
 import (
+	"time"
+
 	"go-api/internal/domain/user/valueobject"
 )
 
 // User はユーザーエンティティ。
 type User struct {
-	id    valueobject.UserID
-	name  valueobject.UserName
-	email valueobject.Email
+	id        valueobject.UserID
+	name      valueobject.UserName
+	email     valueobject.Email
+	createdAt time.Time
 }
 
 // NewUser は新しいUserエンティティを生成する。IDは自動付与される。
+// 作成日時は保存先が記録するため、保存して取得し直すまではゼロ値になる。
 func NewUser(name valueobject.UserName, email valueobject.Email) *User {
 	return &User{
 		id:    valueobject.NewUserID(),
@@ -21,17 +25,19 @@ func NewUser(name valueobject.UserName, email valueobject.Email) *User {
 }
 
 // Reconstruct は永続化層から読み出したデータでUserを復元する。
-func Reconstruct(id valueobject.UserID, name valueobject.UserName, email valueobject.Email) *User {
+func Reconstruct(id valueobject.UserID, name valueobject.UserName, email valueobject.Email, createdAt time.Time) *User {
 	return &User{
-		id:    id,
-		name:  name,
-		email: email,
+		id:        id,
+		name:      name,
+		email:     email,
+		createdAt: createdAt,
 	}
 }
 
 func (u *User) ID() valueobject.UserID     { return u.id }
 func (u *User) Name() valueobject.UserName { return u.name }
 func (u *User) Email() valueobject.Email   { return u.email }
+func (u *User) CreatedAt() time.Time       { return u.createdAt }
 
 // ChangeName はユーザー名を変更する。
 func (u *User) ChangeName(name valueobject.UserName) {
@@ -2,6 +2,7 @@ package user
 
 import (
 	"testing"
+	"time"
 
 	"go-api/internal/domain/user/valueobject"
 )
@@ -31,7 +32,9 @@ func TestReconstruct(t *testing.T) {
 		name, _ := valueobject.NewUserName("佐藤花子")
 		email, _ := valueobject.NewEmail("sato@example.com")
 
-		u := Reconstruct(id, name, email)
+		createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		u := Reconstruct(id, name, email, createdAt)
 
 		if u.ID().String() != "550e8400-e29b-41d4-a716-446655440000" {
 			t.Errorf("ID got %q, want %q", u.ID().String(), "550e8400-e29b-41d4-a716-446655440000")
@@ -42,5 +45,8 @@ func TestReconstruct(t *testing.T) {
 		if u.Email().String() != "sato@example.com" {
 			t.Errorf("Email got %q, want %q", u.Email().String(), "sato@example.com")
 		}
+		if !u.CreatedAt().Equal(createdAt) {
+			t.Errorf("CreatedAt got %v, want %v", u.CreatedAt(), createdAt)
+		}
 	})
 }
@@ -1,16 +1,19 @@
 package valueobject
 
 import (
-	"errors"
+	"fmt"
 	"regexp"
 )
 
 var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
 
+// MaxEmailLength はメールアドレスの最大バイト数。
+const MaxEmailLength = 255
+
 var (
-	ErrEmailRequired = errors.New("email is required")
-	ErrEmailTooLong  = errors.New("email must be 255 characters or less")
-	ErrEmailInvalid  = errors.New("email format is invalid")
+	ErrEmailRequired = define(CodeRequired, "email is required", "user.email_required")
+	ErrEmailTooLong  = define(CodeTooLong, fmt.Sprintf("email must be %d characters or less", MaxEmailLength), "user.email_too_long")
+	ErrEmailInvalid  = define(CodeInvalidFormat, "email format is invalid", "user.email_invalid")
 )
 
 // Email はメールアドレスを表す値オブジェクト。
@@ -23,7 +26,7 @@ func NewEmail(v string) (Email, error) {
 	if v == "" {
 		return Email{}, ErrEmailRequired
 	}
-	if len(v) > 255 {
+	if len(v) > MaxEmailLength {
 		return Email{}, ErrEmailTooLong
 	}
 	if !emailRegex.MatchString(v) {
@@ -1,8 +1,100 @@
 package valueobject
 
+import (
+	"errors"
+	"strings"
+
+	"go-api/internal/domain"
+)
+
 // エラーコード定数
 const (
 	CodeRequired      = "required"
 	CodeTooLong       = "too_long"
 	CodeInvalidFormat = "invalid_format"
 )
+
+// Error は値オブジェクトの生成に失敗した理由を表すエラー。
+// Code は入力元（HTTP、インポート等）によらず同じ値になる。
+type Error struct {
+	Code    string
+	message string
+}
+
+func newError(code, message string) *Error {
+	return &Error{Code: code, message: message}
+}
+
+// define は値オブジェクトのエラーを入力値の不正（VALIDATION_ERROR）の種類として登録する。
+// messageKey は利用者向けメッセージを翻訳するためのカタログのキー。
+func define(code, message, messageKey string) error {
+	return domain.Define(newError(code, message), domain.CategoryInvalidInput, "VALIDATION_ERROR", messageKey)
+}
+
+// Error はerrorインターフェースを実装する。
+func (e *Error) Error() string {
+	return e.message
+}
+
+// Is は errors.Is で domain.ErrInvalidInput との比較を可能にする。
+func (e *Error) Is(target error) bool {
+	return target == domain.ErrInvalidInput
+}
+
+// FieldError はどの入力項目で値オブジェクトの生成に失敗したかを表すエラー。
+type FieldError struct {
+	Field string // 入力項目名（例: "name"）
+	Err   error  // 値オブジェクトのエラー（ErrNameRequired 等）
+}
+
+// Error はerrorインターフェースを実装する。
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+// Unwrap は errors.Is/As のチェーンを維持する。
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Code は値オブジェクトのエラーコードを返す。
+func (e *FieldError) Code() string {
+	var voErr *Error
+	if errors.As(e.Err, &voErr) {
+		return voErr.Code
+	}
+	return CodeInvalidFormat
+}
+
+// ValidationErrors は入力項目ごとの値オブジェクトの生成エラーの一覧。
+// 全ての項目を検証してからまとめて返すために使う。
+type ValidationErrors []*FieldError
+
+// Add は err が nil でなければ field のエラーとして追加する。
+func (v *ValidationErrors) Add(field string, err error) {
+	if err != nil {
+		*v = append(*v, &FieldError{Field: field, Err: err})
+	}
+}
+
+// Err はエラーがあれば ValidationErrors を、なければ nil を返す。
+func (v ValidationErrors) Err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return v
+}
+
+// Error はerrorインターフェースを実装する。
+func (v ValidationErrors) Error() string {
+	msgs := make([]string, len(v))
+	for i, e := range v {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Is は errors.Is で domain.ErrInvalidInput との比較を可能にする。
+func (v ValidationErrors) Is(target error) bool {
+	return target == domain.ErrInvalidInput
+}
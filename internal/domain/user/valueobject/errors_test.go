@@ -0,0 +1,61 @@
+package valueobject
+
+import (
+	"errors"
+	"testing"
+
+	"go-api/internal/domain"
+)
+
+func TestValidationErrors(t *testing.T) {
+	t.Run("正常系/エラーがなければ nil を返す", func(t *testing.T) {
+		var errs ValidationErrors
+		errs.Add("name", nil)
+
+		if err := errs.Err(); err != nil {
+			t.Errorf("got %v, want nil", err)
+		}
+	})
+
+	t.Run("異常系/項目ごとのエラーとコードを保持する", func(t *testing.T) {
+		var errs ValidationErrors
+		_, err := NewUserName("")
+		errs.Add("name", err)
+		_, err = NewEmail("invalid")
+		errs.Add("email", err)
+
+		err = errs.Err()
+		if err == nil {
+			t.Fatal("エラーが返されるべき")
+		}
+		if got := err.Error(); got != "name: name is required; email: email format is invalid" {
+			t.Errorf("got %q", got)
+		}
+
+		var ve ValidationErrors
+		if !errors.As(err, &ve) || len(ve) != 2 {
+			t.Fatalf("got %v, want 2 field errors", err)
+		}
+		if ve[0].Field != "name" || ve[0].Code() != CodeRequired {
+			t.Errorf("got %s/%s, want name/%s", ve[0].Field, ve[0].Code(), CodeRequired)
+		}
+		if ve[1].Field != "email" || ve[1].Code() != CodeInvalidFormat {
+			t.Errorf("got %s/%s, want email/%s", ve[1].Field, ve[1].Code(), CodeInvalidFormat)
+		}
+	})
+
+	t.Run("異常系/ErrInvalidInput として判定される", func(t *testing.T) {
+		var errs ValidationErrors
+		errs.Add("name", ErrNameTooLong)
+
+		if !errors.Is(errs.Err(), domain.ErrInvalidInput) {
+			t.Error("ValidationErrors は ErrInvalidInput として判定されるべき")
+		}
+		if !errors.Is(ErrEmailInvalid, domain.ErrInvalidInput) {
+			t.Error("値オブジェクトのエラーは ErrInvalidInput として判定されるべき")
+		}
+		if !errors.Is(errs[0], ErrNameTooLong) {
+			t.Error("FieldError は元のエラーとして判定されるべき")
+		}
+	})
+}
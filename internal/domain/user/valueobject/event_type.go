@@ -0,0 +1,29 @@
+package valueobject
+
+var ErrEventTypeInvalid = define(CodeInvalidFormat, "invalid event type", "user.event_type_invalid")
+
+// EventType はユーザーの変更イベントの種類を表す値オブジェクト。
+type EventType struct {
+	value string
+}
+
+// イベントの種類。
+var (
+	EventUserCreated = EventType{value: "user.created"}
+	EventUserUpdated = EventType{value: "user.updated"}
+	EventUserDeleted = EventType{value: "user.deleted"}
+)
+
+// ParseEventType は文字列からEventTypeを復元する。既知の種類でなければエラーを返す。
+func ParseEventType(v string) (EventType, error) {
+	switch v {
+	case EventUserCreated.value, EventUserUpdated.value, EventUserDeleted.value:
+		return EventType{value: v}, nil
+	default:
+		return EventType{}, ErrEventTypeInvalid
+	}
+}
+
+func (t EventType) String() string {
+	return t.value
+}
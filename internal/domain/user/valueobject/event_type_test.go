@@ -0,0 +1,46 @@
+package valueobject
+
+import (
+	"testing"
+)
+
+func TestParseEventType(t *testing.T) {
+	validCases := []struct {
+		input string
+		want  EventType
+	}{
+		{"user.created", EventUserCreated},
+		{"user.updated", EventUserUpdated},
+		{"user.deleted", EventUserDeleted},
+	}
+
+	for _, tt := range validCases {
+		t.Run("正常系/"+tt.input, func(t *testing.T) {
+			got, err := ParseEventType(tt.input)
+			if err != nil {
+				t.Fatalf("有効な種類でエラーが発生: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	invalidCases := []struct {
+		name  string
+		input string
+	}{
+		{"異常系/空文字", ""},
+		{"異常系/未知の種類", "user.renamed"},
+		{"異常系/大文字", "USER.CREATED"},
+	}
+
+	for _, tt := range invalidCases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseEventType(tt.input)
+			if err != ErrEventTypeInvalid {
+				t.Errorf("got %v, want ErrEventTypeInvalid", err)
+			}
+		})
+	}
+}
@@ -1,12 +1,8 @@
 package valueobject
 
-import (
-	"errors"
+import "github.com/google/uuid"
 
-	"github.com/google/uuid"
-)
-
-var ErrInvalidID = errors.New("invalid user id")
+var ErrInvalidID = define(CodeInvalidFormat, "invalid user id", "user.invalid_id")
 
 // UserID はユーザーIDを表す値オブジェクト。
 type UserID struct {
@@ -1,10 +1,13 @@
 package valueobject
 
-import "errors"
+import "fmt"
+
+// MaxUserNameLength はユーザー名の最大文字数。
+const MaxUserNameLength = 100
 
 var (
-	ErrNameRequired = errors.New("name is required")
-	ErrNameTooLong  = errors.New("name must be 100 characters or less")
+	ErrNameRequired = define(CodeRequired, "name is required", "user.name_required")
+	ErrNameTooLong  = define(CodeTooLong, fmt.Sprintf("name must be %d characters or less", MaxUserNameLength), "user.name_too_long")
 )
 
 // UserName はユーザー名を表す値オブジェクト。
@@ -17,7 +20,7 @@ func NewUserName(v string) (UserName, error) {
 	if v == "" {
 		return UserName{}, ErrNameRequired
 	}
-	if len([]rune(v)) > 100 {
+	if len([]rune(v)) > MaxUserNameLength {
 		return UserName{}, ErrNameTooLong
 	}
 	return UserName{value: v}, nil
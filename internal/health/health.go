@@ -0,0 +1,135 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Status は稼働状態。
+type Status string
+
+const (
+	StatusOK           Status = "ok"
+	StatusUnavailable  Status = "unavailable"
+	StatusShuttingDown Status = "shutting_down"
+)
+
+// CheckFunc は依存先（DB等）が利用可能かを確認する関数。利用できない場合はエラーを返す。
+type CheckFunc func(ctx context.Context) error
+
+// Options は Registry の設定。
+type Options struct {
+	// Timeout は1回のチェックの上限時間。
+	Timeout time.Duration
+	// CacheTTL はチェック結果を再利用する期間。プローブが依存先に負荷をかけないようにする。
+	CacheTTL time.Duration
+}
+
+// Report は全チェックの結果。
+type Report struct {
+	Status Status
+	Checks []Result
+}
+
+// Result は1つのチェックの結果。
+type Result struct {
+	Name      string
+	Status    Status
+	Latency   time.Duration
+	CheckedAt time.Time
+	// Err は直近のチェックのエラー。成功した場合は nil。
+	Err error
+	// LastErr と LastErrAt は最後に失敗したときのエラーと時刻。成功に戻っても保持する。
+	LastErr   error
+	LastErrAt time.Time
+}
+
+// Registry は登録されたチェックを実行し、結果を一定期間キャッシュする。
+type Registry struct {
+	readiness *Readiness
+	opts      Options
+	checkers  []*checker
+	now       func() time.Time
+}
+
+// NewRegistry は Registry を生成する。
+func NewRegistry(readiness *Readiness, opts Options) *Registry {
+	return &Registry{readiness: readiness, opts: opts, now: time.Now}
+}
+
+// Register はチェックを登録する。
+func (r *Registry) Register(name string, fn CheckFunc) {
+	r.checkers = append(r.checkers, &checker{name: name, fn: fn})
+}
+
+// Check は全チェックを並行して実行し、結果を返す。
+// 停止処理中はチェック結果にかかわらず StatusShuttingDown を返す。
+func (r *Registry) Check(ctx context.Context) Report {
+	results := make([]Result, len(r.checkers))
+	var wg sync.WaitGroup
+	for i, c := range r.checkers {
+		wg.Go(func() {
+			results[i] = c.check(ctx, r.opts, r.now)
+		})
+	}
+	wg.Wait()
+
+	status := StatusOK
+	for _, res := range results {
+		if res.Status != StatusOK {
+			status = StatusUnavailable
+		}
+	}
+	if r.readiness.ShuttingDown() {
+		status = StatusShuttingDown
+	}
+	return Report{Status: status, Checks: results}
+}
+
+// checker は登録されたチェックとキャッシュした結果。
+type checker struct {
+	name string
+	fn   CheckFunc
+
+	mu        sync.Mutex
+	result    Result
+	expiresAt time.Time
+}
+
+// check はキャッシュが有効ならその結果を返し、期限切れならチェックを実行する。
+// 同時に呼ばれた場合は1回だけ実行し、他の呼び出しはその結果を待つ。
+func (c *checker) check(ctx context.Context, opts Options, now func() time.Time) Result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	start := now()
+	if start.Before(c.expiresAt) {
+		return c.result
+	}
+
+	// 呼び出し元のリクエストが切断されてもキャンセル結果をキャッシュしないよう、Timeout のみで打ち切る
+	checkCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), opts.Timeout)
+	defer cancel()
+	err := c.fn(checkCtx)
+	end := now()
+
+	res := Result{
+		Name:      c.name,
+		Status:    StatusOK,
+		Latency:   end.Sub(start),
+		CheckedAt: end,
+		Err:       err,
+		LastErr:   c.result.LastErr,
+		LastErrAt: c.result.LastErrAt,
+	}
+	if err != nil {
+		res.Status = StatusUnavailable
+		res.LastErr = err
+		res.LastErrAt = end
+	}
+
+	c.result = res
+	c.expiresAt = end.Add(opts.CacheTTL)
+	return res
+}
@@ -0,0 +1,136 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	opts := Options{Timeout: time.Second, CacheTTL: 2 * time.Second}
+
+	t.Run("全てのチェックが成功した場合はok", func(t *testing.T) {
+		r := NewRegistry(NewReadiness(), opts)
+		r.Register("db", func(context.Context) error { return nil })
+		r.Register("migrations", func(context.Context) error { return nil })
+
+		report := r.Check(context.Background())
+
+		assert.Equal(t, StatusOK, report.Status)
+		require.Len(t, report.Checks, 2)
+		assert.Equal(t, "db", report.Checks[0].Name)
+		assert.Equal(t, "migrations", report.Checks[1].Name)
+	})
+
+	t.Run("失敗したチェックがある場合はunavailable", func(t *testing.T) {
+		r := NewRegistry(NewReadiness(), opts)
+		r.Register("db", func(context.Context) error { return errors.New("connection refused") })
+		r.Register("migrations", func(context.Context) error { return nil })
+
+		report := r.Check(context.Background())
+
+		assert.Equal(t, StatusUnavailable, report.Status)
+		assert.Equal(t, StatusUnavailable, report.Checks[0].Status)
+		assert.EqualError(t, report.Checks[0].Err, "connection refused")
+		assert.Equal(t, StatusOK, report.Checks[1].Status)
+	})
+
+	t.Run("停止処理中はshutting_down", func(t *testing.T) {
+		readiness := NewReadiness()
+		r := NewRegistry(readiness, opts)
+		r.Register("db", func(context.Context) error { return nil })
+		readiness.SetShuttingDown()
+
+		assert.Equal(t, StatusShuttingDown, r.Check(context.Background()).Status)
+	})
+
+	t.Run("CacheTTLの間は結果を再利用する", func(t *testing.T) {
+		now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		r := NewRegistry(NewReadiness(), opts)
+		r.now = func() time.Time { return now }
+		var calls atomic.Int32
+		r.Register("db", func(context.Context) error { calls.Add(1); return nil })
+
+		r.Check(context.Background())
+		now = now.Add(time.Second)
+		r.Check(context.Background())
+		assert.Equal(t, int32(1), calls.Load())
+
+		now = now.Add(2 * time.Second)
+		r.Check(context.Background())
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("成功に戻っても最後のエラーを保持する", func(t *testing.T) {
+		now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		failedAt := now
+		r := NewRegistry(NewReadiness(), opts)
+		r.now = func() time.Time { return now }
+		fail := true
+		r.Register("db", func(context.Context) error {
+			if fail {
+				return errors.New("connection refused")
+			}
+			return nil
+		})
+
+		r.Check(context.Background())
+		fail = false
+		now = now.Add(3 * time.Second)
+		report := r.Check(context.Background())
+
+		res := report.Checks[0]
+		assert.Equal(t, StatusOK, res.Status)
+		assert.NoError(t, res.Err)
+		assert.EqualError(t, res.LastErr, "connection refused")
+		assert.Equal(t, failedAt, res.LastErrAt)
+	})
+
+	t.Run("Timeoutを超えたチェックは失敗とする", func(t *testing.T) {
+		r := NewRegistry(NewReadiness(), Options{Timeout: 10 * time.Millisecond})
+		r.Register("db", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		report := r.Check(context.Background())
+
+		assert.Equal(t, StatusUnavailable, report.Status)
+		assert.ErrorIs(t, report.Checks[0].Err, context.DeadlineExceeded)
+	})
+
+	t.Run("呼び出し元のキャンセルはチェックに伝播しない", func(t *testing.T) {
+		r := NewRegistry(NewReadiness(), opts)
+		r.Register("db", func(ctx context.Context) error { return ctx.Err() })
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.Equal(t, StatusOK, r.Check(ctx).Status)
+	})
+}
+
+func TestReadiness(t *testing.T) {
+	t.Run("停止処理の開始でDoneが閉じられる", func(t *testing.T) {
+		readiness := NewReadiness()
+		select {
+		case <-readiness.Done():
+			t.Fatal("停止処理の開始前に閉じられている")
+		default:
+		}
+
+		readiness.SetShuttingDown()
+		readiness.SetShuttingDown()
+
+		assert.True(t, readiness.ShuttingDown())
+		select {
+		case <-readiness.Done():
+		default:
+			t.Fatal("停止処理の開始後に閉じられていない")
+		}
+	})
+}
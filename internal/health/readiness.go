@@ -0,0 +1,37 @@
+// Package health はプロセスの稼働状態とトラフィックを受け付けられるかどうかの判定を提供する。
+package health
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Readiness はプロセスが新しいトラフィックを受け付けられる状態かどうかを保持する。
+// 停止処理の開始時に ShuttingDown に切り替え、ロードバランサーに振り分け対象から外させる。
+type Readiness struct {
+	shuttingDown atomic.Bool
+	once         sync.Once
+	done         chan struct{}
+}
+
+// NewReadiness は Readiness を生成する。
+func NewReadiness() *Readiness {
+	return &Readiness{done: make(chan struct{})}
+}
+
+// SetShuttingDown は停止処理中の状態に切り替える。
+func (r *Readiness) SetShuttingDown() {
+	r.shuttingDown.Store(true)
+	r.once.Do(func() { close(r.done) })
+}
+
+// ShuttingDown は停止処理中かどうかを返す。
+func (r *Readiness) ShuttingDown() bool {
+	return r.shuttingDown.Load()
+}
+
+// Done は停止処理の開始時に閉じられるチャネルを返す。
+// SSE 等の長時間の接続は、これを受けて終了し、http.Server.Shutdown の完了を妨げないようにする。
+func (r *Readiness) Done() <-chan struct{} {
+	return r.done
+}
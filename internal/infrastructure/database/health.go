@@ -0,0 +1,48 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"go-api/internal/health"
+)
+
+// PingCheck はコネクションプールからDBに到達できるかを確認する。
+func PingCheck(pool *pgxpool.Pool) health.CheckFunc {
+	return func(ctx context.Context) error {
+		return pool.Ping(ctx)
+	}
+}
+
+// MigrationCheck はDBに適用済みのマイグレーション（golang-migrate の schema_migrations）が
+// want と一致し、途中で失敗した状態（dirty）でないことを確認する。
+func MigrationCheck(pool *pgxpool.Pool, want uint) health.CheckFunc {
+	return func(ctx context.Context) error {
+		var (
+			version int64
+			dirty   bool
+		)
+		err := pool.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("no migrations applied, want version %d", want)
+		}
+		if err != nil {
+			return fmt.Errorf("query migration version: %w", err)
+		}
+		return checkMigrationVersion(version, dirty, want)
+	}
+}
+
+func checkMigrationVersion(version int64, dirty bool, want uint) error {
+	if dirty {
+		return fmt.Errorf("migration version %d is dirty", version)
+	}
+	if version != int64(want) {
+		return fmt.Errorf("migration version %d, want %d", version, want)
+	}
+	return nil
+}
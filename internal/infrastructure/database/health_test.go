@@ -0,0 +1,21 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckMigrationVersion(t *testing.T) {
+	t.Run("バージョンが一致する場合は成功", func(t *testing.T) {
+		assert.NoError(t, checkMigrationVersion(2, false, 2))
+	})
+
+	t.Run("バージョンが一致しない場合はエラー", func(t *testing.T) {
+		assert.EqualError(t, checkMigrationVersion(1, false, 2), "migration version 1, want 2")
+	})
+
+	t.Run("dirtyの場合はエラー", func(t *testing.T) {
+		assert.EqualError(t, checkMigrationVersion(2, true, 2), "migration version 2 is dirty")
+	})
+}
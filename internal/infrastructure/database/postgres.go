@@ -4,25 +4,59 @@ package database
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel/trace"
 
+	"go-api/internal/backoff"
 	"go-api/internal/config"
 )
 
+// ConnectMetrics は起動時の接続の再試行を記録する。
+type ConnectMetrics interface {
+	// ConnectRetried は接続に失敗して再試行したことを記録する。
+	ConnectRetried()
+}
+
 // Connect はPostgreSQLへの接続プールを生成する。
-func Connect(ctx context.Context, cfg config.DatabaseConfig) (*pgxpool.Pool, error) {
-	poolCfg, err := pgxpool.ParseConfig(cfg.URL)
+// 発行するクエリは tp のスパンとして記録される。
+// DBの起動を待てるよう、接続できない場合は cfg.ConnectMaxAttempts 回まで間隔を空けて再試行し、m に記録する（nil の場合は記録しない）。
+func Connect(ctx context.Context, cfg config.DatabaseConfig, tp trace.TracerProvider, logger *slog.Logger, m ConnectMetrics) (*pgxpool.Pool, error) {
+	poolCfg, err := parsePoolConfig(cfg.URL, cfg, tp)
 	if err != nil {
-		return nil, fmt.Errorf("parse database config: %w", err)
+		return nil, err
 	}
 
-	poolCfg.MaxConns = cfg.MaxConns
-	poolCfg.MinConns = cfg.MinConns
-	poolCfg.MaxConnLifetime = cfg.MaxConnLifetime
+	b := backoff.Backoff{Min: cfg.ConnectMinBackoff, Max: cfg.ConnectMaxBackoff}
+	for attempt := 0; ; attempt++ {
+		pool, err := connect(ctx, poolCfg, cfg.ConnectTimeout)
+		if err == nil {
+			if attempt > 0 {
+				logger.InfoContext(ctx, "database connected", "attempts", attempt+1)
+			}
+			return pool, nil
+		}
+		if attempt+1 >= cfg.ConnectMaxAttempts || ctx.Err() != nil {
+			return nil, fmt.Errorf("connect after %d attempts: %w", attempt+1, err)
+		}
 
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		delay := b.Delay(attempt)
+		if m != nil {
+			m.ConnectRetried()
+		}
+		logger.WarnContext(ctx, "database connection failed; retrying",
+			"attempt", attempt+1, "error", err.Error(), "retry_in", delay)
+		if err := backoff.Sleep(ctx, delay); err != nil {
+			return nil, fmt.Errorf("connect after %d attempts: %w", attempt+1, err)
+		}
+	}
+}
+
+// connect は timeout 以内に接続プールを生成し、接続できることを確認する。
+func connect(ctx context.Context, poolCfg *pgxpool.Config, timeout time.Duration) (*pgxpool.Pool, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
@@ -37,3 +71,45 @@ func Connect(ctx context.Context, cfg config.DatabaseConfig) (*pgxpool.Pool, err
 
 	return pool, nil
 }
+
+// ConnectReplicas はレプリカ（cfg.ReplicaURLs）ごとに接続プールを生成する。プールの設定はプライマリと同じ。
+// 起動時には接続を確認しない。到達できないレプリカは Router が振り分け先から外す。
+func ConnectReplicas(ctx context.Context, cfg config.DatabaseConfig, tp trace.TracerProvider) ([]*pgxpool.Pool, error) {
+	pools := make([]*pgxpool.Pool, 0, len(cfg.ReplicaURLs))
+	for i, url := range cfg.ReplicaURLs {
+		pool, err := newReplicaPool(ctx, url, cfg, tp)
+		if err != nil {
+			for _, p := range pools {
+				p.Close()
+			}
+			return nil, fmt.Errorf("replica %d: %w", i, err)
+		}
+		pools = append(pools, pool)
+	}
+	return pools, nil
+}
+
+func newReplicaPool(ctx context.Context, url string, cfg config.DatabaseConfig, tp trace.TracerProvider) (*pgxpool.Pool, error) {
+	poolCfg, err := parsePoolConfig(url, cfg, tp)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		return nil, fmt.Errorf("create pool: %w", err)
+	}
+	return pool, nil
+}
+
+func parsePoolConfig(url string, cfg config.DatabaseConfig, tp trace.TracerProvider) (*pgxpool.Config, error) {
+	poolCfg, err := pgxpool.ParseConfig(url)
+	if err != nil {
+		return nil, fmt.Errorf("parse database config: %w", err)
+	}
+
+	poolCfg.MaxConns = cfg.MaxConns
+	poolCfg.MinConns = cfg.MinConns
+	poolCfg.MaxConnLifetime = cfg.MaxConnLifetime
+	poolCfg.ConnConfig.Tracer = NewQueryTracer(tp)
+	return poolCfg, nil
+}
@@ -0,0 +1,308 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DBTX はsqlcが生成した Queries（pgx/v5）がクエリの発行に使う接続。
+type DBTX interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Router のデフォルト値。
+const (
+	DefaultMaxReplicaLag        = 5 * time.Second
+	DefaultReplicaCheckInterval = 2 * time.Second
+	DefaultReadYourWritesWindow = 10 * time.Second
+)
+
+// replicaLagQuery はレプリカがプライマリからWALを受信しているかどうかと、遅延（秒）を返す。
+// 受信済みのWALを全て適用済みの場合は、プライマリで更新がないだけなので遅延なしとみなす。
+// WALの受信が止まった場合も受信済みのWALは全て適用済みになるため、pg_stat_wal_receiver で受信中かを確かめる。
+// pg_read_all_stats の権限がないロールには status が NULL で見えるため、受信のプロセスがあれば受信中とみなす。
+const replicaLagQuery = `SELECT
+    EXISTS (SELECT 1 FROM pg_stat_wal_receiver WHERE status IS NULL OR status = 'streaming'),
+    CASE
+        WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+        ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+    END::float8`
+
+// errNotStreaming はレプリカがプライマリからWALを受信していないことを表す。遅延が分からないため振り分け先から外す。
+var errNotStreaming = errors.New("replica is not streaming WAL from the primary")
+
+// RouterOptions は Router の設定。
+type RouterOptions struct {
+	// MaxLag を超えて遅延したレプリカは読み込みの振り分け先から外す。
+	MaxLag time.Duration
+	// CheckInterval はレプリカの遅延を確認する間隔。
+	CheckInterval time.Duration
+	// ReadYourWritesWindow は書き込んだセッションの読み込みをプライマリに送る期間。
+	ReadYourWritesWindow time.Duration
+	// Logger はレプリカの除外・復帰を記録する。
+	Logger *slog.Logger
+}
+
+// ReplicaStatus はレプリカの状態。
+type ReplicaStatus struct {
+	Name    string
+	Healthy bool
+	Lag     time.Duration
+}
+
+type replica struct {
+	name    string
+	db      DBTX
+	healthy atomic.Bool
+	lag     atomic.Int64
+}
+
+// Router は読み込みをレプリカ、書き込みをプライマリに振り分ける。
+// 遅延が MaxLag を超えたレプリカや遅延を確認できないレプリカは、回復するまで振り分け先から外す。
+// 読み込めるレプリカがない場合はプライマリから読む。
+//
+// 書き込んだセッション（WithSession）の読み込みは ReadYourWritesWindow の間プライマリに送り、
+// 書き込んだ内容がレプリカに反映される前に読んでも古い値を返さないようにする。
+type Router struct {
+	primary  DBTX
+	replicas []*replica
+	opts     RouterOptions
+	next     atomic.Uint64
+	now      func() time.Time
+}
+
+// NewRouter は Router を生成する。遅延の確認は Run で行い、確認が済むまではプライマリから読む。
+func NewRouter(primary *pgxpool.Pool, replicas []*pgxpool.Pool, opts RouterOptions) *Router {
+	rs := make([]*replica, len(replicas))
+	for i, pool := range replicas {
+		cfg := pool.Config().ConnConfig
+		rs[i] = &replica{name: net.JoinHostPort(cfg.Host, strconv.Itoa(int(cfg.Port))), db: pool}
+	}
+	return newRouter(primary, rs, opts)
+}
+
+func newRouter(primary DBTX, replicas []*replica, opts RouterOptions) *Router {
+	if opts.MaxLag <= 0 {
+		opts.MaxLag = DefaultMaxReplicaLag
+	}
+	if opts.CheckInterval <= 0 {
+		opts.CheckInterval = DefaultReplicaCheckInterval
+	}
+	if opts.ReadYourWritesWindow <= 0 {
+		opts.ReadYourWritesWindow = DefaultReadYourWritesWindow
+	}
+	if opts.Logger == nil {
+		opts.Logger = slog.Default()
+	}
+	return &Router{
+		primary:  primary,
+		replicas: replicas,
+		opts:     opts,
+		now:      time.Now,
+	}
+}
+
+// Writer は書き込みに使う接続を返す。全てプライマリに送り、セッションに書き込んだ時刻を記録する。
+func (r *Router) Writer() DBTX {
+	return writer{r}
+}
+
+// Reader は読み込みに使う接続を返す。書き込んだ直後のセッションを除き、読み込めるレプリカに順に振り分ける。
+func (r *Router) Reader() DBTX {
+	return reader{r}
+}
+
+// Replicas はレプリカの状態を返す。
+func (r *Router) Replicas() []ReplicaStatus {
+	statuses := make([]ReplicaStatus, len(r.replicas))
+	for i, rep := range r.replicas {
+		statuses[i] = ReplicaStatus{
+			Name:    rep.name,
+			Healthy: rep.healthy.Load(),
+			Lag:     time.Duration(rep.lag.Load()),
+		}
+	}
+	return statuses
+}
+
+// Run は ctx が終了するまで CheckInterval ごとにレプリカの遅延を確認し、振り分け先を更新する。
+func (r *Router) Run(ctx context.Context) {
+	t := time.NewTicker(r.opts.CheckInterval)
+	defer t.Stop()
+	for {
+		r.check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// check は全てのレプリカの遅延を確認する。
+func (r *Router) check(ctx context.Context) {
+	for _, rep := range r.replicas {
+		lag, err := r.replicaLag(ctx, rep)
+		if ctx.Err() != nil {
+			return
+		}
+		rep.lag.Store(int64(lag))
+		healthy := err == nil && lag <= r.opts.MaxLag
+		if rep.healthy.Swap(healthy) == healthy {
+			continue
+		}
+		switch {
+		case healthy:
+			r.opts.Logger.InfoContext(ctx, "replica available for reads", "replica", rep.name, "lag", lag)
+		case err != nil:
+			r.opts.Logger.WarnContext(ctx, "replica ejected", "replica", rep.name, "error", err.Error())
+		default:
+			r.opts.Logger.WarnContext(ctx, "replica ejected", "replica", rep.name, "lag", lag, "max_lag", r.opts.MaxLag)
+		}
+	}
+}
+
+func (r *Router) replicaLag(ctx context.Context, rep *replica) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.opts.CheckInterval)
+	defer cancel()
+	var (
+		streaming bool
+		seconds   float64
+	)
+	if err := rep.db.QueryRow(ctx, replicaLagQuery).Scan(&streaming, &seconds); err != nil {
+		return 0, fmt.Errorf("query replication lag: %w", err)
+	}
+	if !streaming {
+		return 0, errNotStreaming
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// route は読み込みに使う接続を選ぶ。
+func (r *Router) route(ctx context.Context) DBTX {
+	if r.RecentlyWrote(ctx) {
+		return r.primary
+	}
+	n := uint64(len(r.replicas))
+	start := r.next.Add(1)
+	for i := range n {
+		if rep := r.replicas[(start+i)%n]; rep.healthy.Load() {
+			return rep.db
+		}
+	}
+	return r.primary
+}
+
+// Session は読み込みの振り分けで使う、呼び出し元の最後の書き込みの時刻。
+// 時刻はプロセス内に持たず、レスポンスで呼び出し元に返して次のリクエストで送り返させる（クッキー等）。
+// そのため呼び出し元の後続のリクエストをどのインスタンスが受けても、同じようにプライマリに送られる。
+type Session struct {
+	mu        sync.Mutex
+	lastWrite time.Time
+}
+
+// NewSession は呼び出し元が送り返した最後の書き込みの時刻から Session を生成する。時刻がない場合はゼロ値を渡す。
+func NewSession(lastWrite time.Time) *Session {
+	return &Session{lastWrite: lastWrite}
+}
+
+// LastWrite は最後の書き込みの時刻を返す。書き込んでいない場合はゼロ値を返す。
+func (s *Session) LastWrite() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastWrite
+}
+
+// MarkWritten は at に書き込んだことを記録する。
+func (s *Session) MarkWritten(at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if at.After(s.lastWrite) {
+		s.lastWrite = at
+	}
+}
+
+type sessionKey struct{}
+
+// WithSession は読み込みの振り分けに使う呼び出し元のセッションをコンテキストに格納する。
+// セッションで書き込んだ後の読み込みは ReadYourWritesWindow の間プライマリに送られる。
+func WithSession(ctx context.Context, session *Session) context.Context {
+	return context.WithValue(ctx, sessionKey{}, session)
+}
+
+// SessionFromContext はコンテキストからセッションを取り出す。
+func SessionFromContext(ctx context.Context) (*Session, bool) {
+	session, ok := ctx.Value(sessionKey{}).(*Session)
+	return session, ok
+}
+
+func (r *Router) markWritten(ctx context.Context) {
+	if session, ok := SessionFromContext(ctx); ok {
+		session.MarkWritten(r.now())
+	}
+}
+
+// RecentlyWrote は ctx のセッションが ReadYourWritesWindow 以内に書き込み、読み込みをプライマリに送るかどうかを返す。
+// 時刻は他のインスタンスが記録したものでありうるため、時計のずれを見込んで未来の時刻も期間の長さまでは受け入れる。
+func (r *Router) RecentlyWrote(ctx context.Context) bool {
+	session, ok := SessionFromContext(ctx)
+	if !ok {
+		return false
+	}
+	at := session.LastWrite()
+	if at.IsZero() {
+		return false
+	}
+	elapsed := r.now().Sub(at)
+	return elapsed < r.opts.ReadYourWritesWindow && elapsed > -r.opts.ReadYourWritesWindow
+}
+
+// writer は全てのクエリをプライマリに送り、書き込みとして記録する。
+type writer struct {
+	r *Router
+}
+
+func (w writer) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	defer w.r.markWritten(ctx)
+	return w.r.primary.Exec(ctx, sql, args...)
+}
+
+func (w writer) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	defer w.r.markWritten(ctx)
+	return w.r.primary.Query(ctx, sql, args...)
+}
+
+func (w writer) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	defer w.r.markWritten(ctx)
+	return w.r.primary.QueryRow(ctx, sql, args...)
+}
+
+// reader は問い合わせをレプリカに振り分ける。Exec は書き込みとしてプライマリに送る。
+type reader struct {
+	r *Router
+}
+
+func (d reader) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return writer(d).Exec(ctx, sql, args...)
+}
+
+func (d reader) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return d.r.route(ctx).Query(ctx, sql, args...)
+}
+
+func (d reader) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return d.r.route(ctx).QueryRow(ctx, sql, args...)
+}
@@ -0,0 +1,185 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDB は遅延の問い合わせに lag または err を返し、受け取ったクエリを記録する DBTX。
+// stalled の場合はWALを受信していないと返す。
+type fakeDB struct {
+	mu      sync.Mutex
+	lag     time.Duration
+	err     error
+	stalled bool
+	queries []string
+}
+
+func (d *fakeDB) record(sql string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.queries = append(d.queries, sql)
+}
+
+func (d *fakeDB) set(lag time.Duration, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.lag, d.err = lag, err
+}
+
+func (d *fakeDB) Exec(_ context.Context, sql string, _ ...any) (pgconn.CommandTag, error) {
+	d.record(sql)
+	return pgconn.CommandTag{}, nil
+}
+
+func (d *fakeDB) Query(_ context.Context, sql string, _ ...any) (pgx.Rows, error) {
+	d.record(sql)
+	return nil, nil
+}
+
+func (d *fakeDB) QueryRow(_ context.Context, sql string, _ ...any) pgx.Row {
+	d.record(sql)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return fakeRow{lag: d.lag, err: d.err, stalled: d.stalled}
+}
+
+type fakeRow struct {
+	lag     time.Duration
+	err     error
+	stalled bool
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	*dest[0].(*bool) = !r.stalled
+	*dest[1].(*float64) = r.lag.Seconds()
+	return nil
+}
+
+func newTestRouter(primary *fakeDB, replicas ...*fakeDB) *Router {
+	rs := make([]*replica, len(replicas))
+	for i, db := range replicas {
+		rs[i] = &replica{name: string(rune('a' + i)), db: db}
+	}
+	return newRouter(primary, rs, RouterOptions{
+		MaxLag:               time.Second,
+		ReadYourWritesWindow: 5 * time.Second,
+		Logger:               slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+}
+
+func TestRouter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("遅延を確認するまではプライマリから読む", func(t *testing.T) {
+		primary, replica := &fakeDB{}, &fakeDB{}
+		r := newTestRouter(primary, replica)
+
+		assert.Same(t, primary, r.route(ctx))
+	})
+
+	t.Run("遅延が上限以内のレプリカに順に振り分ける", func(t *testing.T) {
+		primary, a, b := &fakeDB{}, &fakeDB{}, &fakeDB{lag: 500 * time.Millisecond}
+		r := newTestRouter(primary, a, b)
+		r.check(ctx)
+
+		got := []DBTX{r.route(ctx), r.route(ctx), r.route(ctx), r.route(ctx)}
+		assert.ElementsMatch(t, []DBTX{a, a, b, b}, got)
+		assert.Equal(t, []ReplicaStatus{
+			{Name: "a", Healthy: true},
+			{Name: "b", Healthy: true, Lag: 500 * time.Millisecond},
+		}, r.Replicas())
+	})
+
+	t.Run("遅延が上限を超えたレプリカや確認に失敗したレプリカは外し、回復すると戻す", func(t *testing.T) {
+		primary, a, b := &fakeDB{}, &fakeDB{lag: 2 * time.Second}, &fakeDB{}
+		r := newTestRouter(primary, a, b)
+		r.check(ctx)
+
+		for range 4 {
+			assert.Same(t, b, r.route(ctx))
+		}
+
+		b.set(0, errors.New("connection refused"))
+		r.check(ctx)
+		assert.Same(t, primary, r.route(ctx), "読み込めるレプリカがない場合はプライマリから読むべき")
+
+		a.set(0, nil)
+		r.check(ctx)
+		assert.Same(t, a, r.route(ctx))
+	})
+
+	t.Run("WALの受信が止まったレプリカは遅延がなく見えても外す", func(t *testing.T) {
+		primary, a, b := &fakeDB{}, &fakeDB{stalled: true}, &fakeDB{}
+		r := newTestRouter(primary, a, b)
+		r.check(ctx)
+
+		for range 4 {
+			assert.Same(t, b, r.route(ctx))
+		}
+		assert.False(t, r.Replicas()[0].Healthy)
+	})
+
+	t.Run("書き込んだセッションの読み込みは期間の間プライマリに送る", func(t *testing.T) {
+		primary, replica := &fakeDB{}, &fakeDB{}
+		r := newTestRouter(primary, replica)
+		now := time.Now()
+		r.now = func() time.Time { return now }
+		r.check(ctx)
+		session := NewSession(time.Time{})
+		writer := WithSession(ctx, session)
+		other := WithSession(ctx, NewSession(time.Time{}))
+
+		_, err := r.Writer().Exec(writer, "UPDATE users SET name = $1")
+		require.NoError(t, err)
+
+		assert.Equal(t, now, session.LastWrite())
+		assert.Same(t, primary, r.route(writer))
+		assert.Same(t, replica, r.route(other), "他のセッションはレプリカから読むべき")
+		assert.Same(t, replica, r.route(ctx), "セッションのない読み込みはレプリカから読むべき")
+
+		now = now.Add(5 * time.Second)
+		assert.Same(t, replica, r.route(writer), "期間を過ぎるとレプリカから読むべき")
+	})
+
+	t.Run("呼び出し元が送り返した書き込みの時刻が期間内であればプライマリに送る", func(t *testing.T) {
+		primary, replica := &fakeDB{}, &fakeDB{}
+		r := newTestRouter(primary, replica)
+		now := time.Now()
+		r.now = func() time.Time { return now }
+		r.check(ctx)
+
+		assert.Same(t, primary, r.route(WithSession(ctx, NewSession(now.Add(-4*time.Second)))))
+		assert.Same(t, replica, r.route(WithSession(ctx, NewSession(now.Add(-5*time.Second)))), "期間を過ぎた時刻はレプリカから読むべき")
+		assert.Same(t, primary, r.route(WithSession(ctx, NewSession(now.Add(time.Second)))), "時計のずれによる未来の時刻も期間内として扱うべき")
+		assert.Same(t, replica, r.route(WithSession(ctx, NewSession(now.Add(time.Hour)))), "期間より先の時刻は受け入れないべき")
+	})
+
+	t.Run("書き込みはレプリカに送らない", func(t *testing.T) {
+		primary, replica := &fakeDB{}, &fakeDB{}
+		r := newTestRouter(primary, replica)
+		r.check(ctx)
+		session := WithSession(ctx, NewSession(time.Time{}))
+
+		_, err := r.Reader().Exec(session, "DELETE FROM users")
+		require.NoError(t, err)
+		_ = r.Writer().QueryRow(ctx, "INSERT INTO users DEFAULT VALUES RETURNING id")
+
+		assert.Equal(t, []string{"DELETE FROM users", "INSERT INTO users DEFAULT VALUES RETURNING id"}, primary.queries)
+		assert.Equal(t, []string{replicaLagQuery}, replica.queries)
+		assert.Same(t, primary, r.route(session), "Reader の Exec も書き込みとして記録するべき")
+	})
+}
@@ -0,0 +1,111 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"syscall"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"go-api/internal/backoff"
+)
+
+// RetryPolicy のデフォルト値。
+const DefaultRetryMaxAttempts = 3
+
+// PostgreSQL エラーコード
+const (
+	pgAdminShutdown        = "57P01"
+	pgSerializationFailure = "40001"
+)
+
+// RetryMetrics は読み込みの再試行を記録する。
+type RetryMetrics interface {
+	// Retried は op を reason（connection_reset・admin_shutdown・serialization_failure）で再試行したことを記録する。
+	Retried(op, reason string)
+}
+
+// RetryOptions は RetryPolicy の設定。
+type RetryOptions struct {
+	// MaxAttempts は最初の試行を含めた試行回数の上限。1 の場合は再試行しない。
+	MaxAttempts int
+	// Backoff は再試行までの待ち時間。
+	Backoff backoff.Backoff
+	// Metrics は nil の場合は記録しない。
+	Metrics RetryMetrics
+	// Logger は再試行を記録する。
+	Logger *slog.Logger
+}
+
+// RetryPolicy は一時的なエラーで失敗した読み込みを再試行する。
+// 再試行してよいのは何度実行しても結果が変わらない読み込みだけで、書き込みには使わない。
+type RetryPolicy struct {
+	opts RetryOptions
+}
+
+// NewRetryPolicy は RetryPolicy を生成する。
+func NewRetryPolicy(opts RetryOptions) *RetryPolicy {
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = DefaultRetryMaxAttempts
+	}
+	if opts.Logger == nil {
+		opts.Logger = slog.Default()
+	}
+	return &RetryPolicy{opts: opts}
+}
+
+// Do は fn を実行し、一時的なエラーで失敗した場合は MaxAttempts 回まで再試行する。
+// ctx が終了した場合や、再試行までに ctx の期限が来る場合は最後のエラーを返す。
+// p が nil の場合は再試行しない。
+func (p *RetryPolicy) Do(ctx context.Context, op string, fn func() error) error {
+	if p == nil {
+		return fn()
+	}
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || attempt+1 >= p.opts.MaxAttempts || ctx.Err() != nil {
+			return err
+		}
+		reason, ok := transientReason(err)
+		if !ok {
+			return err
+		}
+
+		delay := p.opts.Backoff.Delay(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= delay {
+			return err
+		}
+		if p.opts.Metrics != nil {
+			p.opts.Metrics.Retried(op, reason)
+		}
+		p.opts.Logger.WarnContext(ctx, "retrying database read",
+			"op", op, "attempt", attempt+1, "reason", reason, "error", err.Error(), "retry_in", delay)
+		if backoff.Sleep(ctx, delay) != nil {
+			return err
+		}
+	}
+}
+
+// transientReason は再試行すれば成功しうるエラーの場合に、その理由を返す。
+func transientReason(err error) (string, bool) {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return "", false
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgAdminShutdown:
+			return "admin_shutdown", true
+		case pgSerializationFailure:
+			return "serialization_failure", true
+		}
+		return "", false
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) || pgconn.SafeToRetry(err) {
+		return "connection_reset", true
+	}
+	return "", false
+}
@@ -0,0 +1,126 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+
+	"go-api/internal/backoff"
+)
+
+// retryMetrics は再試行の理由を op ごとに記録する RetryMetrics。
+type retryMetrics map[string][]string
+
+func (m retryMetrics) Retried(op, reason string) { m[op] = append(m[op], reason) }
+
+func newTestRetryPolicy(m RetryMetrics) *RetryPolicy {
+	return NewRetryPolicy(RetryOptions{
+		MaxAttempts: 3,
+		Backoff:     backoff.Backoff{Min: time.Millisecond, Max: time.Millisecond},
+		Metrics:     m,
+		Logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+}
+
+// failing は最初の n 回 err を返す関数と、呼ばれた回数を返す。
+func failing(n int, err error) (func() error, *int) {
+	calls := 0
+	return func() error {
+		calls++
+		if calls <= n {
+			return err
+		}
+		return nil
+	}, &calls
+}
+
+func TestTransientReason(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		reason string
+	}{
+		{"接続のリセット", fmt.Errorf("read: %w", syscall.ECONNRESET), "connection_reset"},
+		{"接続が途中で切れた", fmt.Errorf("receive message: %w", io.ErrUnexpectedEOF), "connection_reset"},
+		{"管理者による停止", &pgconn.PgError{Code: "57P01"}, "admin_shutdown"},
+		{"シリアライゼーションの失敗", &pgconn.PgError{Code: "40001"}, "serialization_failure"},
+		{"その他のPostgreSQLのエラー", &pgconn.PgError{Code: "23505"}, ""},
+		{"コンテキストの期限切れ", context.DeadlineExceeded, ""},
+		{"その他のエラー", errors.New("boom"), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, ok := transientReason(tt.err)
+			assert.Equal(t, tt.reason, reason)
+			assert.Equal(t, tt.reason != "", ok)
+		})
+	}
+}
+
+func TestRetryPolicy_Do(t *testing.T) {
+	ctx := context.Background()
+	shutdown := &pgconn.PgError{Code: "57P01"}
+
+	t.Run("一時的なエラーは再試行して記録する", func(t *testing.T) {
+		m := retryMetrics{}
+		fn, calls := failing(2, shutdown)
+
+		err := newTestRetryPolicy(m).Do(ctx, "FindByID", fn)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, *calls)
+		assert.Equal(t, retryMetrics{"FindByID": {"admin_shutdown", "admin_shutdown"}}, m)
+	})
+
+	t.Run("試行回数の上限に達すると最後のエラーを返す", func(t *testing.T) {
+		fn, calls := failing(5, shutdown)
+
+		err := newTestRetryPolicy(nil).Do(ctx, "FindAll", fn)
+
+		assert.ErrorIs(t, err, shutdown)
+		assert.Equal(t, 3, *calls)
+	})
+
+	t.Run("一時的でないエラーは再試行しない", func(t *testing.T) {
+		boom := errors.New("boom")
+		fn, calls := failing(1, boom)
+
+		err := newTestRetryPolicy(nil).Do(ctx, "FindAll", fn)
+
+		assert.ErrorIs(t, err, boom)
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("再試行までに期限が来る場合は待たずに返す", func(t *testing.T) {
+		p := newTestRetryPolicy(nil)
+		p.opts.Backoff = backoff.Backoff{Min: time.Minute, Max: time.Minute}
+		ctx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		fn, calls := failing(1, shutdown)
+
+		start := time.Now()
+		err := p.Do(ctx, "FindByID", fn)
+
+		assert.ErrorIs(t, err, shutdown)
+		assert.Equal(t, 1, *calls)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("nil の場合は再試行しない", func(t *testing.T) {
+		var p *RetryPolicy
+		fn, calls := failing(1, shutdown)
+
+		err := p.Do(ctx, "FindByID", fn)
+
+		assert.ErrorIs(t, err, shutdown)
+		assert.Equal(t, 1, *calls)
+	})
+}
@@ -0,0 +1,127 @@
+package database
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite" // database/sql の "sqlite" ドライバ（cgo 不要）
+
+	sqlitemigrations "go-api/db/sqlite/migrations"
+	"go-api/internal/config"
+	"go-api/internal/health"
+)
+
+// sqlitePragmas は接続ごとに設定するプラグマ。
+// 書き込み中も読み込めるよう WAL にし、ロックが競合した場合はエラーにせず待つ。
+var sqlitePragmas = []string{"busy_timeout(5000)", "journal_mode(WAL)", "foreign_keys(1)"}
+
+// OpenSQLite はSQLiteのデータベースファイルを開き、埋め込まれたマイグレーションのうち未適用のものを適用する。
+func OpenSQLite(ctx context.Context, cfg config.SQLiteConfig) (*sql.DB, error) {
+	// トランザクションは開始時に書き込みのロックを取り、途中でロックを昇格できずに失敗するのを防ぐ
+	params := url.Values{"_pragma": sqlitePragmas, "_txlock": {"immediate"}}
+	db, err := sql.Open("sqlite", "file:"+cfg.Path+"?"+params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("ping database: %w", err)
+	}
+	if err := migrateSQLite(ctx, db, sqlitemigrations.FS); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("migrate database: %w", err)
+	}
+	return db, nil
+}
+
+// SQLitePingCheck はSQLiteのデータベースにアクセスできるかを確認する。
+func SQLitePingCheck(db *sql.DB) health.CheckFunc {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
+
+// migrateSQLite は fsys の up マイグレーションのうち未適用のものを順に適用する。
+// 適用済みのバージョンは golang-migrate と同じ schema_migrations に記録するため、migrate CLI でも扱える。
+// 各マイグレーションはトランザクションで適用し、同時に起動した他のプロセスと重複して適用しない。
+func migrateSQLite(ctx context.Context, db *sql.DB, fsys fs.FS) error {
+	if _, err := db.ExecContext(ctx,
+		"CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)",
+	); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	names, err := fs.Glob(fsys, "*.up.sql")
+	if err != nil {
+		return err
+	}
+	versions := make(map[string]uint, len(names))
+	for _, name := range names {
+		prefix, _, _ := strings.Cut(name, "_")
+		v, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid migration file name %q: %w", name, err)
+		}
+		versions[name] = uint(v)
+	}
+	slices.SortFunc(names, func(a, b string) int { return cmp.Compare(versions[a], versions[b]) })
+
+	for _, name := range names {
+		if err := applySQLiteMigration(ctx, db, fsys, name, versions[name]); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// applySQLiteMigration は適用済みのバージョンが version より前の場合に name を適用する。
+func applySQLiteMigration(ctx context.Context, db *sql.DB, fsys fs.FS, name string, version uint) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var (
+		current int64
+		dirty   bool
+	)
+	err = tx.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&current, &dirty)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("query migration version: %w", err)
+	}
+	if dirty {
+		return fmt.Errorf("migration version %d is dirty", current)
+	}
+	if current >= int64(version) {
+		return nil
+	}
+
+	script, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, string(script)); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations"); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, dirty) VALUES (?, false)", version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,109 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/config"
+)
+
+func openTestSQLite(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func migrationVersion(t *testing.T, db *sql.DB) (version int64, dirty bool) {
+	t.Helper()
+	err := db.QueryRow("SELECT version, dirty FROM schema_migrations").Scan(&version, &dirty)
+	require.NoError(t, err)
+	return version, dirty
+}
+
+func TestOpenSQLite(t *testing.T) {
+	t.Run("ファイルを作成して全てのマイグレーションを適用する", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "go-api.db")
+
+		db, err := OpenSQLite(context.Background(), config.SQLiteConfig{Path: path})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+
+		version, dirty := migrationVersion(t, db)
+		assert.Equal(t, int64(4), version)
+		assert.False(t, dirty)
+		require.NoError(t, SQLitePingCheck(db)(context.Background()))
+	})
+
+	t.Run("開き直しても適用済みのマイグレーションは再適用しない", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "go-api.db")
+		db, err := OpenSQLite(context.Background(), config.SQLiteConfig{Path: path})
+		require.NoError(t, err)
+		_, err = db.Exec("INSERT INTO users (id, name, email) VALUES ('1', 'test', 'test@example.com')")
+		require.NoError(t, err)
+		require.NoError(t, db.Close())
+
+		db, err = OpenSQLite(context.Background(), config.SQLiteConfig{Path: path})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+
+		var n int
+		require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM users").Scan(&n))
+		assert.Equal(t, 1, n)
+	})
+}
+
+func TestMigrateSQLite(t *testing.T) {
+	t.Run("未適用のマイグレーションだけを番号順に適用する", func(t *testing.T) {
+		db := openTestSQLite(t)
+		fsys := fstest.MapFS{
+			"000001_a.up.sql":   {Data: []byte("CREATE TABLE a (id INTEGER);")},
+			"000001_a.down.sql": {Data: []byte("DROP TABLE a;")},
+		}
+		require.NoError(t, migrateSQLite(context.Background(), db, fsys))
+
+		fsys["000010_c.up.sql"] = &fstest.MapFile{Data: []byte("INSERT INTO b (id) VALUES (1);")}
+		fsys["000002_b.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE b (id INTEGER);")}
+		require.NoError(t, migrateSQLite(context.Background(), db, fsys))
+
+		version, _ := migrationVersion(t, db)
+		assert.Equal(t, int64(10), version)
+		var n int
+		require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM b").Scan(&n))
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("失敗したマイグレーションはロールバックし、バージョンを進めない", func(t *testing.T) {
+		db := openTestSQLite(t)
+		fsys := fstest.MapFS{
+			"000001_a.up.sql": {Data: []byte("CREATE TABLE a (id INTEGER);")},
+			"000002_b.up.sql": {Data: []byte("CREATE TABLE b (id INTEGER); INSERT INTO missing VALUES (1);")},
+		}
+
+		err := migrateSQLite(context.Background(), db, fsys)
+		assert.ErrorContains(t, err, "000002_b.up.sql")
+
+		version, dirty := migrationVersion(t, db)
+		assert.Equal(t, int64(1), version)
+		assert.False(t, dirty)
+		_, err = db.Exec("SELECT * FROM b")
+		assert.Error(t, err, "失敗したマイグレーションの変更は残らないべき")
+	})
+
+	t.Run("dirtyの場合はエラー", func(t *testing.T) {
+		db := openTestSQLite(t)
+		_, err := db.Exec("CREATE TABLE schema_migrations (version INTEGER NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL);" +
+			"INSERT INTO schema_migrations VALUES (1, true);")
+		require.NoError(t, err)
+
+		err = migrateSQLite(context.Background(), db, fstest.MapFS{"000002_b.up.sql": {Data: []byte("SELECT 1;")}})
+		assert.ErrorContains(t, err, "migration version 1 is dirty")
+	})
+}
@@ -0,0 +1,93 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// QueryTracer はpgxのクエリごとにクライアントスパンを記録する pgx.QueryTracer の実装。
+type QueryTracer struct {
+	tracer trace.Tracer
+}
+
+var _ pgx.QueryTracer = (*QueryTracer)(nil)
+
+// NewQueryTracer は QueryTracer を生成する。
+func NewQueryTracer(tp trace.TracerProvider) *QueryTracer {
+	return &QueryTracer{tracer: tp.Tracer("go-api/database")}
+}
+
+// TraceQueryStart は pgx.QueryTracer を実装する。
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	op := operationName(data.SQL)
+	name := op
+	if name == "" {
+		name = semconv.DBSystemNamePostgreSQL.Value.AsString()
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.DBSystemNamePostgreSQL,
+		semconv.DBQueryText(data.SQL),
+	}
+	if op != "" {
+		attrs = append(attrs, semconv.DBOperationName(op))
+	}
+	if conn != nil {
+		cfg := conn.Config()
+		attrs = append(attrs,
+			semconv.DBNamespace(cfg.Database),
+			semconv.ServerAddress(cfg.Host),
+			semconv.ServerPort(int(cfg.Port)),
+		)
+	}
+
+	ctx, _ = t.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	return ctx
+}
+
+// TraceQueryEnd は pgx.QueryTracer を実装する。
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	defer span.End()
+
+	if data.Err == nil || errors.Is(data.Err, pgx.ErrNoRows) {
+		return
+	}
+
+	errType := "_OTHER"
+	var pgErr *pgconn.PgError
+	if errors.As(data.Err, &pgErr) {
+		errType = pgErr.Code
+		span.SetAttributes(semconv.DBResponseStatusCode(pgErr.Code))
+	}
+	span.SetAttributes(semconv.ErrorTypeKey.String(errType))
+	span.RecordError(data.Err)
+	span.SetStatus(codes.Error, data.Err.Error())
+}
+
+// operationName はSQLの先頭のキーワード（SELECT・INSERT 等）を返す。
+// sqlc が付与する "-- name: ..." のような行コメントは読み飛ばす。
+func operationName(sql string) string {
+	for _, line := range strings.Split(sql, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "--") {
+			continue
+		}
+		if i := strings.IndexAny(line, " \t(;"); i >= 0 {
+			line = line[:i]
+		}
+		return strings.ToUpper(line)
+	}
+	return ""
+}
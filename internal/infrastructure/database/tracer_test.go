@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestQueryTracer(t *testing.T) {
+	newTracer := func() (*QueryTracer, *tracetest.SpanRecorder) {
+		sr := tracetest.NewSpanRecorder()
+		return NewQueryTracer(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))), sr
+	}
+
+	t.Run("クエリごとにクライアントスパンを記録する", func(t *testing.T) {
+		tracer, sr := newTracer()
+		sql := "-- name: GetUser :one\nSELECT id, name FROM users WHERE id = $1"
+
+		ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: sql})
+		tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: pgx.ErrNoRows})
+
+		spans := sr.Ended()
+		require.Len(t, spans, 1)
+		span := spans[0]
+		assert.Equal(t, "SELECT", span.Name())
+		assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+		assert.Equal(t, codes.Unset, span.Status().Code)
+		attrs := attribute.NewSet(span.Attributes()...)
+		system, _ := attrs.Value("db.system.name")
+		assert.Equal(t, "postgresql", system.AsString())
+		op, _ := attrs.Value("db.operation.name")
+		assert.Equal(t, "SELECT", op.AsString())
+		text, _ := attrs.Value("db.query.text")
+		assert.Equal(t, sql, text.AsString())
+	})
+
+	t.Run("PostgreSQLのエラーはSQLSTATEを記録する", func(t *testing.T) {
+		tracer, sr := newTracer()
+
+		ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "INSERT INTO users VALUES ($1)"})
+		tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: &pgconn.PgError{Code: "23505"}})
+
+		spans := sr.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		attrs := attribute.NewSet(spans[0].Attributes()...)
+		code, _ := attrs.Value("db.response.status_code")
+		assert.Equal(t, "23505", code.AsString())
+		errType, _ := attrs.Value("error.type")
+		assert.Equal(t, "23505", errType.AsString())
+	})
+}
+
+func TestOperationName(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want string
+	}{
+		{sql: "select 1", want: "SELECT"},
+		{sql: "-- name: CreateUser :exec\nINSERT INTO users (id) VALUES ($1)", want: "INSERT"},
+		{sql: "begin", want: "BEGIN"},
+		{sql: "WITH t AS (SELECT 1) SELECT * FROM t", want: "WITH"},
+		{sql: "-- comment only", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sql, func(t *testing.T) {
+			assert.Equal(t, tt.want, operationName(tt.sql))
+		})
+	}
+}
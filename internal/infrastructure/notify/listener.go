@@ -0,0 +1,304 @@
+// Package notify はPostgreSQLの LISTEN/NOTIFY でユーザーの変更を受け取り、プロセス内の購読者に配る。
+// 他のレプリカで行われた変更を、キャッシュの無効化や SSE の配信に反映するために使う。
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"go-api/internal/backoff"
+	"go-api/internal/domain/user"
+	"go-api/internal/domain/user/valueobject"
+)
+
+// Channel はユーザーの変更を通知するチャネル。user_events テーブルのトリガーが NOTIFY する。
+const Channel = "user_events"
+
+// Listener のデフォルト値。
+const (
+	DefaultMinBackoff       = 500 * time.Millisecond
+	DefaultMaxBackoff       = 30 * time.Second
+	DefaultCatchUpBatchSize = 100
+)
+
+// closeTimeout は切断時に接続を閉じるのを待つ上限。
+const closeTimeout = 5 * time.Second
+
+// Change はユーザーの変更の通知。
+type Change struct {
+	Seq    int64 // user_events の連番
+	Type   valueobject.EventType
+	UserID valueobject.UserID
+}
+
+// Options は Listener の設定。
+type Options struct {
+	// Backoff は再接続の間隔。
+	Backoff backoff.Backoff
+	// CatchUpBatchSize は再接続後に切断中の変更を取得する際の1回の件数。
+	CatchUpBatchSize int
+	// Logger は切断・再接続を記録する。
+	Logger *slog.Logger
+}
+
+// listenConn は LISTEN に使う専用の接続。
+type listenConn interface {
+	listen(ctx context.Context, channel string) error
+	waitForNotification(ctx context.Context) (*pgconn.Notification, error)
+	close(ctx context.Context) error
+}
+
+// Listener は専用の接続で LISTEN し、受け取った変更をプロセス内の購読者に配る。
+// 切断された場合はバックオフを挟んで再接続し、切断中に記録された変更を user_events から取得して配る。
+// 同じ変更が重複して配られることがあるため、購読者は冪等に処理する。
+type Listener struct {
+	connect func(ctx context.Context) (listenConn, error)
+	events  user.EventRepository
+	opts    Options
+
+	// cursor は切断中の変更の取得を始める位置。Run のゴルーチンだけが参照・更新する
+	cursor user.EventPosition
+	ready  bool
+
+	mu         sync.Mutex
+	subs       map[int]func(Change)
+	reconnects map[int]func()
+	nextID     int
+}
+
+// NewListener は Listener を生成する。接続は connConfig で都度作成し、コネクションプールの接続は使わない。
+// events は再接続後に切断中の変更を取得するために使う。
+func NewListener(connConfig *pgx.ConnConfig, events user.EventRepository, opts Options) *Listener {
+	return newListener(func(ctx context.Context) (listenConn, error) {
+		conn, err := pgx.ConnectConfig(ctx, connConfig.Copy())
+		if err != nil {
+			return nil, err
+		}
+		return pgxConn{conn}, nil
+	}, events, opts)
+}
+
+func newListener(connect func(context.Context) (listenConn, error), events user.EventRepository, opts Options) *Listener {
+	if opts.Backoff.Min <= 0 {
+		opts.Backoff.Min = DefaultMinBackoff
+	}
+	if opts.Backoff.Max <= 0 {
+		opts.Backoff.Max = DefaultMaxBackoff
+	}
+	if opts.CatchUpBatchSize <= 0 {
+		opts.CatchUpBatchSize = DefaultCatchUpBatchSize
+	}
+	if opts.Logger == nil {
+		opts.Logger = slog.Default()
+	}
+	return &Listener{
+		connect:    connect,
+		events:     events,
+		opts:       opts,
+		subs:       make(map[int]func(Change)),
+		reconnects: make(map[int]func()),
+	}
+}
+
+// Subscribe は変更を受け取る関数を登録し、登録を解除する関数を返す。
+// fn は Listener のゴルーチンで順に呼ばれるため、ブロックせずにすぐ戻ること。
+func (l *Listener) Subscribe(fn func(Change)) (unsubscribe func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	id := l.nextID
+	l.nextID++
+	l.subs[id] = fn
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		delete(l.subs, id)
+	}
+}
+
+// OnReconnect は再接続して切断中の変更を配り終えた後に呼ぶ関数を登録し、登録を解除する関数を返す。
+// 切断中の変更は user_events から取得して配るが、保持期間を過ぎて削除された変更は配れないため、
+// 購読者はキャッシュ全体を破棄する等で取りこぼしに備える。fn は Listener のゴルーチンで呼ばれる。
+func (l *Listener) OnReconnect(fn func()) (unsubscribe func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	id := l.nextID
+	l.nextID++
+	l.reconnects[id] = fn
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		delete(l.reconnects, id)
+	}
+}
+
+// Run は ctx が終了するまで変更を受け取って購読者に配る。切断された場合は再接続する。
+func (l *Listener) Run(ctx context.Context) {
+	for attempt := 0; ; attempt++ {
+		connected, err := l.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			attempt = 0
+		}
+		delay := l.opts.Backoff.Delay(attempt)
+		l.opts.Logger.WarnContext(ctx, "user event listener disconnected", "error", err.Error(), "retry_in", delay)
+
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// listen は接続して LISTEN し、切断されるまで通知を配る。LISTEN と取りこぼしの取得まで済んだ場合は connected を true で返す。
+func (l *Listener) listen(ctx context.Context) (connected bool, err error) {
+	conn, err := l.connect(ctx)
+	if err != nil {
+		return false, fmt.Errorf("connect: %w", err)
+	}
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+		defer cancel()
+		_ = conn.close(closeCtx)
+	}()
+
+	if err := conn.listen(ctx, Channel); err != nil {
+		return false, fmt.Errorf("listen: %w", err)
+	}
+	// LISTEN の後に取得することで、取得から通知の受信までの間の変更を取りこぼさない
+	reconnected := l.ready
+	n, err := l.catchUp(ctx)
+	if err != nil {
+		return false, fmt.Errorf("catch up: %w", err)
+	}
+	l.opts.Logger.InfoContext(ctx, "listening for user events", "channel", Channel, "caught_up", n)
+	if reconnected {
+		l.notifyReconnect()
+	}
+
+	for {
+		notification, err := conn.waitForNotification(ctx)
+		if err != nil {
+			return true, fmt.Errorf("wait for notification: %w", err)
+		}
+		change, err := parsePayload(notification.Payload)
+		if err != nil {
+			l.opts.Logger.WarnContext(ctx, "invalid user event notification", "payload", notification.Payload, "error", err.Error())
+			continue
+		}
+		l.dispatch(change)
+	}
+}
+
+// catchUp は前回の取得を終えた位置より後に記録された変更を配り、その件数を返す。
+// 初回の接続では配り始める位置を決めるだけで、過去の変更は配らない。
+// 通知はコミットの順に届き、取得の順（user.EventPosition）とは一致しないため、通知を受けても位置は進めない。
+// そのため前回の接続中に通知で配った変更も重複して配る。
+func (l *Listener) catchUp(ctx context.Context) (int, error) {
+	if !l.ready {
+		latest, err := l.events.Latest(ctx)
+		if err != nil {
+			return 0, err
+		}
+		l.cursor, l.ready = latest, true
+		return 0, nil
+	}
+
+	total := 0
+	for {
+		events, err := l.events.FindAfter(ctx, l.cursor, l.opts.CatchUpBatchSize)
+		if err != nil {
+			return total, err
+		}
+		for _, e := range events {
+			l.dispatch(Change{Seq: e.Seq, Type: e.Type, UserID: e.User.ID()})
+		}
+		if len(events) > 0 {
+			l.cursor = events[len(events)-1].Position()
+		}
+		total += len(events)
+		if len(events) < l.opts.CatchUpBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// dispatch は変更を全ての購読者に配る。
+func (l *Listener) dispatch(change Change) {
+	l.mu.Lock()
+	subs := make([]func(Change), 0, len(l.subs))
+	for _, fn := range l.subs {
+		subs = append(subs, fn)
+	}
+	l.mu.Unlock()
+
+	for _, fn := range subs {
+		fn(change)
+	}
+}
+
+// notifyReconnect は OnReconnect で登録された関数を呼ぶ。
+func (l *Listener) notifyReconnect() {
+	l.mu.Lock()
+	fns := make([]func(), 0, len(l.reconnects))
+	for _, fn := range l.reconnects {
+		fns = append(fns, fn)
+	}
+	l.mu.Unlock()
+
+	for _, fn := range fns {
+		fn()
+	}
+}
+
+// payload はトリガーが送る通知の内容。
+type payload struct {
+	Seq    int64  `json:"seq"`
+	Type   string `json:"type"`
+	UserID string `json:"user_id"`
+}
+
+func parsePayload(s string) (Change, error) {
+	var p payload
+	if err := json.Unmarshal([]byte(s), &p); err != nil {
+		return Change{}, err
+	}
+	typ, err := valueobject.ParseEventType(p.Type)
+	if err != nil {
+		return Change{}, err
+	}
+	id, err := valueobject.ParseUserID(p.UserID)
+	if err != nil {
+		return Change{}, err
+	}
+	return Change{Seq: p.Seq, Type: typ, UserID: id}, nil
+}
+
+// pgxConn は pgx の接続で listenConn を実装する。
+type pgxConn struct {
+	conn *pgx.Conn
+}
+
+func (c pgxConn) listen(ctx context.Context, channel string) error {
+	_, err := c.conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize())
+	return err
+}
+
+func (c pgxConn) waitForNotification(ctx context.Context) (*pgconn.Notification, error) {
+	return c.conn.WaitForNotification(ctx)
+}
+
+func (c pgxConn) close(ctx context.Context) error {
+	return c.conn.Close(ctx)
+}
@@ -0,0 +1,298 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/backoff"
+	"go-api/internal/domain/user"
+	"go-api/internal/domain/user/mocks"
+	"go-api/internal/domain/user/valueobject"
+	"go-api/internal/testutil/factory"
+)
+
+var errDisconnected = errors.New("disconnected")
+
+// fakeConn は通知をテストから送れる listenConn。
+type fakeConn struct {
+	notifications chan string
+	disconnected  chan struct{}
+	once          sync.Once
+}
+
+func newFakeConn() *fakeConn {
+	return &fakeConn{notifications: make(chan string), disconnected: make(chan struct{})}
+}
+
+func (c *fakeConn) listen(context.Context, string) error { return nil }
+
+func (c *fakeConn) waitForNotification(ctx context.Context) (*pgconn.Notification, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-c.disconnected:
+		return nil, errDisconnected
+	case payload := <-c.notifications:
+		return &pgconn.Notification{Channel: Channel, Payload: payload}, nil
+	}
+}
+
+func (c *fakeConn) close(context.Context) error {
+	c.disconnect()
+	return nil
+}
+
+func (c *fakeConn) disconnect() {
+	c.once.Do(func() { close(c.disconnected) })
+}
+
+// fakeDialer は connect のたびに新しい fakeConn を返す。failures 回目までは接続に失敗する。
+type fakeDialer struct {
+	failures int
+	attempts int
+	conns    chan *fakeConn
+}
+
+func (d *fakeDialer) connect(context.Context) (listenConn, error) {
+	d.attempts++
+	if d.attempts <= d.failures {
+		return nil, fmt.Errorf("connection refused (attempt %d)", d.attempts)
+	}
+	c := newFakeConn()
+	d.conns <- c
+	return c, nil
+}
+
+// eventLog は記録済みのイベントを保持し、EventRepository のモックから返す。
+type eventLog struct {
+	mu     sync.Mutex
+	events []*user.Event
+}
+
+func newEventLog(t *testing.T, events ...*user.Event) (*eventLog, *mocks.MockEventRepository) {
+	l := &eventLog{events: events}
+	repo := mocks.NewMockEventRepository(t)
+	repo.EXPECT().FindAfter(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(l.findAfter).Maybe()
+	repo.EXPECT().Latest(mock.Anything).RunAndReturn(l.latest).Maybe()
+	return l, repo
+}
+
+func (l *eventLog) append(events ...*user.Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, events...)
+}
+
+func (l *eventLog) findAfter(_ context.Context, after user.EventPosition, limit int) ([]*user.Event, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var found []*user.Event
+	for _, e := range l.events {
+		if after.Before(e.Position()) && len(found) < limit {
+			found = append(found, e)
+		}
+	}
+	return found, nil
+}
+
+func (l *eventLog) latest(context.Context) (user.EventPosition, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.events) == 0 {
+		return user.EventPosition{}, nil
+	}
+	return l.events[len(l.events)-1].Position(), nil
+}
+
+// startListener は Listener を起動し、受け取った変更を送るチャネルを返す。
+func startListener(t *testing.T, d *fakeDialer, repo user.EventRepository) <-chan Change {
+	t.Helper()
+	l := newListener(d.connect, repo, Options{
+		Backoff:          backoff.Backoff{Min: time.Millisecond, Max: 5 * time.Millisecond},
+		CatchUpBatchSize: 2,
+		Logger:           slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+	changes := make(chan Change, 10)
+	l.Subscribe(func(c Change) { changes <- c })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		l.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return changes
+}
+
+func waitConn(t *testing.T, d *fakeDialer) *fakeConn {
+	t.Helper()
+	select {
+	case c := <-d.conns:
+		return c
+	case <-time.After(time.Second):
+		t.Fatal("接続されない")
+		return nil
+	}
+}
+
+// receiveSeqs は n 件の変更を受け取り、Seq を返す。
+func receiveSeqs(t *testing.T, changes <-chan Change, n int) []int64 {
+	t.Helper()
+	var seqs []int64
+	for len(seqs) < n {
+		select {
+		case c := <-changes:
+			seqs = append(seqs, c.Seq)
+		case <-time.After(time.Second):
+			t.Fatalf("変更を受け取れない（受け取り済み: %v）", seqs)
+		}
+	}
+	return seqs
+}
+
+func payloadOf(seq int64, typ valueobject.EventType, u *user.User) string {
+	return fmt.Sprintf(`{"seq":%d,"type":%q,"user_id":%q}`, seq, typ.String(), u.ID().String())
+}
+
+func TestListener(t *testing.T) {
+	t.Run("通知された変更を購読者に配る", func(t *testing.T) {
+		u := factory.NewUser()
+		_, repo := newEventLog(t)
+		d := &fakeDialer{conns: make(chan *fakeConn, 1)}
+		changes := startListener(t, d, repo)
+		conn := waitConn(t, d)
+
+		conn.notifications <- payloadOf(1, valueobject.EventUserUpdated, u)
+
+		c := <-changes
+		assert.Equal(t, int64(1), c.Seq)
+		assert.Equal(t, valueobject.EventUserUpdated, c.Type)
+		assert.Equal(t, u.ID(), c.UserID)
+	})
+
+	t.Run("不正な通知は読み飛ばす", func(t *testing.T) {
+		u := factory.NewUser()
+		_, repo := newEventLog(t)
+		d := &fakeDialer{conns: make(chan *fakeConn, 1)}
+		changes := startListener(t, d, repo)
+		conn := waitConn(t, d)
+
+		conn.notifications <- "invalid"
+		conn.notifications <- `{"seq":1,"type":"user.renamed","user_id":"` + u.ID().String() + `"}`
+		conn.notifications <- payloadOf(2, valueobject.EventUserCreated, u)
+
+		assert.Equal(t, []int64{2}, receiveSeqs(t, changes, 1))
+	})
+
+	t.Run("切断されると再接続し、切断中に記録された変更を配る", func(t *testing.T) {
+		u := factory.NewUser()
+		log, repo := newEventLog(t, factory.NewEvent(1, valueobject.EventUserCreated, u))
+		d := &fakeDialer{conns: make(chan *fakeConn, 1)}
+		changes := startListener(t, d, repo)
+		conn := waitConn(t, d)
+
+		conn.notifications <- payloadOf(2, valueobject.EventUserUpdated, u)
+		assert.Equal(t, []int64{2}, receiveSeqs(t, changes, 1), "接続前に記録された変更は配らないべき")
+
+		log.append(
+			factory.NewEvent(2, valueobject.EventUserUpdated, u),
+			factory.NewEvent(3, valueobject.EventUserUpdated, u),
+			factory.NewEvent(4, valueobject.EventUserUpdated, u),
+			factory.NewEvent(5, valueobject.EventUserDeleted, u),
+		)
+		conn.disconnect()
+		conn = waitConn(t, d)
+
+		assert.Equal(t, []int64{2, 3, 4, 5}, receiveSeqs(t, changes, 4), "通知では取得の位置を進めないため、通知で配った変更も重複して配るべき")
+
+		conn.notifications <- payloadOf(6, valueobject.EventUserCreated, u)
+		assert.Equal(t, []int64{6}, receiveSeqs(t, changes, 1))
+
+		log.append(factory.NewEvent(6, valueobject.EventUserCreated, u))
+		conn.disconnect()
+		waitConn(t, d)
+
+		assert.Equal(t, []int64{6}, receiveSeqs(t, changes, 1), "前回の再接続で取得した変更は配らないべき")
+	})
+
+	t.Run("再接続すると切断中の変更を配った後にOnReconnectの関数を呼ぶ", func(t *testing.T) {
+		u := factory.NewUser()
+		log, repo := newEventLog(t)
+		d := &fakeDialer{conns: make(chan *fakeConn, 1)}
+		l := newListener(d.connect, repo, Options{
+			Backoff: backoff.Backoff{Min: time.Millisecond, Max: 5 * time.Millisecond},
+			Logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+		})
+		received := make(chan string, 10)
+		l.Subscribe(func(c Change) { received <- fmt.Sprintf("change %d", c.Seq) })
+		l.OnReconnect(func() { received <- "reconnect" })
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			l.Run(ctx)
+			close(done)
+		}()
+		defer func() {
+			cancel()
+			<-done
+		}()
+
+		conn := waitConn(t, d)
+		log.append(factory.NewEvent(1, valueobject.EventUserUpdated, u))
+		conn.disconnect()
+		waitConn(t, d)
+
+		var got []string
+		for range 2 {
+			select {
+			case r := <-received:
+				got = append(got, r)
+			case <-time.After(time.Second):
+				t.Fatalf("受け取れない（受け取り済み: %v）", got)
+			}
+		}
+		assert.Equal(t, []string{"change 1", "reconnect"}, got, "初回の接続では呼ばず、再接続では取りこぼしを配った後に呼ぶべき")
+	})
+
+	t.Run("接続に失敗した場合は再試行する", func(t *testing.T) {
+		u := factory.NewUser()
+		_, repo := newEventLog(t)
+		d := &fakeDialer{failures: 3, conns: make(chan *fakeConn, 1)}
+		changes := startListener(t, d, repo)
+		conn := waitConn(t, d)
+
+		conn.notifications <- payloadOf(1, valueobject.EventUserCreated, u)
+
+		assert.Equal(t, []int64{1}, receiveSeqs(t, changes, 1))
+		assert.Equal(t, 4, d.attempts)
+	})
+
+	t.Run("登録を解除した購読者には配らない", func(t *testing.T) {
+		_, repo := newEventLog(t)
+		l := newListener((&fakeDialer{}).connect, repo, Options{})
+		var got []Change
+		unsubscribe := l.Subscribe(func(c Change) { got = append(got, c) })
+
+		l.dispatch(Change{Seq: 1})
+		unsubscribe()
+		l.dispatch(Change{Seq: 2})
+
+		require.Len(t, got, 1)
+		assert.Equal(t, int64(1), got[0].Seq)
+	})
+}
@@ -0,0 +1,91 @@
+//go:build integration
+
+package notify_test
+
+import (
+	"context"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/domain/user/valueobject"
+	"go-api/internal/infrastructure/notify"
+	"go-api/internal/infrastructure/repository/postgres"
+	sqlcuser "go-api/internal/sqlc/user"
+)
+
+const testTimeout = 5 * time.Second
+
+var testPool *pgxpool.Pool
+
+func TestMain(m *testing.M) {
+	connStr := os.Getenv("TEST_DATABASE_URL")
+	if connStr == "" {
+		log.Fatal("TEST_DATABASE_URL が設定されていません")
+	}
+
+	var err error
+	testPool, err = pgxpool.New(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("データベース接続に失敗: %v", err)
+	}
+
+	code := m.Run()
+
+	testPool.Close()
+	os.Exit(code)
+}
+
+func TestListener_Postgres(t *testing.T) {
+	t.Run("ユーザーの変更がトリガーから通知される", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+		t.Cleanup(cancel)
+
+		l := notify.NewListener(testPool.Config().ConnConfig,
+			postgres.NewUserEventRepository(sqlcuser.New(testPool)),
+			notify.Options{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))})
+		changes := make(chan notify.Change, 10)
+		l.Subscribe(func(c notify.Change) { changes <- c })
+
+		runCtx, stop := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			l.Run(runCtx)
+			close(done)
+		}()
+		t.Cleanup(func() {
+			stop()
+			<-done
+		})
+		time.Sleep(200 * time.Millisecond) // LISTEN の開始を待つ
+
+		id := uuid.New()
+		t.Cleanup(func() {
+			_, _ = testPool.Exec(context.Background(), `DELETE FROM users WHERE id = $1`, id)
+		})
+		_, err := testPool.Exec(ctx, `INSERT INTO users (id, name, email) VALUES ($1, 'notify', $2)`, id, id.String()+"@example.com")
+		require.NoError(t, err, "ユーザーの作成に失敗")
+
+		for {
+			select {
+			case c := <-changes:
+				if c.UserID.String() != id.String() {
+					continue // 並行して実行された他のテストの変更
+				}
+				assert.Equal(t, valueobject.EventUserCreated, c.Type)
+				assert.Positive(t, c.Seq)
+				return
+			case <-ctx.Done():
+				t.Fatal("変更が通知されない")
+			}
+		}
+	})
+}
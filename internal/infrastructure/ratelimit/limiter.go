@@ -0,0 +1,70 @@
+// Package ratelimit はトークンバケット方式のレート制限を提供する。
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit はトークンバケットの設定。
+type Limit struct {
+	Rate  float64 // 1秒あたりの補充トークン数
+	Burst int     // バケット容量（瞬間的に許容するリクエスト数）
+}
+
+// Window はバケットが空から満杯に戻るまでの時間。
+func (l Limit) Window() time.Duration {
+	if l.Rate <= 0 {
+		return 0
+	}
+	return time.Duration(float64(l.Burst) / l.Rate * float64(time.Second))
+}
+
+// Result はレート制限の判定結果。
+type Result struct {
+	Allowed    bool
+	Limit      int           // バケット容量
+	Remaining  int           // 残りトークン数
+	ResetAfter time.Duration // バケットが満杯に戻るまでの時間
+	RetryAfter time.Duration // 次のリクエストが許可されるまでの時間（拒否時のみ）
+}
+
+// Limiter はキー単位でリクエストの可否を判定するインターフェース。
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// bucket はトークンバケットの状態。
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// take は経過時間分のトークンを補充した上で1トークン消費を試みる。
+// バックエンド間で判定ロジックを揃えるため、状態の保存方法とは切り離している。
+func (b *bucket) take(now time.Time, limit Limit) Result {
+	burst := float64(limit.Burst)
+	if elapsed := now.Sub(b.updated).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(burst, b.tokens+elapsed*limit.Rate)
+	}
+	b.updated = now
+
+	res := Result{Limit: limit.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = secondsToDuration((1 - b.tokens) / limit.Rate)
+	}
+	res.Remaining = int(b.tokens)
+	res.ResetAfter = secondsToDuration((burst - b.tokens) / limit.Rate)
+	return res
+}
+
+func secondsToDuration(s float64) time.Duration {
+	if s <= 0 || math.IsInf(s, 0) || math.IsNaN(s) {
+		return 0
+	}
+	return time.Duration(s * float64(time.Second))
+}
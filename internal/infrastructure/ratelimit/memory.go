@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval は満杯に戻ったバケットを掃除する間隔。PostgresLimiter の Run も同じ間隔で削除する。
+const sweepInterval = time.Minute
+
+// memoryEntry はバケットと、そのバケットが満杯に戻る時刻の組。
+type memoryEntry struct {
+	bucket bucket
+	fullAt time.Time
+}
+
+// MemoryLimiter はプロセス内メモリにバケットを保持する Limiter の実装。
+// 単一レプリカ構成や開発用途を想定している。
+type MemoryLimiter struct {
+	mu        sync.Mutex
+	entries   map[string]*memoryEntry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryLimiter は MemoryLimiter を生成する。
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		entries: make(map[string]*memoryEntry),
+		now:     time.Now,
+	}
+}
+
+// Allow はキーに対応するバケットから1トークン消費を試みる。
+func (l *MemoryLimiter) Allow(_ context.Context, key string, limit Limit) (Result, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	e, ok := l.entries[key]
+	if !ok {
+		e = &memoryEntry{bucket: bucket{tokens: float64(limit.Burst), updated: now}}
+		l.entries[key] = e
+	}
+	res := e.bucket.take(now, limit)
+	e.fullAt = now.Add(res.ResetAfter)
+	return res, nil
+}
+
+// sweep は満杯まで回復したバケットを削除し、メモリ使用量を抑える。
+// 満杯のバケットは削除しても次回生成時と同じ状態になるため判定に影響しない。
+func (l *MemoryLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, e := range l.entries {
+		if !now.Before(e.fullAt) {
+			delete(l.entries, key)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMemoryLimiter(now *time.Time) *MemoryLimiter {
+	l := NewMemoryLimiter()
+	l.now = func() time.Time { return *now }
+	return l
+}
+
+func TestMemoryLimiter_Allow(t *testing.T) {
+	ctx := context.Background()
+	limit := Limit{Rate: 1, Burst: 2}
+
+	t.Run("バケット容量まではリクエストを許可する", func(t *testing.T) {
+		now := time.Now()
+		l := newTestMemoryLimiter(&now)
+
+		res, err := l.Allow(ctx, "client", limit)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 2, res.Limit)
+		assert.Equal(t, 1, res.Remaining)
+
+		res, err = l.Allow(ctx, "client", limit)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 0, res.Remaining)
+		assert.Equal(t, 2*time.Second, res.ResetAfter)
+	})
+
+	t.Run("容量を超えると拒否しRetryAfterを返す", func(t *testing.T) {
+		now := time.Now()
+		l := newTestMemoryLimiter(&now)
+
+		for range 2 {
+			_, err := l.Allow(ctx, "client", limit)
+			require.NoError(t, err)
+		}
+
+		res, err := l.Allow(ctx, "client", limit)
+		require.NoError(t, err)
+		assert.False(t, res.Allowed)
+		assert.Equal(t, 0, res.Remaining)
+		assert.Equal(t, time.Second, res.RetryAfter)
+	})
+
+	t.Run("時間経過でトークンが補充される", func(t *testing.T) {
+		now := time.Now()
+		l := newTestMemoryLimiter(&now)
+
+		for range 3 {
+			_, err := l.Allow(ctx, "client", limit)
+			require.NoError(t, err)
+		}
+
+		now = now.Add(time.Second)
+		res, err := l.Allow(ctx, "client", limit)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+	})
+
+	t.Run("キーごとに独立したバケットを持つ", func(t *testing.T) {
+		now := time.Now()
+		l := newTestMemoryLimiter(&now)
+
+		for range 3 {
+			_, err := l.Allow(ctx, "a", limit)
+			require.NoError(t, err)
+		}
+
+		res, err := l.Allow(ctx, "b", limit)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+	})
+
+	t.Run("満杯に戻ったバケットは掃除される", func(t *testing.T) {
+		now := time.Now()
+		l := newTestMemoryLimiter(&now)
+
+		_, err := l.Allow(ctx, "idle", limit)
+		require.NoError(t, err)
+
+		now = now.Add(sweepInterval)
+		_, err = l.Allow(ctx, "active", limit)
+		require.NoError(t, err)
+
+		assert.NotContains(t, l.entries, "idle")
+		assert.Contains(t, l.entries, "active")
+	})
+}
@@ -0,0 +1,96 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// lockBucketSQL はバケット行を作成または行ロックし、現在の状態とDB時刻を返す。
+	// DB時刻を使うことでレプリカ間の時計のずれを判定に持ち込まない。
+	lockBucketSQL = `
+INSERT INTO rate_limit_buckets (key, tokens, updated_at)
+VALUES ($1, $2, clock_timestamp())
+ON CONFLICT (key) DO UPDATE SET key = EXCLUDED.key
+RETURNING tokens, updated_at, clock_timestamp()`
+
+	updateBucketSQL = `
+UPDATE rate_limit_buckets
+SET tokens = $2, updated_at = $3, full_at = $4
+WHERE key = $1`
+
+	// pruneBucketsSQL は満杯に戻ったバケットを削除する。
+	// Allow が行ロックを保持している間は待ち、更新後の full_at で判定し直すため、使用中のバケットは削除しない。
+	pruneBucketsSQL = `
+DELETE FROM rate_limit_buckets
+WHERE full_at <= clock_timestamp()`
+)
+
+// PostgresLimiter はPostgreSQLにバケットを保持する Limiter の実装。
+// 複数レプリカで同じ上限を共有する場合に使用する。
+type PostgresLimiter struct {
+	pool   *pgxpool.Pool
+	logger *slog.Logger
+}
+
+// NewPostgresLimiter は PostgresLimiter を生成する。logger は Run での削除の失敗を記録する。
+func NewPostgresLimiter(pool *pgxpool.Pool, logger *slog.Logger) *PostgresLimiter {
+	return &PostgresLimiter{pool: pool, logger: logger}
+}
+
+// Allow はキーに対応するバケットから1トークン消費を試みる。
+// 同一キーへの同時リクエストは行ロックで直列化される。
+func (l *PostgresLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	var res Result
+	err := pgx.BeginFunc(ctx, l.pool, func(tx pgx.Tx) error {
+		var (
+			b   bucket
+			now time.Time
+		)
+		if err := tx.QueryRow(ctx, lockBucketSQL, key, float64(limit.Burst)).Scan(&b.tokens, &b.updated, &now); err != nil {
+			return fmt.Errorf("lock rate limit bucket: %w", err)
+		}
+
+		res = b.take(now, limit)
+
+		if _, err := tx.Exec(ctx, updateBucketSQL, key, b.tokens, b.updated, now.Add(res.ResetAfter)); err != nil {
+			return fmt.Errorf("update rate limit bucket: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	return res, nil
+}
+
+// Prune は満杯に戻ったバケット（最後のリクエストからウィンドウ以上経過したもの）を削除し、削除した件数を返す。
+// 満杯のバケットは削除しても次回生成時と同じ状態になるため判定に影響しない。
+func (l *PostgresLimiter) Prune(ctx context.Context) (int64, error) {
+	tag, err := l.pool.Exec(ctx, pruneBucketsSQL)
+	if err != nil {
+		return 0, fmt.Errorf("prune rate limit buckets: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// Run は ctx が終了するまで sweepInterval ごとに Prune を実行する。
+func (l *PostgresLimiter) Run(ctx context.Context) {
+	t := time.NewTicker(sweepInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+		if _, err := l.Prune(ctx); err != nil && ctx.Err() == nil {
+			l.logger.WarnContext(ctx, "failed to prune rate limit buckets", "error", err.Error())
+		}
+	}
+}
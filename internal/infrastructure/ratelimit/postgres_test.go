@@ -0,0 +1,100 @@
+//go:build integration
+
+package ratelimit_test
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/infrastructure/ratelimit"
+)
+
+const testTimeout = 5 * time.Second
+
+var testPool *pgxpool.Pool
+
+func TestMain(m *testing.M) {
+	connStr := os.Getenv("TEST_DATABASE_URL")
+	if connStr == "" {
+		log.Fatal("TEST_DATABASE_URL が設定されていません")
+	}
+
+	var err error
+	testPool, err = pgxpool.New(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("データベース接続に失敗: %v", err)
+	}
+
+	code := m.Run()
+
+	testPool.Close()
+	os.Exit(code)
+}
+
+func TestPostgresLimiter_Allow(t *testing.T) {
+	t.Run("バケット容量を超えると拒否する", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+		t.Cleanup(cancel)
+
+		key := "test:" + uuid.NewString()
+		t.Cleanup(func() {
+			_, _ = testPool.Exec(context.Background(), `DELETE FROM rate_limit_buckets WHERE key = $1`, key)
+		})
+
+		l := ratelimit.NewPostgresLimiter(testPool, slog.Default())
+		limit := ratelimit.Limit{Rate: 0.001, Burst: 2}
+
+		for range 2 {
+			res, err := l.Allow(ctx, key, limit)
+			require.NoError(t, err, "Allow に失敗")
+			assert.True(t, res.Allowed, "容量内は許可されるべき")
+		}
+
+		res, err := l.Allow(ctx, key, limit)
+		require.NoError(t, err, "Allow に失敗")
+		assert.False(t, res.Allowed, "容量超過は拒否されるべき")
+		assert.Positive(t, res.RetryAfter, "RetryAfter が設定されるべき")
+	})
+}
+
+func TestPostgresLimiter_Prune(t *testing.T) {
+	t.Run("満杯に戻ったバケットだけを削除する", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+		t.Cleanup(cancel)
+
+		idle, active := "test:"+uuid.NewString(), "test:"+uuid.NewString()
+		t.Cleanup(func() {
+			_, _ = testPool.Exec(context.Background(), `DELETE FROM rate_limit_buckets WHERE key = ANY($1)`, []string{idle, active})
+		})
+
+		l := ratelimit.NewPostgresLimiter(testPool, slog.Default())
+		_, err := l.Allow(ctx, idle, ratelimit.Limit{Rate: 1000, Burst: 1})
+		require.NoError(t, err, "Allow に失敗")
+		_, err = l.Allow(ctx, active, ratelimit.Limit{Rate: 0.001, Burst: 1})
+		require.NoError(t, err, "Allow に失敗")
+		time.Sleep(10 * time.Millisecond)
+
+		_, err = l.Prune(ctx)
+		require.NoError(t, err, "Prune に失敗")
+
+		var keys []string
+		rows, err := testPool.Query(ctx, `SELECT key FROM rate_limit_buckets WHERE key = ANY($1)`, []string{idle, active})
+		require.NoError(t, err)
+		for rows.Next() {
+			var key string
+			require.NoError(t, rows.Scan(&key))
+			keys = append(keys, key)
+		}
+		require.NoError(t, rows.Err())
+		assert.Equal(t, []string{active}, keys, "ウィンドウ内のバケットは残すべき")
+	})
+}
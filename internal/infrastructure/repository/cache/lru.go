@@ -0,0 +1,138 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// lruEntry は lru の要素。
+type lruEntry struct {
+	key     string
+	value   entry
+	expires time.Time
+}
+
+// lru は件数の上限と有効期限を持つプロセス内のキャッシュ。上限を超えると最も使われていない要素を捨てる。
+type lru struct {
+	mu    sync.Mutex
+	size  int
+	order *list.List // 先頭ほど最近使われた要素
+	items map[string]*list.Element
+	// reads は読み込み中のキーの世代。読み込み中に無効化された値を書き戻さないために使う
+	reads map[string]*pendingRead
+	now   func() time.Time
+}
+
+// pendingRead は読み込み中のキーの状態。generation はキーの無効化のたびに進める。
+type pendingRead struct {
+	generation uint64
+	readers    int
+}
+
+func newLRU(size int) *lru {
+	return &lru{
+		size:  size,
+		order: list.New(),
+		items: make(map[string]*list.Element, size),
+		reads: make(map[string]*pendingRead),
+		now:   time.Now,
+	}
+}
+
+// get は有効期限内の値を返す。
+func (c *lru) get(key string) (entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		return entry{}, false
+	}
+	e := el.Value.(*lruEntry)
+	if !c.now().Before(e.expires) {
+		c.removeElement(el)
+		return entry{}, false
+	}
+	c.order.MoveToFront(el)
+	return e.value, true
+}
+
+// begin はキーの読み込みを始め、キーの現在の世代を返す。世代は addIf に渡し、読み込みを終えたら end を呼ぶ。
+func (c *lru) begin(key string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	p, ok := c.reads[key]
+	if !ok {
+		p = &pendingRead{}
+		c.reads[key] = p
+	}
+	p.readers++
+	return p.generation
+}
+
+// end はキーの読み込みを終える。読み込み中のキーがなくなれば世代を捨てる。
+func (c *lru) end(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	p, ok := c.reads[key]
+	if !ok {
+		return
+	}
+	if p.readers--; p.readers == 0 {
+		delete(c.reads, key)
+	}
+}
+
+// addIf は begin の後にキーが無効化されなかった場合に限り値を追加し、上限を超えて捨てた要素の件数を返す。
+// 読み込みと並行して更新・削除された場合に、古い値をキャッシュに残さない。
+func (c *lru) addIf(generation uint64, key string, value entry, ttl time.Duration) (added bool, evicted int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	p, ok := c.reads[key]
+	if !ok || generation != p.generation || ttl <= 0 {
+		return false, 0
+	}
+	expires := c.now().Add(ttl)
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*lruEntry)
+		e.value, e.expires = value, expires
+		c.order.MoveToFront(el)
+		return true, 0
+	}
+	c.items[key] = c.order.PushFront(&lruEntry{key: key, value: value, expires: expires})
+	for c.order.Len() > c.size {
+		c.removeElement(c.order.Back())
+		evicted++
+	}
+	return true, evicted
+}
+
+// remove はキーの値を削除し、読み込み中であればキーの世代を進める。
+func (c *lru) remove(keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range keys {
+		if p, ok := c.reads[key]; ok {
+			p.generation++
+		}
+		if el, ok := c.items[key]; ok {
+			c.removeElement(el)
+		}
+	}
+}
+
+// clear はすべての値を削除し、読み込み中のすべてのキーの世代を進める。
+func (c *lru) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, p := range c.reads {
+		p.generation++
+	}
+	c.order.Init()
+	clear(c.items)
+}
+
+func (c *lru) removeElement(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.items, el.Value.(*lruEntry).key)
+}
@@ -0,0 +1,113 @@
+package cache_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/domain/user"
+	"go-api/internal/domain/user/valueobject"
+	"go-api/internal/infrastructure/database"
+	"go-api/internal/infrastructure/repository/cache"
+	"go-api/internal/infrastructure/repository/coalesce"
+	"go-api/internal/infrastructure/repository/memory"
+	"go-api/internal/testutil/factory"
+)
+
+// replicatedRepository はレプリカへの反映が遅れる保存先。書き込みはプライマリに行い、
+// 書き込んだセッション（database.WithSession）の読み込みだけをプライマリに送る。replicate でレプリカに反映する。
+type replicatedRepository struct {
+	*memory.UserRepository // レプリカ
+	primary                *memory.UserRepository
+
+	mu      sync.Mutex
+	pending []*user.User
+}
+
+func newReplicatedRepository() *replicatedRepository {
+	return &replicatedRepository{
+		UserRepository: memory.NewUserRepository(),
+		primary:        memory.NewUserRepository(),
+	}
+}
+
+func (r *replicatedRepository) Save(ctx context.Context, u *user.User) error {
+	if err := r.primary.Save(ctx, u); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pending = append(r.pending, user.Reconstruct(u.ID(), u.Name(), u.Email(), u.CreatedAt()))
+	if session, ok := database.SessionFromContext(ctx); ok {
+		session.MarkWritten(time.Now())
+	}
+	return nil
+}
+
+func (r *replicatedRepository) FindByID(ctx context.Context, id valueobject.UserID) (*user.User, error) {
+	if r.recentlyWrote(ctx) {
+		return r.primary.FindByID(ctx, id)
+	}
+	return r.UserRepository.FindByID(ctx, id)
+}
+
+func (r *replicatedRepository) FindByIDs(ctx context.Context, ids []valueobject.UserID) ([]*user.User, error) {
+	if r.recentlyWrote(ctx) {
+		return r.primary.FindByIDs(ctx, ids)
+	}
+	return r.UserRepository.FindByIDs(ctx, ids)
+}
+
+// recentlyWrote は database.Router.RecentlyWrote の代わり。
+func (r *replicatedRepository) recentlyWrote(ctx context.Context) bool {
+	session, ok := database.SessionFromContext(ctx)
+	return ok && !session.LastWrite().IsZero()
+}
+
+func (r *replicatedRepository) replicate(t *testing.T) {
+	t.Helper()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, u := range r.pending {
+		require.NoError(t, r.UserRepository.Save(context.Background(), u))
+	}
+	r.pending = nil
+}
+
+func TestUserRepository_ReadYourWrites(t *testing.T) {
+	t.Run("書き込んだセッションは他のセッションがレプリカから読んでキャッシュした古い値ではなく書き込んだ値を読む", func(t *testing.T) {
+		ctx := context.Background()
+		storage := newReplicatedRepository()
+		repo := cache.NewUserRepository(
+			coalesce.NewUserRepository(storage, coalesce.Options{Bypass: storage.recentlyWrote}),
+			cache.Options{Bypass: storage.recentlyWrote},
+		)
+		u := factory.NewUser(factory.WithName("before"))
+		require.NoError(t, repo.Save(ctx, u))
+		storage.replicate(t)
+
+		writer := database.WithSession(ctx, database.NewSession(time.Time{}))
+		other := database.WithSession(ctx, database.NewSession(time.Time{}))
+		updated := user.Reconstruct(u.ID(), u.Name(), u.Email(), u.CreatedAt())
+		name, err := valueobject.NewUserName("after")
+		require.NoError(t, err)
+		updated.ChangeName(name)
+		require.NoError(t, repo.Save(writer, updated))
+
+		got, err := repo.FindByID(other, u.ID())
+		require.NoError(t, err)
+		require.Equal(t, "before", got.Name().String(), "他のセッションはレプリカから読む")
+
+		got, err = repo.FindByID(writer, u.ID())
+		require.NoError(t, err)
+		assert.Equal(t, "after", got.Name().String())
+		users, err := repo.FindByIDs(writer, []valueobject.UserID{u.ID()})
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		assert.Equal(t, "after", users[0].Name().String())
+	})
+}
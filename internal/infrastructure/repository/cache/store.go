@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"go-api/internal/domain/user"
+	"go-api/internal/domain/user/valueobject"
+)
+
+// Store はレプリカ間で共有するキャッシュのバックエンド。キーごとに値と、無効化のたびに進む版を持つ。
+// Redis 互換のストアを想定し、Get は値と版のキーの MGET、SetIfVersion は版を比べてから SET（EX 付き）する
+// スクリプト、Invalidate は DEL と INCR の MULTI に対応する。版は値の TTL より長く保持する。
+type Store interface {
+	// Get は keys の値と版を返す。値も版もないキーは結果に含めない（版は 0 として扱う）。
+	Get(ctx context.Context, keys ...string) (map[string]Item, error)
+	// SetIfVersion はキーの版が version のままの場合に限り、ttl の間だけ値を保持する。保持したかどうかを返す。
+	SetIfVersion(ctx context.Context, key string, value []byte, ttl time.Duration, version uint64) (bool, error)
+	// Invalidate はキーの値を削除し、版を進める。
+	Invalidate(ctx context.Context, keys ...string) error
+}
+
+// Item は Store に保持した値と版。Value が nil の場合は値がない。
+type Item struct {
+	Value   []byte
+	Version uint64
+}
+
+// keyPrefix は Store に保存するキーの接頭辞。
+const keyPrefix = "user:"
+
+func storeKey(id valueobject.UserID) string {
+	return keyPrefix + id.String()
+}
+
+// entry はキャッシュする取得結果。user が nil の場合はユーザーが存在しないことを表す。
+// 呼び出し元がエンティティを変更してもキャッシュに影響しないよう、保存時と取り出し時に複製する。
+type entry struct {
+	user *user.User
+}
+
+func newEntry(u *user.User) entry {
+	if u == nil {
+		return entry{}
+	}
+	return entry{user: cloneUser(u)}
+}
+
+// found はユーザーが存在する場合に複製を返す。
+func (e entry) found() (*user.User, bool) {
+	if e.user == nil {
+		return nil, false
+	}
+	return cloneUser(e.user), true
+}
+
+func cloneUser(u *user.User) *user.User {
+	return user.Reconstruct(u.ID(), u.Name(), u.Email(), u.CreatedAt())
+}
+
+// storedEntry は Store に保存する entry のJSON。
+type storedEntry struct {
+	Missing   bool      `json:"missing,omitempty"`
+	ID        string    `json:"id,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	Email     string    `json:"email,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitzero"`
+}
+
+func encodeEntry(e entry) ([]byte, error) {
+	if e.user == nil {
+		return json.Marshal(storedEntry{Missing: true})
+	}
+	return json.Marshal(storedEntry{
+		ID:        e.user.ID().String(),
+		Name:      e.user.Name().String(),
+		Email:     e.user.Email().String(),
+		CreatedAt: e.user.CreatedAt(),
+	})
+}
+
+func decodeEntry(b []byte) (entry, error) {
+	var s storedEntry
+	if err := json.Unmarshal(b, &s); err != nil {
+		return entry{}, err
+	}
+	if s.Missing {
+		return entry{}, nil
+	}
+	id, idErr := valueobject.ParseUserID(s.ID)
+	name, nameErr := valueobject.NewUserName(s.Name)
+	email, emailErr := valueobject.NewEmail(s.Email)
+	if err := errors.Join(idErr, nameErr, emailErr); err != nil {
+		return entry{}, err
+	}
+	return entry{user: user.Reconstruct(id, name, email, s.CreatedAt)}, nil
+}
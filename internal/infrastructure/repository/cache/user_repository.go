@@ -0,0 +1,366 @@
+// Package cache はリポジトリの読み込み結果をキャッシュするデコレーターを提供する。
+package cache
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"go-api/internal/domain"
+	"go-api/internal/domain/user"
+	"go-api/internal/domain/user/valueobject"
+)
+
+// UserRepository のデフォルト値。
+const (
+	DefaultSize        = 10000
+	DefaultTTL         = time.Minute
+	DefaultNegativeTTL = 5 * time.Second
+)
+
+// Metrics の layer ラベル値。
+const (
+	LayerLocal  = "local"
+	LayerShared = "shared"
+)
+
+// Metrics の result ラベル値。
+const (
+	ResultHit         = "hit"
+	ResultNegativeHit = "negative_hit" // 存在しないことをキャッシュしていた
+	ResultMiss        = "miss"
+)
+
+// Metrics の source ラベル値。
+const (
+	SourceWrite     = "write"     // このプロセスでの保存・削除
+	SourceNotify    = "notify"    // 他のレプリカでの変更の通知
+	SourceReconnect = "reconnect" // 通知の再接続
+)
+
+// Metrics はキャッシュの利用状況を記録する。
+type Metrics interface {
+	// Lookup は層（local・shared）ごとの参照結果を記録する。
+	Lookup(layer, result string)
+	// Evicted は件数の上限を超えて捨てた件数を記録する。
+	Evicted(n int)
+	// Invalidated は無効化を原因ごとに記録する。
+	Invalidated(source string)
+}
+
+type nopMetrics struct{}
+
+func (nopMetrics) Lookup(string, string) {}
+func (nopMetrics) Evicted(int)           {}
+func (nopMetrics) Invalidated(string)    {}
+
+// Options は UserRepository の設定。
+type Options struct {
+	// Size はプロセス内に保持するユーザーの件数の上限。
+	Size int
+	// TTL は取得したユーザーを保持する期間。
+	TTL time.Duration
+	// NegativeTTL はユーザーが存在しなかったことを保持する期間。作成直後の参照に古い結果を返さないよう短くする。
+	NegativeTTL time.Duration
+	// Store はレプリカ間で共有するキャッシュ。nil の場合はプロセス内のキャッシュだけを使う。
+	Store Store
+	// Bypass が true を返す読み込みはキャッシュを参照も保存もせずに元のリポジトリから読む。書き込んだ直後のセッションの
+	// 読み込み（database.Router.RecentlyWrote）を渡し、他のセッションがレプリカから読んでキャッシュした古い値を返さないようにする。
+	Bypass func(ctx context.Context) bool
+	// Metrics はキャッシュの利用状況を記録する。
+	Metrics Metrics
+	// Logger は Store のエラーを記録する。
+	Logger *slog.Logger
+}
+
+// UserRepository は user.UserRepository の読み込み結果をキャッシュするデコレーター。
+// プロセス内のLRU、設定されていれば共有の Store、元のリポジトリの順に参照する。
+// Save・Delete では両方のキャッシュから削除する。他のレプリカでの変更は Invalidate で反映し、
+// 反映が漏れた場合も TTL の経過で元のリポジトリから取得し直す。
+type UserRepository struct {
+	inner user.UserRepository
+	local *lru
+	opts  Options
+}
+
+var _ user.UserRepository = (*UserRepository)(nil)
+
+// NewUserRepository は inner をキャッシュする UserRepository を生成する。
+func NewUserRepository(inner user.UserRepository, opts Options) *UserRepository {
+	if opts.Size <= 0 {
+		opts.Size = DefaultSize
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = DefaultTTL
+	}
+	if opts.NegativeTTL <= 0 {
+		opts.NegativeTTL = DefaultNegativeTTL
+	}
+	if opts.Metrics == nil {
+		opts.Metrics = nopMetrics{}
+	}
+	if opts.Logger == nil {
+		opts.Logger = slog.Default()
+	}
+	return &UserRepository{
+		inner: inner,
+		local: newLRU(opts.Size),
+		opts:  opts,
+	}
+}
+
+// Save はユーザーを保存し、キャッシュから削除する。
+// 保存に失敗した場合も反映されたかどうかが分からないため削除する。
+func (r *UserRepository) Save(ctx context.Context, u *user.User) error {
+	err := r.inner.Save(ctx, u)
+	r.invalidate(ctx, u.ID())
+	return err
+}
+
+// FindByID は指定されたIDのユーザーを取得する。
+// 見つからない場合は domain.ErrNotFound を返し、NegativeTTL の間はそれをキャッシュする。
+func (r *UserRepository) FindByID(ctx context.Context, id valueobject.UserID) (*user.User, error) {
+	if r.bypass(ctx) {
+		return r.inner.FindByID(ctx, id)
+	}
+	key := storeKey(id)
+	if e, ok := r.lookupLocal(key); ok {
+		return foundOrNotFound(e, "FindByID")
+	}
+
+	generation := r.local.begin(key)
+	defer r.local.end(key)
+	shared, versions := r.lookupShared(ctx, key)
+	if e, ok := shared[key]; ok {
+		r.fillLocal(generation, key, e)
+		return foundOrNotFound(e, "FindByID")
+	}
+
+	u, err := r.inner.FindByID(ctx, id)
+	switch {
+	case err == nil:
+		r.fill(ctx, generation, versions, key, newEntry(u))
+	case errors.Is(err, domain.ErrNotFound):
+		r.fill(ctx, generation, versions, key, entry{})
+	}
+	return u, err
+}
+
+// FindByIDs は指定されたIDのユーザーをまとめて取得する。
+// キャッシュにないIDだけを元のリポジトリから1回で取得し、見つからなかったIDは存在しないものとしてキャッシュする。
+func (r *UserRepository) FindByIDs(ctx context.Context, ids []valueobject.UserID) ([]*user.User, error) {
+	if r.bypass(ctx) {
+		return r.inner.FindByIDs(ctx, ids)
+	}
+	users := make([]*user.User, 0, len(ids))
+	pending := make(map[string]valueobject.UserID, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		key := storeKey(id)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		e, ok := r.lookupLocal(key)
+		if !ok {
+			pending[key] = id
+			continue
+		}
+		if u, ok := e.found(); ok {
+			users = append(users, u)
+		}
+	}
+	if len(pending) == 0 {
+		return users, nil
+	}
+
+	generations := make(map[string]uint64, len(pending))
+	keys := make([]string, 0, len(pending))
+	for key := range pending {
+		generations[key] = r.local.begin(key)
+		keys = append(keys, key)
+	}
+	defer func() {
+		for _, key := range keys {
+			r.local.end(key)
+		}
+	}()
+	shared, versions := r.lookupShared(ctx, keys...)
+	for key, e := range shared {
+		r.fillLocal(generations[key], key, e)
+		delete(pending, key)
+		if u, ok := e.found(); ok {
+			users = append(users, u)
+		}
+	}
+	if len(pending) == 0 {
+		return users, nil
+	}
+
+	missing := make([]valueobject.UserID, 0, len(pending))
+	for _, id := range pending {
+		missing = append(missing, id)
+	}
+	found, err := r.inner.FindByIDs(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range found {
+		key := storeKey(u.ID())
+		r.fill(ctx, generations[key], versions, key, newEntry(u))
+		delete(pending, key)
+	}
+	for key := range pending {
+		r.fill(ctx, generations[key], versions, key, entry{})
+	}
+	return append(users, found...), nil
+}
+
+// FindAll は全ユーザーを取得する。一覧はキャッシュしない。
+func (r *UserRepository) FindAll(ctx context.Context) ([]*user.User, error) {
+	return r.inner.FindAll(ctx)
+}
+
+// FindPage はユーザーを1ページ分取得する。ページはキャッシュしない。
+func (r *UserRepository) FindPage(ctx context.Context, after *user.PageCursor, limit int) ([]*user.User, error) {
+	return r.inner.FindPage(ctx, after, limit)
+}
+
+// Count はユーザーの件数を返す。件数はキャッシュしない。
+func (r *UserRepository) Count(ctx context.Context) (int, error) {
+	return r.inner.Count(ctx)
+}
+
+// Delete はユーザーを削除し、キャッシュから削除する。
+func (r *UserRepository) Delete(ctx context.Context, id valueobject.UserID) error {
+	err := r.inner.Delete(ctx, id)
+	r.invalidate(ctx, id)
+	return err
+}
+
+// Invalidate はプロセス内のキャッシュからユーザーを削除する。他のレプリカでの変更の通知を受けて呼ぶ。
+// 共有の Store は変更したレプリカが削除するため対象にしない。
+func (r *UserRepository) Invalidate(id valueobject.UserID) {
+	r.local.remove(storeKey(id))
+	r.opts.Metrics.Invalidated(SourceNotify)
+}
+
+// InvalidateAll はプロセス内のキャッシュをすべて削除する。通知の再接続後に呼び、
+// 切断中に取りこぼした変更の古い値を残さない。
+func (r *UserRepository) InvalidateAll() {
+	r.local.clear()
+	r.opts.Metrics.Invalidated(SourceReconnect)
+}
+
+func (r *UserRepository) invalidate(ctx context.Context, id valueobject.UserID) {
+	key := storeKey(id)
+	r.local.remove(key)
+	r.opts.Metrics.Invalidated(SourceWrite)
+	if r.opts.Store == nil {
+		return
+	}
+	// 呼び出し元のキャンセルで削除が漏れると、他のレプリカが TTL の間古い値を返すため切り離す
+	if err := r.opts.Store.Invalidate(context.WithoutCancel(ctx), key); err != nil {
+		r.opts.Logger.WarnContext(ctx, "failed to delete user from shared cache", "key", key, "error", err.Error())
+	}
+}
+
+func (r *UserRepository) bypass(ctx context.Context) bool {
+	return r.opts.Bypass != nil && r.opts.Bypass(ctx)
+}
+
+func (r *UserRepository) lookupLocal(key string) (entry, bool) {
+	e, ok := r.local.get(key)
+	r.opts.Metrics.Lookup(LayerLocal, lookupResult(e, ok))
+	return e, ok
+}
+
+// lookupShared は Store から keys の値と版を取得する。版は値がなかったキーの fill に渡す。
+// Store のエラーは記録し、見つからなかったものとして扱う。その場合は版が分からないため Store に保存しない。
+func (r *UserRepository) lookupShared(ctx context.Context, keys ...string) (map[string]entry, map[string]uint64) {
+	if r.opts.Store == nil {
+		return nil, nil
+	}
+	items, err := r.opts.Store.Get(ctx, keys...)
+	var versions map[string]uint64
+	if err != nil {
+		r.opts.Logger.WarnContext(ctx, "failed to get users from shared cache", "error", err.Error())
+	} else {
+		versions = make(map[string]uint64, len(keys))
+	}
+	entries := make(map[string]entry, len(items))
+	for _, key := range keys {
+		item := items[key]
+		if versions != nil {
+			versions[key] = item.Version
+		}
+		if item.Value == nil {
+			r.opts.Metrics.Lookup(LayerShared, ResultMiss)
+			continue
+		}
+		e, err := decodeEntry(item.Value)
+		if err != nil {
+			r.opts.Logger.WarnContext(ctx, "invalid user in shared cache", "key", key, "error", err.Error())
+			r.opts.Metrics.Lookup(LayerShared, ResultMiss)
+			continue
+		}
+		r.opts.Metrics.Lookup(LayerShared, lookupResult(e, true))
+		entries[key] = e
+	}
+	return entries, versions
+}
+
+// fill は元のリポジトリから取得した結果を両方のキャッシュに保存する。
+// 取得中にこのプロセスで無効化された場合は、古い値の可能性があるため保存しない。
+// Store には lookupShared で取得した版のままの場合に限り保存し、他のレプリカでの無効化を上書きしない。
+func (r *UserRepository) fill(ctx context.Context, generation uint64, versions map[string]uint64, key string, e entry) {
+	if !r.fillLocal(generation, key, e) || r.opts.Store == nil {
+		return
+	}
+	version, ok := versions[key]
+	if !ok {
+		return
+	}
+	b, err := encodeEntry(e)
+	if err == nil {
+		_, err = r.opts.Store.SetIfVersion(ctx, key, b, r.ttl(e), version)
+	}
+	if err != nil {
+		r.opts.Logger.WarnContext(ctx, "failed to set user to shared cache", "key", key, "error", err.Error())
+	}
+}
+
+func (r *UserRepository) fillLocal(generation uint64, key string, e entry) bool {
+	added, evicted := r.local.addIf(generation, key, e, r.ttl(e))
+	if evicted > 0 {
+		r.opts.Metrics.Evicted(evicted)
+	}
+	return added
+}
+
+func (r *UserRepository) ttl(e entry) time.Duration {
+	if e.user == nil {
+		return r.opts.NegativeTTL
+	}
+	return r.opts.TTL
+}
+
+func lookupResult(e entry, ok bool) string {
+	switch {
+	case !ok:
+		return ResultMiss
+	case e.user == nil:
+		return ResultNegativeHit
+	default:
+		return ResultHit
+	}
+}
+
+func foundOrNotFound(e entry, op string) (*user.User, error) {
+	u, ok := e.found()
+	if !ok {
+		return nil, domain.NotFound("user", op)
+	}
+	return u, nil
+}
@@ -0,0 +1,392 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/domain"
+	"go-api/internal/domain/user"
+	"go-api/internal/domain/user/mocks"
+	"go-api/internal/domain/user/valueobject"
+	"go-api/internal/testutil/factory"
+)
+
+// memoryStore はテスト用のプロセス内の Store。Redis 互換のストアの代わりに使う。
+type memoryStore struct {
+	mu       sync.Mutex
+	values   map[string][]byte
+	versions map[string]uint64
+	err      error
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{values: make(map[string][]byte), versions: make(map[string]uint64)}
+}
+
+func (s *memoryStore) Get(_ context.Context, keys ...string) (map[string]Item, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+	found := make(map[string]Item)
+	for _, key := range keys {
+		v, ok := s.values[key]
+		version, versioned := s.versions[key]
+		if ok || versioned {
+			found[key] = Item{Value: v, Version: version}
+		}
+	}
+	return found, nil
+}
+
+func (s *memoryStore) SetIfVersion(_ context.Context, key string, value []byte, _ time.Duration, version uint64) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return false, s.err
+	}
+	if s.versions[key] != version {
+		return false, nil
+	}
+	s.values[key] = value
+	return true, nil
+}
+
+func (s *memoryStore) Invalidate(_ context.Context, keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	for _, key := range keys {
+		delete(s.values, key)
+		s.versions[key]++
+	}
+	return nil
+}
+
+// recordedMetrics は記録された参照結果を数える Metrics。
+type recordedMetrics struct {
+	lookups map[string]int
+	evicted int
+}
+
+func (m *recordedMetrics) Lookup(layer, result string) { m.lookups[layer+"/"+result]++ }
+func (m *recordedMetrics) Evicted(n int)               { m.evicted += n }
+func (m *recordedMetrics) Invalidated(string)          {}
+
+func newTestRepository(inner user.UserRepository, opts Options, now *time.Time) *UserRepository {
+	opts.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	r := NewUserRepository(inner, opts)
+	if now != nil {
+		r.local.now = func() time.Time { return *now }
+	}
+	return r
+}
+
+func TestUserRepository_FindByID(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("2回目以降はキャッシュから返す", func(t *testing.T) {
+		u := factory.NewUser(factory.WithName("test"))
+		inner := mocks.NewMockUserRepository(t)
+		inner.EXPECT().FindByID(mock.Anything, u.ID()).Return(u, nil).Once()
+		m := &recordedMetrics{lookups: map[string]int{}}
+		r := newTestRepository(inner, Options{Metrics: m}, nil)
+
+		for range 3 {
+			got, err := r.FindByID(ctx, u.ID())
+			require.NoError(t, err)
+			assert.Equal(t, "test", got.Name().String())
+		}
+		assert.Equal(t, map[string]int{"local/miss": 1, "local/hit": 2}, m.lookups)
+	})
+
+	t.Run("返したエンティティを変更してもキャッシュに影響しない", func(t *testing.T) {
+		u := factory.NewUser(factory.WithName("before"))
+		inner := mocks.NewMockUserRepository(t)
+		inner.EXPECT().FindByID(mock.Anything, u.ID()).Return(u, nil).Once()
+		r := newTestRepository(inner, Options{}, nil)
+
+		got, err := r.FindByID(ctx, u.ID())
+		require.NoError(t, err)
+		name, _ := valueobject.NewUserName("after")
+		got.ChangeName(name)
+
+		got, err = r.FindByID(ctx, u.ID())
+		require.NoError(t, err)
+		assert.Equal(t, "before", got.Name().String())
+	})
+
+	t.Run("TTLを過ぎると元のリポジトリから取得し直す", func(t *testing.T) {
+		u := factory.NewUser()
+		inner := mocks.NewMockUserRepository(t)
+		inner.EXPECT().FindByID(mock.Anything, u.ID()).Return(u, nil).Twice()
+		now := time.Now()
+		r := newTestRepository(inner, Options{TTL: time.Minute}, &now)
+
+		_, err := r.FindByID(ctx, u.ID())
+		require.NoError(t, err)
+		now = now.Add(time.Minute)
+		_, err = r.FindByID(ctx, u.ID())
+		require.NoError(t, err)
+	})
+
+	t.Run("存在しないことをNegativeTTLの間キャッシュする", func(t *testing.T) {
+		id := valueobject.NewUserID()
+		inner := mocks.NewMockUserRepository(t)
+		inner.EXPECT().FindByID(mock.Anything, id).Return(nil, domain.NotFound("user", "FindByID")).Twice()
+		now := time.Now()
+		r := newTestRepository(inner, Options{NegativeTTL: 5 * time.Second}, &now)
+
+		for range 2 {
+			_, err := r.FindByID(ctx, id)
+			assert.ErrorIs(t, err, domain.ErrNotFound)
+		}
+		now = now.Add(5 * time.Second)
+		_, err := r.FindByID(ctx, id)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("NotFound以外のエラーはキャッシュしない", func(t *testing.T) {
+		id := valueobject.NewUserID()
+		inner := mocks.NewMockUserRepository(t)
+		inner.EXPECT().FindByID(mock.Anything, id).Return(nil, errors.New("connection refused")).Twice()
+		r := newTestRepository(inner, Options{}, nil)
+
+		for range 2 {
+			_, err := r.FindByID(ctx, id)
+			assert.EqualError(t, err, "connection refused")
+		}
+	})
+
+	t.Run("件数の上限を超えると最も使われていないユーザーを捨てる", func(t *testing.T) {
+		a, b, c := factory.NewUser(), factory.NewUser(), factory.NewUser()
+		inner := mocks.NewMockUserRepository(t)
+		inner.EXPECT().FindByID(mock.Anything, a.ID()).Return(a, nil).Once()
+		inner.EXPECT().FindByID(mock.Anything, b.ID()).Return(b, nil).Twice()
+		inner.EXPECT().FindByID(mock.Anything, c.ID()).Return(c, nil).Once()
+		m := &recordedMetrics{lookups: map[string]int{}}
+		r := newTestRepository(inner, Options{Size: 2, Metrics: m}, nil)
+
+		for _, id := range []valueobject.UserID{a.ID(), b.ID(), a.ID(), c.ID(), a.ID(), b.ID()} {
+			_, err := r.FindByID(ctx, id)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 2, m.evicted)
+	})
+
+	t.Run("取得中に無効化された結果はキャッシュしない", func(t *testing.T) {
+		u := factory.NewUser()
+		inner := mocks.NewMockUserRepository(t)
+		var r *UserRepository
+		inner.EXPECT().FindByID(mock.Anything, u.ID()).
+			RunAndReturn(func(context.Context, valueobject.UserID) (*user.User, error) {
+				r.Invalidate(u.ID()) // 取得中に他のレプリカで更新された
+				return u, nil
+			}).Once()
+		inner.EXPECT().FindByID(mock.Anything, u.ID()).Return(u, nil).Once()
+		r = newTestRepository(inner, Options{}, nil)
+
+		for range 3 {
+			_, err := r.FindByID(ctx, u.ID())
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("取得中に他のユーザーが無効化されてもキャッシュする", func(t *testing.T) {
+		u, other := factory.NewUser(), factory.NewUser()
+		inner := mocks.NewMockUserRepository(t)
+		var r *UserRepository
+		inner.EXPECT().FindByID(mock.Anything, u.ID()).
+			RunAndReturn(func(context.Context, valueobject.UserID) (*user.User, error) {
+				r.Invalidate(other.ID())
+				return u, nil
+			}).Once()
+		r = newTestRepository(inner, Options{}, nil)
+
+		for range 3 {
+			_, err := r.FindByID(ctx, u.ID())
+			require.NoError(t, err)
+		}
+		assert.Empty(t, r.local.reads)
+	})
+}
+
+func TestUserRepository_Invalidation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Saveでキャッシュから削除する", func(t *testing.T) {
+		u := factory.NewUser()
+		inner := mocks.NewMockUserRepository(t)
+		inner.EXPECT().FindByID(mock.Anything, u.ID()).Return(u, nil).Twice()
+		inner.EXPECT().Save(mock.Anything, u).Return(nil).Once()
+		r := newTestRepository(inner, Options{}, nil)
+
+		_, err := r.FindByID(ctx, u.ID())
+		require.NoError(t, err)
+		require.NoError(t, r.Save(ctx, u))
+		_, err = r.FindByID(ctx, u.ID())
+		require.NoError(t, err)
+	})
+
+	t.Run("Saveで存在しないことのキャッシュも削除する", func(t *testing.T) {
+		u := factory.NewUser()
+		inner := mocks.NewMockUserRepository(t)
+		inner.EXPECT().FindByID(mock.Anything, u.ID()).Return(nil, domain.NotFound("user", "FindByID")).Once()
+		inner.EXPECT().Save(mock.Anything, u).Return(nil).Once()
+		inner.EXPECT().FindByID(mock.Anything, u.ID()).Return(u, nil).Once()
+		r := newTestRepository(inner, Options{}, nil)
+
+		_, err := r.FindByID(ctx, u.ID())
+		require.ErrorIs(t, err, domain.ErrNotFound)
+		require.NoError(t, r.Save(ctx, u))
+		got, err := r.FindByID(ctx, u.ID())
+		require.NoError(t, err)
+		assert.Equal(t, u.ID(), got.ID())
+	})
+
+	t.Run("Deleteでキャッシュから削除する", func(t *testing.T) {
+		u := factory.NewUser()
+		inner := mocks.NewMockUserRepository(t)
+		inner.EXPECT().FindByID(mock.Anything, u.ID()).Return(u, nil).Once()
+		inner.EXPECT().Delete(mock.Anything, u.ID()).Return(nil).Once()
+		inner.EXPECT().FindByID(mock.Anything, u.ID()).Return(nil, domain.NotFound("user", "FindByID")).Once()
+		r := newTestRepository(inner, Options{}, nil)
+
+		_, err := r.FindByID(ctx, u.ID())
+		require.NoError(t, err)
+		require.NoError(t, r.Delete(ctx, u.ID()))
+		_, err = r.FindByID(ctx, u.ID())
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("InvalidateAllでプロセス内のキャッシュをすべて削除する", func(t *testing.T) {
+		u1, u2 := factory.NewUser(), factory.NewUser()
+		inner := mocks.NewMockUserRepository(t)
+		inner.EXPECT().FindByID(mock.Anything, u1.ID()).Return(u1, nil).Twice()
+		inner.EXPECT().FindByID(mock.Anything, u2.ID()).Return(u2, nil).Twice()
+		r := newTestRepository(inner, Options{}, nil)
+
+		for range 2 {
+			for _, id := range []valueobject.UserID{u1.ID(), u2.ID()} {
+				_, err := r.FindByID(ctx, id)
+				require.NoError(t, err)
+			}
+			r.InvalidateAll()
+		}
+	})
+}
+
+func TestUserRepository_Store(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("他のレプリカが保存した結果をStoreから返し、Saveで両方から削除する", func(t *testing.T) {
+		u := factory.NewUser(factory.WithName("test"))
+		store := newMemoryStore()
+		inner := mocks.NewMockUserRepository(t)
+		inner.EXPECT().FindByID(mock.Anything, u.ID()).Return(u, nil).Twice()
+		inner.EXPECT().Save(mock.Anything, u).Return(nil).Once()
+		m := &recordedMetrics{lookups: map[string]int{}}
+		a := newTestRepository(inner, Options{Store: store}, nil)
+		b := newTestRepository(inner, Options{Store: store, Metrics: m}, nil)
+
+		_, err := a.FindByID(ctx, u.ID())
+		require.NoError(t, err)
+		got, err := b.FindByID(ctx, u.ID())
+		require.NoError(t, err)
+		assert.Equal(t, "test", got.Name().String())
+		assert.Equal(t, map[string]int{"local/miss": 1, "shared/hit": 1}, m.lookups)
+
+		require.NoError(t, a.Save(ctx, u))
+		b.Invalidate(u.ID()) // 変更の通知を受けた
+		_, err = b.FindByID(ctx, u.ID())
+		require.NoError(t, err)
+	})
+
+	t.Run("存在しないことをStoreで共有する", func(t *testing.T) {
+		id := valueobject.NewUserID()
+		store := newMemoryStore()
+		inner := mocks.NewMockUserRepository(t)
+		inner.EXPECT().FindByID(mock.Anything, id).Return(nil, domain.NotFound("user", "FindByID")).Once()
+		a := newTestRepository(inner, Options{Store: store}, nil)
+		b := newTestRepository(inner, Options{Store: store}, nil)
+
+		_, err := a.FindByID(ctx, id)
+		require.ErrorIs(t, err, domain.ErrNotFound)
+		_, err = b.FindByID(ctx, id)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("取得中に他のレプリカが無効化した結果はStoreに保存しない", func(t *testing.T) {
+		u := factory.NewUser()
+		store := newMemoryStore()
+		inner := mocks.NewMockUserRepository(t)
+		inner.EXPECT().FindByID(mock.Anything, u.ID()).
+			RunAndReturn(func(context.Context, valueobject.UserID) (*user.User, error) {
+				// 取得中に他のレプリカで更新され、Store から削除された
+				require.NoError(t, store.Invalidate(ctx, storeKey(u.ID())))
+				return u, nil
+			}).Once()
+		r := newTestRepository(inner, Options{Store: store}, nil)
+
+		_, err := r.FindByID(ctx, u.ID())
+		require.NoError(t, err)
+		assert.NotContains(t, store.values, storeKey(u.ID()))
+	})
+
+	t.Run("Storeのエラーは元のリポジトリから取得する", func(t *testing.T) {
+		u := factory.NewUser()
+		store := newMemoryStore()
+		store.err = errors.New("connection refused")
+		inner := mocks.NewMockUserRepository(t)
+		inner.EXPECT().FindByID(mock.Anything, u.ID()).Return(u, nil).Once()
+		inner.EXPECT().Save(mock.Anything, u).Return(nil).Once()
+		r := newTestRepository(inner, Options{Store: store}, nil)
+
+		got, err := r.FindByID(ctx, u.ID())
+		require.NoError(t, err)
+		assert.Equal(t, u.ID(), got.ID())
+		assert.NoError(t, r.Save(ctx, u))
+	})
+}
+
+func TestUserRepository_FindByIDs(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("キャッシュにないIDだけを元のリポジトリから取得し、見つからないIDもキャッシュする", func(t *testing.T) {
+		a, b := factory.NewUser(), factory.NewUser()
+		missing := valueobject.NewUserID()
+		inner := mocks.NewMockUserRepository(t)
+		inner.EXPECT().FindByID(mock.Anything, a.ID()).Return(a, nil).Once()
+		inner.EXPECT().FindByIDs(mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, ids []valueobject.UserID) ([]*user.User, error) {
+				assert.ElementsMatch(t, []valueobject.UserID{b.ID(), missing}, ids)
+				return []*user.User{b}, nil
+			}).Once()
+		r := newTestRepository(inner, Options{}, nil)
+
+		_, err := r.FindByID(ctx, a.ID())
+		require.NoError(t, err)
+
+		for range 2 {
+			users, err := r.FindByIDs(ctx, []valueobject.UserID{a.ID(), b.ID(), missing, a.ID()})
+			require.NoError(t, err)
+			ids := make([]valueobject.UserID, len(users))
+			for i, u := range users {
+				ids[i] = u.ID()
+			}
+			assert.ElementsMatch(t, []valueobject.UserID{a.ID(), b.ID()}, ids)
+		}
+	})
+}
@@ -0,0 +1,226 @@
+// Package coalesce は同時に行われる同じ読み込みを1回の問い合わせにまとめるデコレーターを提供する。
+package coalesce
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"go-api/internal/domain/user"
+	"go-api/internal/domain/user/valueobject"
+)
+
+// Metrics はまとめた読み込みを記録する。
+type Metrics interface {
+	// Call はメソッドの呼び出しを、実行中の問い合わせに相乗りした（coalesced）かどうかとともに記録する。
+	Call(method string, coalesced bool)
+}
+
+type nopMetrics struct{}
+
+func (nopMetrics) Call(string, bool) {}
+
+// DefaultTimeout は Options.Timeout のデフォルト値。
+const DefaultTimeout = 10 * time.Second
+
+// Options は UserRepository の設定。
+type Options struct {
+	// Timeout はまとめた問い合わせの時間の上限。問い合わせは呼び出し元の期限から切り離して実行するため、別に上限を設ける。
+	Timeout time.Duration
+	// Bypass が true を返す読み込みはまとめずに実行する。書き込んだ直後のセッションの読み込み
+	// （database.Router.RecentlyWrote）を渡し、他の呼び出し元の問い合わせの結果を使わないようにする。
+	Bypass func(ctx context.Context) bool
+	// Metrics はまとめた読み込みを記録する。
+	Metrics Metrics
+}
+
+// UserRepository は同時に行われる同じ読み込みを1回の問い合わせにまとめる user.UserRepository のデコレーター。
+// 問い合わせは呼び出し元のキャンセルから切り離して Timeout を上限に実行し、待っている呼び出し元が全てキャンセルした場合に中断する。
+// 結果のエンティティは呼び出し元ごとに複製して返す。
+type UserRepository struct {
+	inner user.UserRepository
+	opts  Options
+
+	group singleflight.Group
+	mu    sync.Mutex
+	calls map[string]*call
+	seq   uint64
+}
+
+var _ user.UserRepository = (*UserRepository)(nil)
+
+// call は実行中の問い合わせと、それを待っている呼び出し元の数。
+type call struct {
+	// key は singleflight のキー。中断した問い合わせに後から来た呼び出し元が相乗りしないよう、問い合わせごとに変える
+	key     string
+	ctx     context.Context
+	cancel  context.CancelFunc
+	waiters int
+}
+
+// NewUserRepository は inner の読み込みをまとめる UserRepository を生成する。
+func NewUserRepository(inner user.UserRepository, opts Options) *UserRepository {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.Metrics == nil {
+		opts.Metrics = nopMetrics{}
+	}
+	return &UserRepository{
+		inner: inner,
+		opts:  opts,
+		calls: make(map[string]*call),
+	}
+}
+
+// Save はユーザーを保存する。書き込みはまとめない。
+func (r *UserRepository) Save(ctx context.Context, u *user.User) error {
+	return r.inner.Save(ctx, u)
+}
+
+// FindByID は指定されたIDのユーザーを取得する。同じIDの実行中の取得があれば、その結果を使う。
+func (r *UserRepository) FindByID(ctx context.Context, id valueobject.UserID) (*user.User, error) {
+	v, err := r.do(ctx, "FindByID", id.String(), func(ctx context.Context) (any, error) {
+		return r.inner.FindByID(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cloneUser(v.(*user.User)), nil
+}
+
+// FindByIDs は指定されたIDのユーザーをまとめて取得する。同じIDの組（順序は問わない）の実行中の取得があれば、その結果を使う。
+func (r *UserRepository) FindByIDs(ctx context.Context, ids []valueobject.UserID) ([]*user.User, error) {
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = id.String()
+	}
+	slices.Sort(keys)
+	v, err := r.do(ctx, "FindByIDs", strings.Join(slices.Compact(keys), ","), func(ctx context.Context) (any, error) {
+		return r.inner.FindByIDs(ctx, ids)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cloneUsers(v.([]*user.User)), nil
+}
+
+// FindAll は全ユーザーを取得する。実行中の取得があれば、その結果を使う。
+func (r *UserRepository) FindAll(ctx context.Context) ([]*user.User, error) {
+	v, err := r.do(ctx, "FindAll", "", func(ctx context.Context) (any, error) {
+		return r.inner.FindAll(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cloneUsers(v.([]*user.User)), nil
+}
+
+// FindPage はユーザーを1ページ分取得する。ページはカーソルごとに異なるため、まとめない。
+func (r *UserRepository) FindPage(ctx context.Context, after *user.PageCursor, limit int) ([]*user.User, error) {
+	return r.inner.FindPage(ctx, after, limit)
+}
+
+// Count はユーザーの件数を返す。実行中の取得があれば、その結果を使う。
+func (r *UserRepository) Count(ctx context.Context) (int, error) {
+	v, err := r.do(ctx, "Count", "", func(ctx context.Context) (any, error) {
+		return r.inner.Count(ctx)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return v.(int), nil
+}
+
+// Delete はユーザーを削除する。書き込みはまとめない。
+func (r *UserRepository) Delete(ctx context.Context, id valueobject.UserID) error {
+	return r.inner.Delete(ctx, id)
+}
+
+// do は method と key が同じ実行中の問い合わせがあればその結果を待ち、なければ fn を実行する。
+// ctx がキャンセルされた場合は、問い合わせを待たずに ctx.Err() を返す。Bypass の対象は呼び出し元の ctx で fn を実行する。
+func (r *UserRepository) do(ctx context.Context, method, key string, fn func(context.Context) (any, error)) (any, error) {
+	if r.opts.Bypass != nil && r.opts.Bypass(ctx) {
+		r.opts.Metrics.Call(method, false)
+		return fn(ctx)
+	}
+	key = method + ":" + key
+	c, ch, coalesced := r.join(ctx, key, fn)
+	r.opts.Metrics.Call(method, coalesced)
+	defer r.leave(key, c)
+
+	select {
+	case res := <-ch:
+		return res.Val, res.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// join は key の実行中の問い合わせに待っている呼び出し元として加わる。なければ新しく作って fn を実行する。
+// 問い合わせの完了（forget）と排他にするため、ロックを持ったまま singleflight に登録する。
+func (r *UserRepository) join(ctx context.Context, key string, fn func(context.Context) (any, error)) (c *call, ch <-chan singleflight.Result, coalesced bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, coalesced = r.calls[key]
+	if !coalesced {
+		r.seq++
+		callCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), r.opts.Timeout)
+		c = &call{key: key + "#" + strconv.FormatUint(r.seq, 10), ctx: callCtx, cancel: cancel}
+		r.calls[key] = c
+	}
+	c.waiters++
+	ch = r.group.DoChan(c.key, func() (any, error) {
+		// 完了後に来た呼び出し元は新しく問い合わせる
+		defer r.forget(key, c)
+		return fn(c.ctx)
+	})
+	return c, ch, coalesced
+}
+
+// leave は待っている呼び出し元から外れる。最後の呼び出し元だった場合は問い合わせを終了させる。
+func (r *UserRepository) leave(key string, c *call) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c.waiters--
+	if c.waiters > 0 {
+		return
+	}
+	c.cancel()
+	r.forgetLocked(key, c)
+}
+
+func (r *UserRepository) forget(key string, c *call) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.forgetLocked(key, c)
+}
+
+func (r *UserRepository) forgetLocked(key string, c *call) {
+	if r.calls[key] == c {
+		delete(r.calls, key)
+	}
+}
+
+func cloneUser(u *user.User) *user.User {
+	if u == nil {
+		return nil
+	}
+	return user.Reconstruct(u.ID(), u.Name(), u.Email(), u.CreatedAt())
+}
+
+func cloneUsers(users []*user.User) []*user.User {
+	if users == nil {
+		return nil
+	}
+	cloned := make([]*user.User, len(users))
+	for i, u := range users {
+		cloned[i] = cloneUser(u)
+	}
+	return cloned
+}
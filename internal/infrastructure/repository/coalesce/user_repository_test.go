@@ -0,0 +1,242 @@
+package coalesce_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/domain/user"
+	"go-api/internal/domain/user/mocks"
+	"go-api/internal/domain/user/valueobject"
+	"go-api/internal/infrastructure/repository/coalesce"
+	"go-api/internal/testutil/factory"
+)
+
+// recordedMetrics は呼び出しを数える Metrics。
+type recordedMetrics struct {
+	mu        sync.Mutex
+	calls     int
+	coalesced int
+}
+
+func (m *recordedMetrics) Call(_ string, coalesced bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls++
+	if coalesced {
+		m.coalesced++
+	}
+}
+
+// waitCalls は n 件の呼び出しが記録されるまで待つ。
+func (m *recordedMetrics) waitCalls(t *testing.T, n int) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return m.calls >= n
+	}, time.Second, time.Millisecond)
+}
+
+type result struct {
+	user *user.User
+	err  error
+}
+
+func TestUserRepository_FindByID(t *testing.T) {
+	t.Run("同時に行われた同じIDの取得は1回の問い合わせにまとめる", func(t *testing.T) {
+		u := factory.NewUser(factory.WithName("test"))
+		release := make(chan struct{})
+		inner := mocks.NewMockUserRepository(t)
+		inner.EXPECT().FindByID(mock.Anything, u.ID()).
+			RunAndReturn(func(context.Context, valueobject.UserID) (*user.User, error) {
+				<-release
+				return u, nil
+			}).Once()
+		m := &recordedMetrics{}
+		r := coalesce.NewUserRepository(inner, coalesce.Options{Metrics: m})
+
+		results := make(chan result, 3)
+		for range 3 {
+			go func() {
+				got, err := r.FindByID(context.Background(), u.ID())
+				results <- result{got, err}
+			}()
+		}
+		m.waitCalls(t, 3)
+		close(release)
+
+		var users []*user.User
+		for range 3 {
+			res := <-results
+			require.NoError(t, res.err)
+			assert.Equal(t, "test", res.user.Name().String())
+			users = append(users, res.user)
+		}
+		assert.NotSame(t, users[0], users[1], "呼び出し元ごとに複製したエンティティを返すべき")
+		assert.Equal(t, 2, m.coalesced)
+	})
+
+	t.Run("呼び出し元のキャンセルは他の呼び出し元の取得を中断しない", func(t *testing.T) {
+		u := factory.NewUser()
+		release := make(chan struct{})
+		inner := mocks.NewMockUserRepository(t)
+		inner.EXPECT().FindByID(mock.Anything, u.ID()).
+			RunAndReturn(func(ctx context.Context, _ valueobject.UserID) (*user.User, error) {
+				select {
+				case <-release:
+					return u, nil
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}).Once()
+		m := &recordedMetrics{}
+		r := coalesce.NewUserRepository(inner, coalesce.Options{Metrics: m})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		canceled := make(chan result, 1)
+		go func() {
+			got, err := r.FindByID(ctx, u.ID())
+			canceled <- result{got, err}
+		}()
+		m.waitCalls(t, 1)
+		other := make(chan result, 1)
+		go func() {
+			got, err := r.FindByID(context.Background(), u.ID())
+			other <- result{got, err}
+		}()
+		m.waitCalls(t, 2)
+
+		cancel()
+		res := <-canceled
+		assert.ErrorIs(t, res.err, context.Canceled)
+
+		close(release)
+		res = <-other
+		require.NoError(t, res.err)
+		assert.Equal(t, u.ID(), res.user.ID())
+	})
+
+	t.Run("待っている呼び出し元が全てキャンセルすると問い合わせを中断する", func(t *testing.T) {
+		id := valueobject.NewUserID()
+		aborted := make(chan struct{})
+		inner := mocks.NewMockUserRepository(t)
+		inner.EXPECT().FindByID(mock.Anything, id).
+			RunAndReturn(func(ctx context.Context, _ valueobject.UserID) (*user.User, error) {
+				<-ctx.Done()
+				close(aborted)
+				return nil, ctx.Err()
+			}).Once()
+		r := coalesce.NewUserRepository(inner, coalesce.Options{})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := r.FindByID(ctx, id)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		select {
+		case <-aborted:
+		case <-time.After(time.Second):
+			t.Fatal("問い合わせが中断されない")
+		}
+	})
+
+	t.Run("まとめた問い合わせはTimeoutで中断する", func(t *testing.T) {
+		id := valueobject.NewUserID()
+		inner := mocks.NewMockUserRepository(t)
+		inner.EXPECT().FindByID(mock.Anything, id).
+			RunAndReturn(func(ctx context.Context, _ valueobject.UserID) (*user.User, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}).Once()
+		r := coalesce.NewUserRepository(inner, coalesce.Options{Timeout: 10 * time.Millisecond})
+
+		_, err := r.FindByID(context.Background(), id)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("Bypassの対象は実行中の問い合わせに相乗りせず、呼び出し元のコンテキストで問い合わせる", func(t *testing.T) {
+		type stickyKey struct{}
+		u := factory.NewUser()
+		release := make(chan struct{})
+		inner := mocks.NewMockUserRepository(t)
+		inner.EXPECT().FindByID(mock.Anything, u.ID()).
+			RunAndReturn(func(ctx context.Context, _ valueobject.UserID) (*user.User, error) {
+				if ctx.Value(stickyKey{}) == nil {
+					<-release
+				}
+				return u, nil
+			}).Twice()
+		m := &recordedMetrics{}
+		r := coalesce.NewUserRepository(inner, coalesce.Options{
+			Metrics: m,
+			Bypass:  func(ctx context.Context) bool { return ctx.Value(stickyKey{}) != nil },
+		})
+
+		other := make(chan result, 1)
+		go func() {
+			got, err := r.FindByID(context.Background(), u.ID())
+			other <- result{got, err}
+		}()
+		m.waitCalls(t, 1)
+
+		_, err := r.FindByID(context.WithValue(context.Background(), stickyKey{}, true), u.ID())
+		require.NoError(t, err)
+		close(release)
+		res := <-other
+		require.NoError(t, res.err)
+		assert.Zero(t, m.coalesced)
+	})
+
+	t.Run("完了した後の取得は新しく問い合わせる", func(t *testing.T) {
+		u := factory.NewUser()
+		inner := mocks.NewMockUserRepository(t)
+		inner.EXPECT().FindByID(mock.Anything, u.ID()).Return(u, nil).Twice()
+		m := &recordedMetrics{}
+		r := coalesce.NewUserRepository(inner, coalesce.Options{Metrics: m})
+
+		for range 2 {
+			_, err := r.FindByID(context.Background(), u.ID())
+			require.NoError(t, err)
+		}
+		assert.Zero(t, m.coalesced)
+	})
+}
+
+func TestUserRepository_FindByIDs(t *testing.T) {
+	t.Run("順序が異なっても同じIDの組の取得はまとめる", func(t *testing.T) {
+		a, b := factory.NewUser(), factory.NewUser()
+		release := make(chan struct{})
+		inner := mocks.NewMockUserRepository(t)
+		inner.EXPECT().FindByIDs(mock.Anything, mock.Anything).
+			RunAndReturn(func(context.Context, []valueobject.UserID) ([]*user.User, error) {
+				<-release
+				return []*user.User{a, b}, nil
+			}).Once()
+		m := &recordedMetrics{}
+		r := coalesce.NewUserRepository(inner, coalesce.Options{Metrics: m})
+
+		results := make(chan error, 2)
+		for _, ids := range [][]valueobject.UserID{{a.ID(), b.ID()}, {b.ID(), a.ID(), b.ID()}} {
+			go func() {
+				users, err := r.FindByIDs(context.Background(), ids)
+				if err == nil && len(users) != 2 {
+					t.Errorf("2件のユーザーを返すべき: %d", len(users))
+				}
+				results <- err
+			}()
+		}
+		m.waitCalls(t, 2)
+		close(release)
+
+		for range 2 {
+			require.NoError(t, <-results)
+		}
+		assert.Equal(t, 1, m.coalesced)
+	})
+}
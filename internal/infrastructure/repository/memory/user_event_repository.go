@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go-api/internal/domain/user"
+)
+
+// UserEventRepository は UserRepository が記録したユーザーの変更イベントを参照する。
+type UserEventRepository struct {
+	users *UserRepository
+}
+
+var _ user.EventRepository = (*UserEventRepository)(nil)
+
+// NewUserEventRepository は users の変更イベントを参照する UserEventRepository を生成する。
+func NewUserEventRepository(users *UserRepository) *UserEventRepository {
+	return &UserEventRepository{users: users}
+}
+
+// FindAfter は after より後のイベントを古い順に最大 limit 件取得する。
+// イベントは UserRepository のロックを保持して記録するため、Seq の順に見える。
+func (r *UserEventRepository) FindAfter(_ context.Context, after user.EventPosition, limit int) ([]*user.Event, error) {
+	r.users.mu.RLock()
+	defer r.users.mu.RUnlock()
+	events := r.users.events
+	// 古いイベントは削除されうるため、Seq で位置を探す
+	start := sort.Search(len(events), func(i int) bool { return events[i].Seq > after.Seq })
+	end := min(start+max(limit, 0), len(events))
+	found := make([]*user.Event, 0, end-start)
+	for _, e := range events[start:end] {
+		copied := *e
+		copied.User = clone(e.User)
+		found = append(found, &copied)
+	}
+	return found, nil
+}
+
+// Latest は最後に記録されたイベントの位置を返す。イベントがない場合はゼロ値を返す。
+// 削除したイベントも含めて数える。
+func (r *UserEventRepository) Latest(context.Context) (user.EventPosition, error) {
+	r.users.mu.RLock()
+	defer r.users.mu.RUnlock()
+	return user.EventPosition{Seq: r.users.eventSeq}, nil
+}
+
+// FindPosition は seq のイベントの位置を返す。イベントがない場合は false を返す。
+func (r *UserEventRepository) FindPosition(_ context.Context, seq int64) (user.EventPosition, bool, error) {
+	r.users.mu.RLock()
+	defer r.users.mu.RUnlock()
+	events := r.users.events
+	i := sort.Search(len(events), func(i int) bool { return events[i].Seq >= seq })
+	if i == len(events) || events[i].Seq != seq {
+		return user.EventPosition{}, false, nil
+	}
+	return user.EventPosition{Seq: seq}, true, nil
+}
+
+// DeleteBefore は before より前に発生したイベントを削除し、削除した件数を返す。
+func (r *UserEventRepository) DeleteBefore(_ context.Context, before time.Time) (int64, error) {
+	r.users.mu.Lock()
+	defer r.users.mu.Unlock()
+	events := r.users.events
+	// イベントは発生順に記録される
+	n := sort.Search(len(events), func(i int) bool { return !events[i].OccurredAt.Before(before) })
+	r.users.events = append([]*user.Event(nil), events[n:]...)
+	return int64(n), nil
+}
@@ -0,0 +1,97 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/domain/user"
+	"go-api/internal/domain/user/valueobject"
+	"go-api/internal/infrastructure/repository/memory"
+	"go-api/internal/testutil/factory"
+)
+
+func TestUserEventRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("作成・変更・削除をイベントとして記録する", func(t *testing.T) {
+		users := memory.NewUserRepository()
+		events := memory.NewUserEventRepository(users)
+		u := factory.NewUser(factory.WithName("before"))
+
+		require.NoError(t, users.Save(ctx, u))
+		require.NoError(t, users.Save(ctx, u)) // 変更がなければ記録しない
+		name, _ := valueobject.NewUserName("after")
+		u.ChangeName(name)
+		require.NoError(t, users.Save(ctx, u))
+		require.NoError(t, users.Delete(ctx, u.ID()))
+		require.NoError(t, users.Delete(ctx, u.ID())) // 存在しなければ記録しない
+
+		got, err := events.FindAfter(ctx, user.EventPosition{}, 10)
+		require.NoError(t, err)
+		require.Len(t, got, 3)
+		assert.Equal(t, []valueobject.EventType{valueobject.EventUserCreated, valueobject.EventUserUpdated, valueobject.EventUserDeleted},
+			[]valueobject.EventType{got[0].Type, got[1].Type, got[2].Type})
+		assert.Equal(t, "before", got[0].User.Name().String())
+		assert.Equal(t, "after", got[2].User.Name().String(), "削除は削除前の値を記録するべき")
+
+		latest, err := events.Latest(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, user.EventPosition{Seq: 3}, latest)
+	})
+
+	t.Run("指定した位置より後のイベントを件数の上限まで返す", func(t *testing.T) {
+		users := memory.NewUserRepository()
+		events := memory.NewUserEventRepository(users)
+		for range 5 {
+			require.NoError(t, users.Save(ctx, factory.NewUser()))
+		}
+
+		got, err := events.FindAfter(ctx, user.EventPosition{Seq: 2}, 2)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, int64(3), got[0].Seq)
+		assert.Equal(t, int64(4), got[1].Seq)
+
+		got, err = events.FindAfter(ctx, user.EventPosition{Seq: 5}, 10)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+		pos, found, err := events.FindPosition(ctx, 3)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, user.EventPosition{Seq: 3}, pos)
+		_, found, err = events.FindPosition(ctx, 6)
+		require.NoError(t, err)
+		assert.False(t, found, "記録されていないSeqは見つからないべき")
+	})
+	t.Run("指定した日時より前のイベントを削除し、Seqは再利用しない", func(t *testing.T) {
+		users := memory.NewUserRepository()
+		events := memory.NewUserEventRepository(users)
+		for range 3 {
+			require.NoError(t, users.Save(ctx, factory.NewUser()))
+		}
+
+		n, err := events.DeleteBefore(ctx, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, n, "保持期間内のイベントは削除しないべき")
+
+		n, err = events.DeleteBefore(ctx, time.Now().Add(time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), n)
+		got, err := events.FindAfter(ctx, user.EventPosition{}, 10)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+		_, found, err := events.FindPosition(ctx, 3)
+		require.NoError(t, err)
+		assert.False(t, found, "削除したイベントは見つからないべき")
+
+		require.NoError(t, users.Save(ctx, factory.NewUser()))
+		got, err = events.FindAfter(ctx, user.EventPosition{}, 10)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, int64(4), got[0].Seq)
+	})
+}
@@ -0,0 +1,185 @@
+// Package memory はプロセス内メモリを使用したリポジトリ実装を提供する。
+// PostgreSQLを用意せずにサーバーを動かすデモやテストのためのもので、データはプロセスの終了で失われる。
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"go-api/internal/domain"
+	"go-api/internal/domain/user"
+	"go-api/internal/domain/user/valueobject"
+)
+
+// record は保存したユーザーと作成順。
+type record struct {
+	user *user.User
+}
+
+// UserRepository はプロセス内メモリを使用したユーザーリポジトリの実装。
+// メールアドレスの一意制約、見つからない場合のエラー、一覧の順序をPostgreSQLの実装に合わせる。
+// 変更は user_events テーブルのトリガーと同様にイベントとして記録し、EventRepository から参照できる。
+type UserRepository struct {
+	mu     sync.RWMutex
+	users  map[string]*record // キーはユーザーID
+	emails map[string]string  // メールアドレスからユーザーID
+	events []*user.Event
+	// eventSeq は最後に記録したイベントの Seq。イベントを削除しても戻さない
+	eventSeq int64
+	now      func() time.Time
+}
+
+var _ user.UserRepository = (*UserRepository)(nil)
+
+// NewUserRepository は空の UserRepository を生成する。
+func NewUserRepository() *UserRepository {
+	return &UserRepository{
+		users:  make(map[string]*record),
+		emails: make(map[string]string),
+		now:    time.Now,
+	}
+}
+
+// Save はユーザーを保存する。既に存在するIDの場合は名前とメールアドレスを更新する。
+// 他のユーザーが使っているメールアドレスの場合は domain.ErrConflict を返す。
+func (r *UserRepository) Save(_ context.Context, u *user.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, email := u.ID().String(), u.Email().String()
+	if owner, ok := r.emails[email]; ok && owner != id {
+		return domain.Conflict("user", "Save", nil)
+	}
+
+	existing, ok := r.users[id]
+	if !ok {
+		saved := reconstruct(u, r.now().UTC().Round(0))
+		r.users[id] = &record{user: saved}
+		r.emails[email] = id
+		r.record(valueobject.EventUserCreated, saved)
+		return nil
+	}
+
+	old := existing.user
+	saved := reconstruct(u, old.CreatedAt())
+	delete(r.emails, old.Email().String())
+	r.emails[email] = id
+	existing.user = saved
+	if !old.Name().Equal(saved.Name()) || !old.Email().Equal(saved.Email()) {
+		r.record(valueobject.EventUserUpdated, saved)
+	}
+	return nil
+}
+
+// FindByID は指定されたIDのユーザーを取得する。
+// 見つからない場合は domain.ErrNotFound を返す。
+func (r *UserRepository) FindByID(_ context.Context, id valueobject.UserID) (*user.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rec, ok := r.users[id.String()]
+	if !ok {
+		return nil, domain.NotFound("user", "FindByID")
+	}
+	return clone(rec.user), nil
+}
+
+// FindByIDs は指定されたIDのユーザーをまとめて取得する。見つからないIDは結果に含めない。
+func (r *UserRepository) FindByIDs(_ context.Context, ids []valueobject.UserID) ([]*user.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	users := make([]*user.User, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		key := id.String()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if rec, ok := r.users[key]; ok {
+			users = append(users, clone(rec.user))
+		}
+	}
+	return users, nil
+}
+
+// FindAll は全ユーザーを作成日時の新しい順に取得する。
+func (r *UserRepository) FindAll(_ context.Context) ([]*user.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	users := make([]*user.User, 0, len(r.users))
+	for _, rec := range r.users {
+		users = append(users, clone(rec.user))
+	}
+	slices.SortFunc(users, compareCursor)
+	return users, nil
+}
+
+// FindPage は一覧の並び順（user.PageCursor）で after より後のユーザーを最大 limit 件取得する。
+func (r *UserRepository) FindPage(_ context.Context, after *user.PageCursor, limit int) ([]*user.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	users := make([]*user.User, 0, len(r.users))
+	for _, rec := range r.users {
+		if after == nil || after.Before(user.CursorOf(rec.user)) {
+			users = append(users, rec.user)
+		}
+	}
+	slices.SortFunc(users, compareCursor)
+	users = users[:min(limit, len(users))]
+	for i, u := range users {
+		users[i] = clone(u)
+	}
+	return users, nil
+}
+
+// Count はユーザーの件数を返す。
+func (r *UserRepository) Count(_ context.Context) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.users), nil
+}
+
+// Delete は指定されたIDのユーザーを削除する。存在しない場合も成功する。
+func (r *UserRepository) Delete(_ context.Context, id valueobject.UserID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec, ok := r.users[id.String()]
+	if !ok {
+		return nil
+	}
+	delete(r.users, id.String())
+	delete(r.emails, rec.user.Email().String())
+	r.record(valueobject.EventUserDeleted, rec.user)
+	return nil
+}
+
+// record は変更をイベントとして記録する。r.mu を保持して呼ぶ。
+func (r *UserRepository) record(typ valueobject.EventType, u *user.User) {
+	r.eventSeq++
+	r.events = append(r.events, &user.Event{
+		Seq:        r.eventSeq,
+		Type:       typ,
+		User:       clone(u),
+		OccurredAt: r.now(),
+	})
+}
+
+// clone は呼び出し元との間でエンティティを共有しないよう複製する。
+func clone(u *user.User) *user.User {
+	return reconstruct(u, u.CreatedAt())
+}
+
+// compareCursor は一覧の並び順（user.PageCursor）で a と b を比較する。
+func compareCursor(a, b *user.User) int {
+	if user.CursorOf(a).Before(user.CursorOf(b)) {
+		return -1
+	}
+	return 1
+}
+
+// reconstruct は作成日時を createdAt にした u の複製を返す。
+func reconstruct(u *user.User, createdAt time.Time) *user.User {
+	return user.Reconstruct(u.ID(), u.Name(), u.Email(), createdAt)
+}
@@ -0,0 +1,15 @@
+package memory_test
+
+import (
+	"testing"
+
+	"go-api/internal/domain/user"
+	"go-api/internal/infrastructure/repository/memory"
+	"go-api/internal/testutil/repositorytest"
+)
+
+func TestUserRepository(t *testing.T) {
+	repositorytest.RunUserRepository(t, func(*testing.T) user.UserRepository {
+		return memory.NewUserRepository()
+	})
+}
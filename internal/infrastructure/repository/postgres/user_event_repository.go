@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"go-api/internal/domain/user"
+	"go-api/internal/domain/user/valueobject"
+	sqlcuser "go-api/internal/sqlc/user"
+)
+
+// UserEventRepository はPostgreSQLを使用したユーザーの変更イベントのリポジトリの実装。
+// イベントは users テーブルのトリガーで user_events テーブルに記録される。
+type UserEventRepository struct {
+	queries *sqlcuser.Queries
+}
+
+// NewUserEventRepository は UserEventRepository を生成する。
+func NewUserEventRepository(queries *sqlcuser.Queries) *UserEventRepository {
+	return &UserEventRepository{queries: queries}
+}
+
+// FindAfter は after より後のイベントを (txid, seq) の順に最大 limit 件取得する。
+// 実行中のトランザクションより後の txid のイベントは、コミットの順が決まるまで返さない（マイグレーション 000009）。
+func (r *UserEventRepository) FindAfter(ctx context.Context, after user.EventPosition, limit int) ([]*user.Event, error) {
+	rows, err := r.queries.ListUserEventsAfter(ctx, sqlcuser.ListUserEventsAfterParams{
+		Txid:    after.TxID,
+		Seq:     after.Seq,
+		MaxRows: int32(min(limit, math.MaxInt32)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*user.Event, 0, len(rows))
+	for i := range rows {
+		e, err := toEvent(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+// Latest は FindAfter で取得できる最後のイベントの位置を返す。イベントがない場合はゼロ値を返す。
+func (r *UserEventRepository) Latest(ctx context.Context) (user.EventPosition, error) {
+	row, err := r.queries.GetLatestUserEventPosition(ctx)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return user.EventPosition{}, nil
+	}
+	if err != nil {
+		return user.EventPosition{}, err
+	}
+	return user.EventPosition{TxID: row.Txid, Seq: row.Seq}, nil
+}
+
+// FindPosition は seq のイベントの位置を返す。イベントがない場合は false を返す。
+func (r *UserEventRepository) FindPosition(ctx context.Context, seq int64) (user.EventPosition, bool, error) {
+	txid, err := r.queries.GetUserEventTxid(ctx, seq)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return user.EventPosition{}, false, nil
+	}
+	if err != nil {
+		return user.EventPosition{}, false, err
+	}
+	return user.EventPosition{TxID: txid, Seq: seq}, true, nil
+}
+
+// DeleteBefore は before より前に発生したイベントを削除し、削除した件数を返す。
+func (r *UserEventRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	return r.queries.DeleteUserEventsBefore(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+}
+
+// toEvent はsqlcの行データをドメインのEventに変換する。
+func toEvent(row *sqlcuser.UserEvent) (*user.Event, error) {
+	typ, err := valueobject.ParseEventType(row.Type)
+	if err != nil {
+		return nil, err
+	}
+	u, err := toEntity(&sqlcuser.User{ID: row.UserID, Name: row.Name, Email: row.Email})
+	if err != nil {
+		return nil, err
+	}
+	return &user.Event{
+		Seq:        row.Seq,
+		TxID:       row.Txid,
+		Type:       typ,
+		User:       u,
+		OccurredAt: row.OccurredAt.Time,
+	}, nil
+}
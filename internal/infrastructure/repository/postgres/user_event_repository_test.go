@@ -0,0 +1,148 @@
+//go:build integration
+
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/domain/user"
+	"go-api/internal/domain/user/valueobject"
+	"go-api/internal/infrastructure/repository/postgres"
+	sqlcuser "go-api/internal/sqlc/user"
+	"go-api/internal/testutil/factory"
+)
+
+func TestUserEventRepository(t *testing.T) {
+	// FindAfter は実行中のトランザクションのイベントを返さないため、書き込みはコミットする
+	t.Run("ユーザーの作成・更新・削除がイベントとして記録される", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+		defer cancel()
+		repo := postgres.NewUserRepository(sqlcuser.New(testPool))
+		events := postgres.NewUserEventRepository(sqlcuser.New(testPool))
+
+		before, err := events.Latest(ctx)
+		require.NoError(t, err, "Latest に失敗")
+
+		u := factory.NewUser(factory.WithName("作成"))
+		t.Cleanup(func() { _ = repo.Delete(context.Background(), u.ID()) })
+		require.NoError(t, repo.Save(ctx, u))
+		require.NoError(t, repo.Save(ctx, u), "値が変わらない保存はイベントにならないべき")
+		name, err := valueobject.NewUserName("更新")
+		require.NoError(t, err)
+		u.ChangeName(name)
+		require.NoError(t, repo.Save(ctx, u))
+		require.NoError(t, repo.Delete(ctx, u.ID()))
+
+		found, err := events.FindAfter(ctx, before, 100)
+		require.NoError(t, err, "FindAfter に失敗")
+		ours := eventsOf(found, u)
+		require.Len(t, ours, 3)
+		assert.Equal(t, valueobject.EventUserCreated, ours[0].Type)
+		assert.Equal(t, "作成", ours[0].User.Name().String())
+		assert.Equal(t, valueobject.EventUserUpdated, ours[1].Type)
+		assert.Equal(t, "更新", ours[1].User.Name().String())
+		assert.Equal(t, valueobject.EventUserDeleted, ours[2].Type)
+		assert.Equal(t, u.ID().String(), ours[2].User.ID().String(), "削除は削除前のユーザーを記録するべき")
+		assert.True(t, ours[0].Position().Before(ours[1].Position()))
+		assert.True(t, ours[1].Position().Before(ours[2].Position()))
+
+		latest, err := events.Latest(ctx)
+		require.NoError(t, err)
+		assert.False(t, latest.Before(ours[2].Position()), "最後のイベントより前の位置を返してはいけない")
+
+		pos, ok, err := events.FindPosition(ctx, ours[1].Seq)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, ours[1].Position(), pos)
+
+		limited, err := events.FindAfter(ctx, ours[0].Position(), 1)
+		require.NoError(t, err)
+		require.Len(t, limited, 1, "limit 件まで取得するべき")
+		assert.True(t, ours[0].Position().Before(limited[0].Position()))
+	})
+
+	t.Run("指定した日時より前のイベントを削除する", func(t *testing.T) {
+		ctx, tx, repo := setupTest(t)
+		events := postgres.NewUserEventRepository(sqlcuser.New(tx))
+		u := factory.NewUser()
+		require.NoError(t, repo.Save(ctx, u))
+		var seq int64
+		require.NoError(t, tx.QueryRow(ctx, `SELECT seq FROM user_events WHERE user_id = $1`, u.ID().String()).Scan(&seq))
+
+		_, err := events.DeleteBefore(ctx, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		_, found, err := events.FindPosition(ctx, seq)
+		require.NoError(t, err)
+		assert.True(t, found, "保持期間内のイベントは削除しないべき")
+
+		n, err := events.DeleteBefore(ctx, time.Now().Add(time.Second))
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, n, int64(1))
+		_, found, err = events.FindPosition(ctx, seq)
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+}
+
+func TestUserEventRepository_ConcurrentTransactions(t *testing.T) {
+	t.Run("同時に書き込むトランザクションは互いを待たず、先に採番したトランザクションのコミットまで後のイベントを返さない", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+		defer cancel()
+		events := postgres.NewUserEventRepository(sqlcuser.New(testPool))
+		before, err := events.Latest(ctx)
+		require.NoError(t, err)
+
+		first, second := factory.NewUser(), factory.NewUser()
+		t.Cleanup(func() {
+			repo := postgres.NewUserRepository(sqlcuser.New(testPool))
+			_ = repo.Delete(context.Background(), first.ID())
+			_ = repo.Delete(context.Background(), second.ID())
+		})
+
+		tx1, err := testPool.Begin(ctx)
+		require.NoError(t, err)
+		defer tx1.Rollback(context.Background())
+		require.NoError(t, postgres.NewUserRepository(sqlcuser.New(tx1)).Save(ctx, first))
+
+		// tx1 のコミット前に、後から始めた tx2 が書き込んでコミットする
+		tx2, err := testPool.Begin(ctx)
+		require.NoError(t, err)
+		defer tx2.Rollback(context.Background())
+		writeCtx, cancelWrite := context.WithTimeout(ctx, time.Second)
+		defer cancelWrite()
+		require.NoError(t, postgres.NewUserRepository(sqlcuser.New(tx2)).Save(writeCtx, second), "tx1 の終了を待たずに書き込めるべき")
+		require.NoError(t, tx2.Commit(writeCtx))
+
+		found, err := events.FindAfter(ctx, before, 100)
+		require.NoError(t, err)
+		assert.Empty(t, eventsOf(found, first, second), "先に採番したトランザクションのコミット前に後のイベントを返してはいけない")
+
+		require.NoError(t, tx1.Commit(ctx))
+
+		found, err = events.FindAfter(ctx, before, 100)
+		require.NoError(t, err)
+		ours := eventsOf(found, first, second)
+		require.Len(t, ours, 2)
+		assert.Equal(t, first.ID(), ours[0].User.ID())
+		assert.Equal(t, second.ID(), ours[1].User.ID())
+	})
+}
+
+// eventsOf は events から users のいずれかのイベントだけを順序を保って返す。
+func eventsOf(events []*user.Event, users ...*user.User) []*user.Event {
+	var out []*user.Event
+	for _, e := range events {
+		for _, u := range users {
+			if e.User.ID().Equal(u.ID()) {
+				out = append(out, e)
+				break
+			}
+		}
+	}
+	return out
+}
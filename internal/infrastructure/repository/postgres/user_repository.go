@@ -13,6 +13,7 @@ import (
 	"go-api/internal/domain"
 	"go-api/internal/domain/user"
 	"go-api/internal/domain/user/valueobject"
+	"go-api/internal/infrastructure/database"
 	sqlcuser "go-api/internal/sqlc/user"
 )
 
@@ -24,17 +25,39 @@ const (
 // UserRepository はPostgreSQLを使用したユーザーリポジトリの実装。
 type UserRepository struct {
 	queries *sqlcuser.Queries
+	// reader は読み込みに使う。レプリカに振り分ける場合は queries と異なる
+	reader *sqlcuser.Queries
+	// retry は一時的なエラーで失敗した読み込みを再試行する。nil の場合は再試行しない
+	retry *database.RetryPolicy
 }
 
-// NewUserRepository は UserRepository を生成する。
-func NewUserRepository(queries *sqlcuser.Queries) *UserRepository {
-	return &UserRepository{queries: queries}
+// Option は UserRepository の生成オプション。
+type Option func(*UserRepository)
+
+// WithReader は読み込み（FindByID・FindByIDs・FindAll・FindPage・Count）に使う Queries を設定する。
+// 設定しない場合は書き込みと同じ queries から読む。
+func WithReader(reader *sqlcuser.Queries) Option {
+	return func(r *UserRepository) { r.reader = reader }
+}
+
+// WithRetry は一時的なエラーで失敗した読み込み（FindByID・FindByIDs・FindAll・FindPage・Count）を policy に従って再試行する。
+func WithRetry(policy *database.RetryPolicy) Option {
+	return func(r *UserRepository) { r.retry = policy }
+}
+
+// NewUserRepository は UserRepository を生成する。queries は書き込みに使う。
+func NewUserRepository(queries *sqlcuser.Queries, opts ...Option) *UserRepository {
+	r := &UserRepository{queries: queries, reader: queries}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
-// Save はユーザーをDBに保存する。
-// 一意制約違反の場合は domain.ErrConflict を返す。
+// Save はユーザーをDBに保存する。既に存在するIDの場合は名前とメールアドレスを更新し、作成日時は変えない。
+// メールアドレスの一意制約違反の場合は domain.ErrConflict を返す。
 func (r *UserRepository) Save(ctx context.Context, u *user.User) error {
-	err := r.queries.CreateUser(ctx, sqlcuser.CreateUserParams{
+	err := r.queries.UpsertUser(ctx, sqlcuser.UpsertUserParams{
 		ID:    uuidToPgtype(u.ID()),
 		Name:  u.Name().String(),
 		Email: u.Email().String(),
@@ -52,7 +75,11 @@ func (r *UserRepository) Save(ctx context.Context, u *user.User) error {
 // FindByID は指定されたIDのユーザーを取得する。
 // 見つからない場合は domain.ErrNotFound を返す。
 func (r *UserRepository) FindByID(ctx context.Context, id valueobject.UserID) (*user.User, error) {
-	row, err := r.queries.GetUser(ctx, uuidToPgtype(id))
+	var row sqlcuser.User
+	err := r.retry.Do(ctx, "FindByID", func() (err error) {
+		row, err = r.reader.GetUser(ctx, uuidToPgtype(id))
+		return err
+	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.NotFound("user", "FindByID")
@@ -62,22 +89,66 @@ func (r *UserRepository) FindByID(ctx context.Context, id valueobject.UserID) (*
 	return toEntity(&row)
 }
 
+// FindByIDs は指定されたIDのユーザーを1回のクエリでまとめて取得する。
+// 見つからないIDは結果に含めない。
+func (r *UserRepository) FindByIDs(ctx context.Context, ids []valueobject.UserID) ([]*user.User, error) {
+	pgIDs := make([]pgtype.UUID, len(ids))
+	for i, id := range ids {
+		pgIDs[i] = uuidToPgtype(id)
+	}
+	var rows []sqlcuser.User
+	err := r.retry.Do(ctx, "FindByIDs", func() (err error) {
+		rows, err = r.reader.ListUsersByIDs(ctx, pgIDs)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toEntities(rows)
+}
+
 // FindAll は全ユーザーを取得する。
 func (r *UserRepository) FindAll(ctx context.Context) ([]*user.User, error) {
-	rows, err := r.queries.ListUsers(ctx)
+	var rows []sqlcuser.User
+	err := r.retry.Do(ctx, "FindAll", func() (err error) {
+		rows, err = r.reader.ListUsers(ctx)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
+	return toEntities(rows)
+}
 
-	users := make([]*user.User, 0, len(rows))
-	for i := range rows {
-		u, err := toEntity(&rows[i])
-		if err != nil {
-			return nil, err
+// FindPage は一覧の並び順（user.PageCursor）で after より後のユーザーを最大 limit 件取得する。
+func (r *UserRepository) FindPage(ctx context.Context, after *user.PageCursor, limit int) ([]*user.User, error) {
+	var rows []sqlcuser.User
+	err := r.retry.Do(ctx, "FindPage", func() (err error) {
+		if after == nil {
+			rows, err = r.reader.ListUsersFirstPage(ctx, int32(limit))
+			return err
 		}
-		users = append(users, u)
+		rows, err = r.reader.ListUsersPageAfter(ctx, sqlcuser.ListUsersPageAfterParams{
+			CreatedAt: pgtype.Timestamptz{Time: after.CreatedAt, Valid: true},
+			ID:        uuidToPgtype(after.ID),
+			MaxRows:   int32(limit),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
-	return users, nil
+	return toEntities(rows)
+}
+
+// Count はユーザーの件数を返す。
+func (r *UserRepository) Count(ctx context.Context) (int, error) {
+	var n int64
+	err := r.retry.Do(ctx, "Count", func() (err error) {
+		n, err = r.reader.CountUsers(ctx)
+		return err
+	})
+	return int(n), err
 }
 
 // Delete は指定されたIDのユーザーを削除する。
@@ -106,7 +177,20 @@ func toEntity(row *sqlcuser.User) (*user.User, error) {
 	if err != nil {
 		return nil, err
 	}
-	return user.Reconstruct(id, name, email), nil
+	return user.Reconstruct(id, name, email, row.CreatedAt.Time), nil
+}
+
+// toEntities はsqlcの行データをドメインのUserエンティティのスライスに変換する。
+func toEntities(rows []sqlcuser.User) ([]*user.User, error) {
+	users := make([]*user.User, 0, len(rows))
+	for i := range rows {
+		u, err := toEntity(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	return users, nil
 }
 
 // uuidToString はPostgreSQLのUUID型を文字列に変換する。
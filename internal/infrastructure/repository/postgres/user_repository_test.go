@@ -21,6 +21,7 @@ import (
 	"go-api/internal/infrastructure/repository/postgres"
 	sqlcuser "go-api/internal/sqlc/user"
 	"go-api/internal/testutil/factory"
+	"go-api/internal/testutil/repositorytest"
 )
 
 const testTimeout = 5 * time.Second
@@ -106,6 +107,59 @@ func TestUserRepository_Save(t *testing.T) {
 		assert.Equal(t, u.Name().String(), name, "Name が一致しない")
 		assert.Equal(t, u.Email().String(), email, "Email が一致しない")
 	})
+
+	t.Run("他のユーザーと同じメールアドレスはErrConflictを返す", func(t *testing.T) {
+		ctx, tx, repo := setupTest(t)
+
+		existing := factory.NewUser()
+		insertUserRow(t, ctx, tx, existing)
+
+		err := repo.Save(ctx, factory.NewUser(factory.WithEmail(existing.Email().String())))
+		assert.True(t, errors.Is(err, domain.ErrConflict), "ErrConflict が返るべき")
+	})
+
+	t.Run("既存のユーザーを行を増やさず、作成日時を保ったまま更新できる", func(t *testing.T) {
+		ctx, tx, repo := setupTest(t)
+
+		u := factory.NewUser(factory.WithName("更新前"))
+		insertUserRow(t, ctx, tx, u)
+		var createdAt time.Time
+		require.NoError(t, tx.QueryRow(ctx, `SELECT created_at FROM users WHERE id = $1`, u.ID().String()).Scan(&createdAt))
+
+		name, err := valueobject.NewUserName("更新後")
+		require.NoError(t, err)
+		email, err := valueobject.NewEmail("updated-" + u.Email().String())
+		require.NoError(t, err)
+		u.ChangeName(name)
+		u.ChangeEmail(email)
+		require.NoError(t, repo.Save(ctx, u), "既存のIDの保存は ErrConflict ではなく更新になるべき")
+
+		var (
+			count        int
+			gotName      string
+			gotEmail     string
+			gotCreatedAt time.Time
+		)
+		require.NoError(t, tx.QueryRow(ctx,
+			`SELECT COUNT(*) OVER (), name, email, created_at FROM users WHERE id = $1`, u.ID().String(),
+		).Scan(&count, &gotName, &gotEmail, &gotCreatedAt))
+		assert.Equal(t, 1, count)
+		assert.Equal(t, "更新後", gotName, "Name が更新されていない")
+		assert.Equal(t, email.String(), gotEmail, "Email が更新されていない")
+		assert.True(t, createdAt.Equal(gotCreatedAt), "作成日時は変えないべき")
+	})
+
+	t.Run("既存のユーザーの更新で他のユーザーと同じメールアドレスはErrConflictを返す", func(t *testing.T) {
+		ctx, tx, repo := setupTest(t)
+
+		a, b := factory.NewUser(), factory.NewUser()
+		insertUserRow(t, ctx, tx, a)
+		insertUserRow(t, ctx, tx, b)
+
+		b.ChangeEmail(a.Email())
+		err := repo.Save(ctx, b)
+		assert.ErrorIs(t, err, domain.ErrConflict)
+	})
 }
 
 func TestUserRepository_FindByID(t *testing.T) {
@@ -135,6 +189,25 @@ func TestUserRepository_FindByID(t *testing.T) {
 	})
 }
 
+func TestUserRepository_FindByIDs(t *testing.T) {
+	t.Run("存在するIDのユーザーだけをまとめて取得できる", func(t *testing.T) {
+		ctx, tx, repo := setupTest(t)
+
+		u1 := factory.NewUser()
+		u2 := factory.NewUser()
+		insertUserRow(t, ctx, tx, u1)
+		insertUserRow(t, ctx, tx, u2)
+
+		users, err := repo.FindByIDs(ctx, []valueobject.UserID{u1.ID(), valueobject.NewUserID(), u2.ID()})
+		require.NoError(t, err, "FindByIDs に失敗")
+		assert.Len(t, users, 2, "存在する2件だけ取得できるべき")
+
+		ids := []string{users[0].ID().String(), users[1].ID().String()}
+		assert.Contains(t, ids, u1.ID().String(), "u1 が含まれるべき")
+		assert.Contains(t, ids, u2.ID().String(), "u2 が含まれるべき")
+	})
+}
+
 func TestUserRepository_FindAll(t *testing.T) {
 	t.Run("全ユーザーを取得できる", func(t *testing.T) {
 		ctx, tx, repo := setupTest(t)
@@ -173,3 +246,26 @@ func TestUserRepository_Delete(t *testing.T) {
 		assert.False(t, found, "削除後はDBに存在しないべき")
 	})
 }
+
+func TestUserRepository_Conformance(t *testing.T) {
+	// 作成順を検証するため、トランザクションで囲まずに確定させる（保存したユーザーはテストの終了時に削除される）
+	repositorytest.RunUserRepository(t, func(*testing.T) user.UserRepository {
+		return postgres.NewUserRepository(sqlcuser.New(testPool))
+	})
+}
+
+func TestUserRepository_WithReader(t *testing.T) {
+	t.Run("読み込みはreaderから、書き込みはqueriesに行う", func(t *testing.T) {
+		ctx, tx, _ := setupTest(t)
+		// reader はトランザクションの外から読むため、確定前の書き込みは見えない
+		repo := postgres.NewUserRepository(sqlcuser.New(tx), postgres.WithReader(sqlcuser.New(testPool)))
+		u := factory.NewUser()
+
+		require.NoError(t, repo.Save(ctx, u))
+
+		_, err := repo.FindByID(ctx, u.ID())
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		_, _, found := selectUserRow(t, ctx, tx, u.ID())
+		assert.True(t, found)
+	})
+}
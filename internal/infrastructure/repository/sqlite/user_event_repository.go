@@ -0,0 +1,89 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"go-api/internal/domain/user"
+	"go-api/internal/domain/user/valueobject"
+	"go-api/internal/sqlc/sqliteuser"
+)
+
+// UserEventRepository はSQLiteを使用したユーザーの変更イベントのリポジトリの実装。
+// イベントは users テーブルのトリガーで user_events テーブルに記録される。
+type UserEventRepository struct {
+	queries *sqliteuser.Queries
+}
+
+// NewUserEventRepository は UserEventRepository を生成する。
+func NewUserEventRepository(queries *sqliteuser.Queries) *UserEventRepository {
+	return &UserEventRepository{queries: queries}
+}
+
+// FindAfter は after より後のイベントを古い順に最大 limit 件取得する。
+// SQLiteは書き込みを直列化するため、Seq の順にコミットされる。
+func (r *UserEventRepository) FindAfter(ctx context.Context, after user.EventPosition, limit int) ([]*user.Event, error) {
+	rows, err := r.queries.ListUserEventsAfter(ctx, sqliteuser.ListUserEventsAfterParams{
+		Seq:   after.Seq,
+		Limit: int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*user.Event, 0, len(rows))
+	for i := range rows {
+		e, err := toEvent(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+// Latest は最新のイベントの位置を返す。イベントがない場合はゼロ値を返す。
+func (r *UserEventRepository) Latest(ctx context.Context) (user.EventPosition, error) {
+	seq, err := r.queries.GetLatestUserEventSeq(ctx)
+	if err != nil {
+		return user.EventPosition{}, err
+	}
+	return user.EventPosition{Seq: seq}, nil
+}
+
+// FindPosition は seq のイベントの位置を返す。イベントがない場合は false を返す。
+func (r *UserEventRepository) FindPosition(ctx context.Context, seq int64) (user.EventPosition, bool, error) {
+	_, err := r.queries.GetUserEventSeq(ctx, seq)
+	if errors.Is(err, sql.ErrNoRows) {
+		return user.EventPosition{}, false, nil
+	}
+	if err != nil {
+		return user.EventPosition{}, false, err
+	}
+	return user.EventPosition{Seq: seq}, true, nil
+}
+
+// DeleteBefore は before より前に発生したイベントを削除し、削除した件数を返す。
+func (r *UserEventRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	return r.queries.DeleteUserEventsBefore(ctx, before.UTC().Format(timestampFormat))
+}
+
+// toEvent はsqlcの行データをドメインのEventに変換する。
+func toEvent(row *sqliteuser.UserEvent) (*user.Event, error) {
+	typ, err := valueobject.ParseEventType(row.Type)
+	if err != nil {
+		return nil, err
+	}
+	u, err := toEntity(&sqliteuser.User{ID: row.UserID, Name: row.Name, Email: row.Email})
+	if err != nil {
+		return nil, err
+	}
+	return &user.Event{
+		Seq:        row.Seq,
+		Type:       typ,
+		User:       u,
+		OccurredAt: row.OccurredAt,
+	}, nil
+}
@@ -0,0 +1,157 @@
+// Package sqlite はSQLiteを使用したリポジトリ実装を提供する。
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	sqlite3 "modernc.org/sqlite"
+	sqlite3lib "modernc.org/sqlite/lib"
+
+	"go-api/internal/domain"
+	"go-api/internal/domain/user"
+	"go-api/internal/domain/user/valueobject"
+	"go-api/internal/sqlc/sqliteuser"
+)
+
+// timestampFormat は created_at を保存する書式（strftime('%Y-%m-%d %H:%M:%f')、UTC）。
+// 文字列のまま比較するため、カーソルの作成日時も同じ書式で渡す。
+const timestampFormat = "2006-01-02 15:04:05.000"
+
+// UserRepository はSQLiteを使用したユーザーリポジトリの実装。
+type UserRepository struct {
+	queries *sqliteuser.Queries
+}
+
+// NewUserRepository は UserRepository を生成する。
+func NewUserRepository(queries *sqliteuser.Queries) *UserRepository {
+	return &UserRepository{queries: queries}
+}
+
+// Save はユーザーをDBに保存する。既に存在するIDの場合は名前とメールアドレスを更新する。
+// メールアドレスの一意制約違反の場合は domain.ErrConflict を返す。
+func (r *UserRepository) Save(ctx context.Context, u *user.User) error {
+	err := r.queries.UpsertUser(ctx, sqliteuser.UpsertUserParams{
+		ID:    u.ID().String(),
+		Name:  u.Name().String(),
+		Email: u.Email().String(),
+	})
+	if err != nil {
+		if isConstraintViolation(err) {
+			return domain.Conflict("user", "Save", err)
+		}
+		return err
+	}
+	return nil
+}
+
+// FindByID は指定されたIDのユーザーを取得する。
+// 見つからない場合は domain.ErrNotFound を返す。
+func (r *UserRepository) FindByID(ctx context.Context, id valueobject.UserID) (*user.User, error) {
+	row, err := r.queries.GetUser(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.NotFound("user", "FindByID")
+		}
+		return nil, err
+	}
+	return toEntity(&row)
+}
+
+// FindByIDs は指定されたIDのユーザーを1回のクエリでまとめて取得する。
+// 見つからないIDは結果に含めない。
+func (r *UserRepository) FindByIDs(ctx context.Context, ids []valueobject.UserID) ([]*user.User, error) {
+	strIDs := make([]string, len(ids))
+	for i, id := range ids {
+		strIDs[i] = id.String()
+	}
+	rows, err := r.queries.ListUsersByIDs(ctx, strIDs)
+	if err != nil {
+		return nil, err
+	}
+	return toEntities(rows)
+}
+
+// FindAll は全ユーザーを取得する。
+func (r *UserRepository) FindAll(ctx context.Context) ([]*user.User, error) {
+	rows, err := r.queries.ListUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toEntities(rows)
+}
+
+// FindPage は一覧の並び順（user.PageCursor）で after より後のユーザーを最大 limit 件取得する。
+func (r *UserRepository) FindPage(ctx context.Context, after *user.PageCursor, limit int) ([]*user.User, error) {
+	var rows []sqliteuser.User
+	var err error
+	if after == nil {
+		rows, err = r.queries.ListUsersFirstPage(ctx, int64(limit))
+	} else {
+		rows, err = r.queries.ListUsersPageAfter(ctx, sqliteuser.ListUsersPageAfterParams{
+			CreatedAt: after.CreatedAt.UTC().Format(timestampFormat),
+			ID:        after.ID.String(),
+			MaxRows:   int64(limit),
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toEntities(rows)
+}
+
+// Count はユーザーの件数を返す。
+func (r *UserRepository) Count(ctx context.Context) (int, error) {
+	n, err := r.queries.CountUsers(ctx)
+	return int(n), err
+}
+
+// Delete は指定されたIDのユーザーを削除する。
+func (r *UserRepository) Delete(ctx context.Context, id valueobject.UserID) error {
+	return r.queries.DeleteUser(ctx, id.String())
+}
+
+// isConstraintViolation は一意制約・主キー制約の違反かどうかを返す。
+func isConstraintViolation(err error) bool {
+	var sqliteErr *sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	switch sqliteErr.Code() {
+	case sqlite3lib.SQLITE_CONSTRAINT_UNIQUE, sqlite3lib.SQLITE_CONSTRAINT_PRIMARYKEY:
+		return true
+	default:
+		return false
+	}
+}
+
+// toEntity はsqlcの行データをドメインのUserエンティティに変換する。
+func toEntity(row *sqliteuser.User) (*user.User, error) {
+	id, err := valueobject.ParseUserID(row.ID)
+	if err != nil {
+		return nil, err
+	}
+	name, err := valueobject.NewUserName(row.Name)
+	if err != nil {
+		return nil, err
+	}
+	email, err := valueobject.NewEmail(row.Email)
+	if err != nil {
+		return nil, err
+	}
+	return user.Reconstruct(id, name, email, row.CreatedAt.UTC()), nil
+}
+
+// toEntities はsqlcの行データをドメインのUserエンティティのスライスに変換する。
+func toEntities(rows []sqliteuser.User) ([]*user.User, error) {
+	users := make([]*user.User, 0, len(rows))
+	for i := range rows {
+		u, err := toEntity(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	return users, nil
+}
@@ -0,0 +1,34 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/config"
+	"go-api/internal/domain/user"
+	"go-api/internal/infrastructure/database"
+	"go-api/internal/infrastructure/repository/sqlite"
+	"go-api/internal/sqlc/sqliteuser"
+	"go-api/internal/testutil/repositorytest"
+)
+
+// openTestDB はテストごとの一時ファイルにマイグレーション済みのデータベースを作成する。
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := database.OpenSQLite(context.Background(), config.SQLiteConfig{
+		Path: filepath.Join(t.TempDir(), "test.db"),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestUserRepository(t *testing.T) {
+	repositorytest.RunUserRepository(t, func(t *testing.T) user.UserRepository {
+		return sqlite.NewUserRepository(sqliteuser.New(openTestDB(t)))
+	})
+}
@@ -0,0 +1,47 @@
+// Package logging は構造化ログの共通処理を提供する。
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+// AttrExtractor はコンテキストからログ属性を取り出す関数。
+type AttrExtractor func(ctx context.Context) []slog.Attr
+
+// ContextHandler はコンテキストから取り出した属性を全てのログレコードに付与する slog.Handler。
+// logger.InfoContext(ctx, ...) のようにコンテキスト付きで出力したログが対象になる。
+type ContextHandler struct {
+	next       slog.Handler
+	extractors []AttrExtractor
+}
+
+// NewContextHandler は ContextHandler を生成する。
+func NewContextHandler(next slog.Handler, extractors ...AttrExtractor) *ContextHandler {
+	return &ContextHandler{next: next, extractors: extractors}
+}
+
+// Enabled は slog.Handler を実装する。
+func (h *ContextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle はコンテキスト由来の属性を追加してから次のハンドラーに渡す。
+func (h *ContextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx != nil {
+		for _, extract := range h.extractors {
+			r.AddAttrs(extract(ctx)...)
+		}
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs は slog.Handler を実装する。
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ContextHandler{next: h.next.WithAttrs(attrs), extractors: h.extractors}
+}
+
+// WithGroup は slog.Handler を実装する。
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return &ContextHandler{next: h.next.WithGroup(name), extractors: h.extractors}
+}
@@ -0,0 +1,49 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/logging"
+)
+
+type ctxKey struct{}
+
+func extractTestAttr(ctx context.Context) []slog.Attr {
+	if v, ok := ctx.Value(ctxKey{}).(string); ok {
+		return []slog.Attr{slog.String("request_id", v)}
+	}
+	return nil
+}
+
+func TestContextHandler(t *testing.T) {
+	t.Run("コンテキストの値をログ属性として付与する", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(logging.NewContextHandler(slog.NewJSONHandler(&buf, nil), extractTestAttr))
+
+		ctx := context.WithValue(context.Background(), ctxKey{}, "req-1")
+		logger.With("component", "test").InfoContext(ctx, "hello")
+
+		var record map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+		assert.Equal(t, "req-1", record["request_id"])
+		assert.Equal(t, "test", record["component"])
+	})
+
+	t.Run("値がない場合は属性を付与しない", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(logging.NewContextHandler(slog.NewJSONHandler(&buf, nil), extractTestAttr))
+
+		logger.Info("hello")
+
+		var record map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+		assert.NotContains(t, record, "request_id")
+	})
+}
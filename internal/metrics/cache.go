@@ -0,0 +1,51 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Cache はユーザーのキャッシュのメトリクス。cache.Metrics を実装する。
+type Cache struct {
+	lookups       *prometheus.CounterVec
+	evictions     prometheus.Counter
+	invalidations *prometheus.CounterVec
+}
+
+// NewCache は Cache を生成してレジストリに登録する。
+func NewCache(reg prometheus.Registerer) *Cache {
+	m := &Cache{
+		lookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "user_cache",
+			Name:      "lookups_total",
+			Help:      "Total number of user cache lookups by layer (local or shared) and result (hit, negative_hit or miss).",
+		}, []string{"layer", "result"}),
+		evictions: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "user_cache",
+			Name:      "evictions_total",
+			Help:      "Total number of users evicted from the local cache because it was full.",
+		}),
+		invalidations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "user_cache",
+			Name:      "invalidations_total",
+			Help:      "Total number of user cache invalidations by source (write or notify).",
+		}, []string{"source"}),
+	}
+	reg.MustRegister(m.lookups, m.evictions, m.invalidations)
+	return m
+}
+
+// Lookup は cache.Metrics を実装する。
+func (m *Cache) Lookup(layer, result string) {
+	m.lookups.WithLabelValues(layer, result).Inc()
+}
+
+// Evicted は cache.Metrics を実装する。
+func (m *Cache) Evicted(n int) {
+	m.evictions.Add(float64(n))
+}
+
+// Invalidated は cache.Metrics を実装する。
+func (m *Cache) Invalidated(source string) {
+	m.invalidations.WithLabelValues(source).Inc()
+}
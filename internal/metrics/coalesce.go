@@ -0,0 +1,31 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Coalesce はまとめた読み込みのメトリクス。coalesce.Metrics を実装する。
+type Coalesce struct {
+	calls *prometheus.CounterVec
+}
+
+// NewCoalesce は Coalesce を生成してレジストリに登録する。
+func NewCoalesce(reg prometheus.Registerer) *Coalesce {
+	m := &Coalesce{
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "user_coalesce",
+			Name:      "calls_total",
+			Help:      "Total number of user repository reads by method and result (executed or coalesced into an in-flight query).",
+		}, []string{"method", "result"}),
+	}
+	reg.MustRegister(m.calls)
+	return m
+}
+
+// Call は coalesce.Metrics を実装する。
+func (m *Coalesce) Call(method string, coalesced bool) {
+	result := "executed"
+	if coalesced {
+		result = "coalesced"
+	}
+	m.calls.WithLabelValues(method, result).Inc()
+}
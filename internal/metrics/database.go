@@ -0,0 +1,51 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// DatabaseRetry はデータベースの読み込みの再試行のメトリクス。database.RetryMetrics を実装する。
+type DatabaseRetry struct {
+	retries *prometheus.CounterVec
+}
+
+// NewDatabaseRetry は DatabaseRetry を生成してレジストリに登録する。
+func NewDatabaseRetry(reg prometheus.Registerer) *DatabaseRetry {
+	m := &DatabaseRetry{
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "db",
+			Name:      "read_retries_total",
+			Help:      "Total number of database reads retried after a transient error, by operation and reason.",
+		}, []string{"op", "reason"}),
+	}
+	reg.MustRegister(m.retries)
+	return m
+}
+
+// Retried は database.RetryMetrics を実装する。
+func (m *DatabaseRetry) Retried(op, reason string) {
+	m.retries.WithLabelValues(op, reason).Inc()
+}
+
+// DatabaseConnect は起動時のデータベースへの接続の再試行のメトリクス。database.ConnectMetrics を実装する。
+type DatabaseConnect struct {
+	retries prometheus.Counter
+}
+
+// NewDatabaseConnect は DatabaseConnect を生成してレジストリに登録する。
+func NewDatabaseConnect(reg prometheus.Registerer) *DatabaseConnect {
+	m := &DatabaseConnect{
+		retries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "db",
+			Name:      "connect_retries_total",
+			Help:      "Total number of database connection attempts retried at startup.",
+		}),
+	}
+	reg.MustRegister(m.retries)
+	return m
+}
+
+// ConnectRetried は database.ConnectMetrics を実装する。
+func (m *DatabaseConnect) ConnectRetried() {
+	m.retries.Inc()
+}
@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// HTTP はHTTPリクエストのメトリクス。
+type HTTP struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// NewHTTP は HTTP を生成してレジストリに登録する。
+func NewHTTP(reg prometheus.Registerer) *HTTP {
+	labels := []string{"method", "route", "status"}
+	m := &HTTP{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Total number of HTTP requests by route pattern and status.",
+		}, labels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by route pattern and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, labels),
+	}
+	reg.MustRegister(m.requests, m.duration)
+	return m
+}
+
+// ObserveRequest はリクエスト1件の結果を記録する。
+// method には既知のメソッドか "OTHER"、route にはパスではなくルートパターンを渡し、ラベルのカーディナリティを抑える。
+func (m *HTTP) ObserveRequest(method, route string, status int, d time.Duration) {
+	labels := prometheus.Labels{
+		"method": method,
+		"route":  route,
+		"status": strconv.Itoa(status),
+	}
+	m.requests.With(labels).Inc()
+	m.duration.With(labels).Observe(d.Seconds())
+}
@@ -0,0 +1,29 @@
+// Package metrics はPrometheus形式のメトリクスを提供する。
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace は全メトリクス名の接頭辞。
+const namespace = "goapi"
+
+// NewRegistry はGoランタイムとプロセスのメトリクスを登録済みのレジストリを生成する。
+// グローバルな DefaultRegisterer は使わず、テストごとに独立したレジストリを作れるようにしている。
+func NewRegistry() *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return reg
+}
+
+// Handler はレジストリの内容をPrometheusのテキスト形式で返すハンドラーを生成する。
+func Handler(reg *prometheus.Registry) http.Handler {
+	return promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg})
+}
@@ -0,0 +1,155 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/infrastructure/database"
+	"go-api/internal/metrics"
+)
+
+func TestUsecase(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := metrics.NewUsecase(reg, func(error) string { return "NOT_FOUND" })
+
+	_, end := m.Start(context.Background(), "GetUser")
+	end(nil)
+	_, end = m.Start(context.Background(), "GetUser")
+	end(errors.New("not found"))
+
+	expected := `
+# HELP goapi_usecase_executions_total Total number of use case executions by outcome (OK or error code).
+# TYPE goapi_usecase_executions_total counter
+goapi_usecase_executions_total{outcome="NOT_FOUND",usecase="GetUser"} 1
+goapi_usecase_executions_total{outcome="OK",usecase="GetUser"} 1
+`
+	err := testutil.GatherAndCompare(reg, strings.NewReader(expected), "goapi_usecase_executions_total")
+	require.NoError(t, err)
+}
+
+func TestHTTP(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := metrics.NewHTTP(reg)
+
+	m.ObserveRequest("GET", "GET /users/{id}", 200, 0)
+	m.ObserveRequest("OTHER", "unmatched", 405, 0)
+
+	expected := `
+# HELP goapi_http_requests_total Total number of HTTP requests by route pattern and status.
+# TYPE goapi_http_requests_total counter
+goapi_http_requests_total{method="GET",route="GET /users/{id}",status="200"} 1
+goapi_http_requests_total{method="OTHER",route="unmatched",status="405"} 1
+`
+	err := testutil.GatherAndCompare(reg, strings.NewReader(expected), "goapi_http_requests_total")
+	require.NoError(t, err)
+}
+
+func TestCache(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := metrics.NewCache(reg)
+
+	m.Lookup("local", "miss")
+	m.Lookup("shared", "hit")
+	m.Evicted(2)
+	m.Invalidated("notify")
+
+	expected := `
+# HELP goapi_user_cache_lookups_total Total number of user cache lookups by layer (local or shared) and result (hit, negative_hit or miss).
+# TYPE goapi_user_cache_lookups_total counter
+goapi_user_cache_lookups_total{layer="local",result="miss"} 1
+goapi_user_cache_lookups_total{layer="shared",result="hit"} 1
+# HELP goapi_user_cache_evictions_total Total number of users evicted from the local cache because it was full.
+# TYPE goapi_user_cache_evictions_total counter
+goapi_user_cache_evictions_total 2
+# HELP goapi_user_cache_invalidations_total Total number of user cache invalidations by source (write or notify).
+# TYPE goapi_user_cache_invalidations_total counter
+goapi_user_cache_invalidations_total{source="notify"} 1
+`
+	err := testutil.GatherAndCompare(reg, strings.NewReader(expected))
+	require.NoError(t, err)
+}
+
+func TestCoalesce(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := metrics.NewCoalesce(reg)
+
+	m.Call("FindByID", false)
+	m.Call("FindByID", true)
+	m.Call("FindByID", true)
+
+	expected := `
+# HELP goapi_user_coalesce_calls_total Total number of user repository reads by method and result (executed or coalesced into an in-flight query).
+# TYPE goapi_user_coalesce_calls_total counter
+goapi_user_coalesce_calls_total{method="FindByID",result="coalesced"} 2
+goapi_user_coalesce_calls_total{method="FindByID",result="executed"} 1
+`
+	err := testutil.GatherAndCompare(reg, strings.NewReader(expected), "goapi_user_coalesce_calls_total")
+	require.NoError(t, err)
+}
+
+func TestDatabaseRetry(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := metrics.NewDatabaseRetry(reg)
+
+	m.Retried("FindByID", "admin_shutdown")
+	m.Retried("FindByID", "admin_shutdown")
+	m.Retried("FindAll", "connection_reset")
+
+	expected := `
+# HELP goapi_db_read_retries_total Total number of database reads retried after a transient error, by operation and reason.
+# TYPE goapi_db_read_retries_total counter
+goapi_db_read_retries_total{op="FindAll",reason="connection_reset"} 1
+goapi_db_read_retries_total{op="FindByID",reason="admin_shutdown"} 2
+`
+	err := testutil.GatherAndCompare(reg, strings.NewReader(expected), "goapi_db_read_retries_total")
+	require.NoError(t, err)
+}
+
+func TestDatabaseConnect(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := metrics.NewDatabaseConnect(reg)
+
+	m.ConnectRetried()
+	m.ConnectRetried()
+
+	expected := `
+# HELP goapi_db_connect_retries_total Total number of database connection attempts retried at startup.
+# TYPE goapi_db_connect_retries_total counter
+goapi_db_connect_retries_total 2
+`
+	err := testutil.GatherAndCompare(reg, strings.NewReader(expected), "goapi_db_connect_retries_total")
+	require.NoError(t, err)
+}
+
+// replicaSource は固定の状態を返す ReplicaSource。
+type replicaSource []database.ReplicaStatus
+
+func (s replicaSource) Replicas() []database.ReplicaStatus { return s }
+
+func TestReplicaCollector(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(metrics.NewReplicaCollector(replicaSource{
+		{Name: "replica1:5432", Healthy: true, Lag: 200 * time.Millisecond},
+		{Name: "replica2:5432", Healthy: false, Lag: 30 * time.Second},
+	}))
+
+	expected := `
+# HELP goapi_db_replica_healthy Whether the replica receives reads (1) or is ejected for lag or errors (0).
+# TYPE goapi_db_replica_healthy gauge
+goapi_db_replica_healthy{replica="replica1:5432"} 1
+goapi_db_replica_healthy{replica="replica2:5432"} 0
+# HELP goapi_db_replica_lag_seconds Replication lag of the replica at the last check.
+# TYPE goapi_db_replica_lag_seconds gauge
+goapi_db_replica_lag_seconds{replica="replica1:5432"} 0.2
+goapi_db_replica_lag_seconds{replica="replica2:5432"} 30
+`
+	err := testutil.GatherAndCompare(reg, strings.NewReader(expected))
+	require.NoError(t, err)
+}
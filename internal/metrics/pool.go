@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// poolCollector は pgxpool の統計情報を収集時点の値で公開する。
+type poolCollector struct {
+	pool *pgxpool.Pool
+
+	acquiredConns    *prometheus.Desc
+	idleConns        *prometheus.Desc
+	totalConns       *prometheus.Desc
+	maxConns         *prometheus.Desc
+	acquireCount     *prometheus.Desc
+	acquireDuration  *prometheus.Desc
+	waitCount        *prometheus.Desc
+	waitDuration     *prometheus.Desc
+	canceledAcquires *prometheus.Desc
+}
+
+// NewPoolCollector は pgxpool の統計情報を公開する Collector を生成する。
+func NewPoolCollector(pool *pgxpool.Pool) prometheus.Collector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+	}
+	return &poolCollector{
+		pool:             pool,
+		acquiredConns:    desc("acquired_conns", "Number of currently acquired connections."),
+		idleConns:        desc("idle_conns", "Number of currently idle connections."),
+		totalConns:       desc("total_conns", "Total number of connections in the pool."),
+		maxConns:         desc("max_conns", "Maximum size of the pool."),
+		acquireCount:     desc("acquires_total", "Total number of successful connection acquires."),
+		acquireDuration:  desc("acquire_duration_seconds_total", "Total time spent acquiring connections."),
+		waitCount:        desc("acquire_waits_total", "Total number of acquires that waited for a connection."),
+		waitDuration:     desc("acquire_wait_duration_seconds_total", "Total time spent waiting for a connection."),
+		canceledAcquires: desc("canceled_acquires_total", "Total number of acquires canceled by context."),
+	}
+}
+
+// Describe は prometheus.Collector を実装する。
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquireCount
+	ch <- c.acquireDuration
+	ch <- c.waitCount
+	ch <- c.waitDuration
+	ch <- c.canceledAcquires
+}
+
+// Collect は prometheus.Collector を実装する。
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.pool.Stat()
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(s.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(s.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(s.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(s.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(s.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, s.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.waitCount, prometheus.CounterValue, float64(s.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.waitDuration, prometheus.CounterValue, s.EmptyAcquireWaitTime().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquires, prometheus.CounterValue, float64(s.CanceledAcquireCount()))
+}
@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go-api/internal/infrastructure/database"
+)
+
+// ReplicaSource はレプリカの状態を返す。database.Router が満たす。
+type ReplicaSource interface {
+	Replicas() []database.ReplicaStatus
+}
+
+// replicaCollector はレプリカの状態を収集時点の値で公開する。
+type replicaCollector struct {
+	source  ReplicaSource
+	healthy *prometheus.Desc
+	lag     *prometheus.Desc
+}
+
+// NewReplicaCollector はレプリカごとの振り分けの可否と遅延を公開する Collector を生成する。
+func NewReplicaCollector(source ReplicaSource) prometheus.Collector {
+	return &replicaCollector{
+		source: source,
+		healthy: prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_replica", "healthy"),
+			"Whether the replica receives reads (1) or is ejected for lag or errors (0).", []string{"replica"}, nil),
+		lag: prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_replica", "lag_seconds"),
+			"Replication lag of the replica at the last check.", []string{"replica"}, nil),
+	}
+}
+
+// Describe は prometheus.Collector を実装する。
+func (c *replicaCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.healthy
+	ch <- c.lag
+}
+
+// Collect は prometheus.Collector を実装する。
+func (c *replicaCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, r := range c.source.Replicas() {
+		healthy := 0.0
+		if r.Healthy {
+			healthy = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.healthy, prometheus.GaugeValue, healthy, r.Name)
+		ch <- prometheus.MustNewConstMetric(c.lag, prometheus.GaugeValue, r.Lag.Seconds(), r.Name)
+	}
+}
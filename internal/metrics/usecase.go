@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// outcomeOK は成功時の outcome ラベル値。
+const outcomeOK = "OK"
+
+// Usecase はユースケースの実行結果のメトリクス。application.Observer を実装する。
+type Usecase struct {
+	executions *prometheus.CounterVec
+	codeOf     func(error) string
+}
+
+// NewUsecase は Usecase を生成してレジストリに登録する。
+// codeOf はエラーを outcome ラベル値（エラーコード）に変換する関数。
+func NewUsecase(reg prometheus.Registerer, codeOf func(error) string) *Usecase {
+	m := &Usecase{
+		executions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "usecase",
+			Name:      "executions_total",
+			Help:      "Total number of use case executions by outcome (OK or error code).",
+		}, []string{"usecase", "outcome"}),
+		codeOf: codeOf,
+	}
+	reg.MustRegister(m.executions)
+	return m
+}
+
+// Start は application.Observer を実装する。
+func (m *Usecase) Start(ctx context.Context, usecase string) (context.Context, func(error)) {
+	return ctx, func(err error) {
+		outcome := outcomeOK
+		if err != nil {
+			outcome = m.codeOf(err)
+		}
+		m.executions.WithLabelValues(usecase, outcome).Inc()
+	}
+}
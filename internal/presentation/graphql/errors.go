@@ -0,0 +1,119 @@
+package graphqlapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+
+	"go-api/internal/domain"
+	"go-api/internal/domain/user/valueobject"
+	httperrors "go-api/internal/presentation/http/errors"
+	"go-api/internal/presentation/http/i18n"
+	"go-api/internal/presentation/http/requestctx"
+)
+
+// リゾルバー以外で発生するエラーのコード（extensions.code）。
+const (
+	CodeBadRequest       = "GRAPHQL_BAD_REQUEST"
+	CodeParseFailed      = "GRAPHQL_PARSE_FAILED"
+	CodeValidationFailed = "GRAPHQL_VALIDATION_FAILED"
+	CodeQueryTooDeep     = "QUERY_TOO_DEEP"
+	CodeQueryTooComplex  = "QUERY_TOO_COMPLEX"
+)
+
+// Error はリゾルバーが返すエラー。extensions.code にREST APIと同じエラーコードを設定する。
+type Error struct {
+	Message string
+	Code    string
+	Details []httperrors.FieldError // 値オブジェクトの検証エラーの項目ごとの詳細
+}
+
+// Error はerrorインターフェースを実装する。
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Extensions は gqlerrors.ExtendedError を実装する。
+func (e *Error) Extensions() map[string]interface{} {
+	ext := map[string]interface{}{"code": e.Code}
+	if len(e.Details) > 0 {
+		ext["details"] = e.Details
+	}
+	return ext
+}
+
+// resolverError はユースケースのエラーを利用者に返すエラーに変換する。
+// コードとメッセージはREST APIと同じ対応（httperrors の Registry）から決め、内部のエラーの文言は返さない。
+// 500系に相当するエラーは詳細をログに記録する。
+func (r *resolver) resolverError(ctx context.Context, err error) error {
+	m := httperrors.Lookup(err)
+	tr := translatorFrom(ctx)
+	gqlErr := &Error{Message: httperrors.Message(tr, err), Code: m.Code}
+
+	var ve valueobject.ValidationErrors
+	if errors.As(err, &ve) {
+		gqlErr.Code = "VALIDATION_ERROR"
+		gqlErr.Details = httperrors.ValidationDetails(tr, ve)
+	}
+
+	// どのエンティティの操作で失敗したかをアクセスログに残す
+	info := requestctx.ErrorInfo{Code: gqlErr.Code}
+	var de *domain.DomainError
+	if errors.As(err, &de) {
+		info.Entity, info.Op = de.Entity, de.Op
+	}
+	requestctx.SetErrorInfo(ctx, info)
+
+	if m.Status >= 500 && r.logger != nil {
+		attrs := []any{"error", err.Error()}
+		if de != nil {
+			attrs = append(attrs, "entity", de.Entity, "op", de.Op)
+		}
+		r.logger.ErrorContext(ctx, "internal error", attrs...)
+	}
+	return gqlErr
+}
+
+// asError は graphql-go がラップしたエラーを辿り、リゾルバーが返した Error を target に設定する。
+func asError(err error, target **Error) bool {
+	for err != nil {
+		switch e := err.(type) {
+		case *Error:
+			*target = e
+			return true
+		case gqlerrors.FormattedError:
+			err = e.OriginalError()
+		case *gqlerrors.Error:
+			err = e.OriginalError
+		default:
+			return errors.As(err, target)
+		}
+	}
+	return false
+}
+
+// newQueryError はリクエスト全体に対するエラーを生成する。
+func newQueryError(message, code string) gqlerrors.FormattedError {
+	return withCode(gqlerrors.NewFormattedError(message), code)
+}
+
+// withCode は err の extensions.code を code にする。
+func withCode(err gqlerrors.FormattedError, code string) gqlerrors.FormattedError {
+	err.Extensions = map[string]interface{}{"code": code}
+	return err
+}
+
+type translatorKey struct{}
+
+// withTranslator はメッセージの翻訳に使う Translator をコンテキストに格納する。
+func withTranslator(ctx context.Context, tr *i18n.Translator) context.Context {
+	return context.WithValue(ctx, translatorKey{}, tr)
+}
+
+func translatorFrom(ctx context.Context) *i18n.Translator {
+	if tr, ok := ctx.Value(translatorKey{}).(*i18n.Translator); ok {
+		return tr
+	}
+	return i18n.Get(i18n.DefaultLanguage)
+}
@@ -0,0 +1,189 @@
+// Package graphqlapi はユーザーのGraphQL APIを提供する。
+// HTTP API・gRPC API と同じユースケースを呼び出し、エラーも同じエラーコードで返す。
+package graphqlapi
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+
+	httperrors "go-api/internal/presentation/http/errors"
+	"go-api/internal/presentation/http/i18n"
+	"go-api/internal/presentation/http/request"
+	"go-api/internal/presentation/http/requestctx"
+)
+
+// Options は Handler の設定。
+type Options struct {
+	Limits   Limits
+	GraphiQL bool // ブラウザからの GET に GraphiQL を返すかどうか（開発環境向け）
+}
+
+// graphqlRequest はGraphQLのリクエスト（GraphQL over HTTP）。
+type graphqlRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+	Extensions    map[string]interface{} `json:"extensions"` // クライアントが付与する拡張。使用しない
+}
+
+// Handler はGraphQLのHTTPハンドラー。
+type Handler struct {
+	schema   graphql.Schema
+	resolver *resolver
+	opts     Options
+	logger   *slog.Logger
+}
+
+// NewHandler は Handler を生成する。スキーマの定義が誤っている場合は panic する。
+func NewHandler(uc Usecases, opts Options, logger *slog.Logger) *Handler {
+	r := &resolver{uc: uc, logger: logger}
+	schema, err := newSchema(r)
+	if err != nil {
+		panic("graphqlapi: invalid schema: " + err.Error())
+	}
+	return &Handler{
+		schema:   schema,
+		resolver: r,
+		opts:     opts,
+		logger:   logger,
+	}
+}
+
+// ServeHTTP はGraphQLのリクエストを実行する。
+// GET /graphql はクエリ文字列で問い合わせ（query のみ）、POST /graphql はJSONで問い合わせと変更を受け付ける。
+// 解析・検証・上限の検査に失敗した場合は 400、実行できた場合はリゾルバーのエラーを含めて 200 を返す。
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req graphqlRequest
+	if r.Method == http.MethodGet {
+		q := r.URL.Query()
+		if h.opts.GraphiQL && !q.Has("query") && acceptsHTML(r) {
+			serveGraphiQL(w)
+			return
+		}
+		req.Query = q.Get("query")
+		req.OperationName = q.Get("operationName")
+		if v := q.Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				h.writeRequestError(w, r, http.StatusBadRequest, newQueryError("variables must be a JSON object", CodeBadRequest))
+				return
+			}
+		}
+	} else if err := request.DecodeJSON(w, r, &req); err != nil {
+		httperrors.WriteError(w, r, err, h.logger)
+		return
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{Source: req.Query})
+	if err != nil {
+		h.writeRequestError(w, r, http.StatusBadRequest, withCode(gqlerrors.FormatError(err), CodeParseFailed))
+		return
+	}
+	// GET は安全なメソッドのため変更を受け付けない（キャッシュやプリフェッチで実行されないようにする）
+	if r.Method == http.MethodGet && hasMutation(doc, req.OperationName) {
+		w.Header().Set("Allow", http.MethodPost)
+		h.writeRequestError(w, r, http.StatusMethodNotAllowed, newQueryError("mutations must be sent with POST", CodeBadRequest))
+		return
+	}
+	if vr := graphql.ValidateDocument(&h.schema, doc, nil); !vr.IsValid {
+		errs := make([]gqlerrors.FormattedError, len(vr.Errors))
+		for i, e := range vr.Errors {
+			errs[i] = withCode(e, CodeValidationFailed)
+		}
+		h.writeRequestErrors(w, r, http.StatusBadRequest, errs)
+		return
+	}
+	if err := h.opts.Limits.check(doc, req.Variables); err != nil {
+		h.writeRequestError(w, r, http.StatusBadRequest, *err)
+		return
+	}
+
+	tr := i18n.Negotiate(r.Header.Get("Accept-Language"))
+	ctx := withTranslator(r.Context(), tr)
+	ctx = withLoader(ctx, newUserLoader(h.resolver.uc.GetMany))
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx,
+	})
+	result.Errors = h.formatErrors(r, result.Errors)
+
+	// 変数の型の不一致等で実行されなかった場合は、解析・検証のエラーと同じく 400 とする
+	if result.Data == nil && result.HasErrors() && len(result.Errors[0].Path) == 0 {
+		h.writeRequestErrors(w, r, http.StatusBadRequest, result.Errors)
+		return
+	}
+	writeResult(w, http.StatusOK, result)
+}
+
+// formatErrors は実行時のエラーに extensions.code を設定する。
+// graphql-go はサンクが返したエラーの extensions を引き継がないため、元のエラーから補う。
+// リゾルバーの Error 以外（パニック等）は内部の文言を返さず、ログに記録する。
+func (h *Handler) formatErrors(r *http.Request, errs []gqlerrors.FormattedError) []gqlerrors.FormattedError {
+	for i := range errs {
+		var gqlErr *Error
+		switch {
+		case asError(errs[i], &gqlErr):
+			errs[i].Extensions = gqlErr.Extensions()
+		case len(errs[i].Path) == 0:
+			// フィールドの解決前に発生したエラー（変数の検証等）は利用者の入力の誤り
+			errs[i] = withCode(errs[i], CodeValidationFailed)
+		default:
+			if h.logger != nil {
+				h.logger.ErrorContext(r.Context(), "internal error", "error", errs[i].Message, "path", errs[i].Path)
+			}
+			requestctx.SetErrorInfo(r.Context(), requestctx.ErrorInfo{Code: "INTERNAL_ERROR"})
+			errs[i].Message = httperrors.Message(translatorFrom(r.Context()), errs[i])
+			errs[i].Extensions = map[string]interface{}{"code": "INTERNAL_ERROR"}
+		}
+	}
+	return errs
+}
+
+// writeRequestError は実行前に発生したエラーを返す。
+func (h *Handler) writeRequestError(w http.ResponseWriter, r *http.Request, status int, err gqlerrors.FormattedError) {
+	h.writeRequestErrors(w, r, status, []gqlerrors.FormattedError{err})
+}
+
+// writeRequestErrors は実行前に発生したエラーを返し、最初のエラーのコードをアクセスログに残す。
+func (h *Handler) writeRequestErrors(w http.ResponseWriter, r *http.Request, status int, errs []gqlerrors.FormattedError) {
+	code, _ := errs[0].Extensions["code"].(string)
+	requestctx.SetErrorInfo(r.Context(), requestctx.ErrorInfo{Code: code})
+	writeResult(w, status, &graphql.Result{Errors: errs})
+}
+
+func writeResult(w http.ResponseWriter, status int, result *graphql.Result) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+// hasMutation は実行する操作が変更（mutation）かどうかを返す。
+func hasMutation(doc *ast.Document, operationName string) bool {
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if operationName != "" && (op.Name == nil || op.Name.Value != operationName) {
+			continue
+		}
+		if op.Operation == ast.OperationTypeMutation {
+			return true
+		}
+	}
+	return false
+}
+
+func acceptsHTML(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/html")
+}
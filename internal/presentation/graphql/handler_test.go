@@ -0,0 +1,267 @@
+package graphqlapi_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	usecase "go-api/internal/application/user"
+	"go-api/internal/domain"
+	domainuser "go-api/internal/domain/user"
+	"go-api/internal/domain/user/mocks"
+	"go-api/internal/domain/user/valueobject"
+	graphqlapi "go-api/internal/presentation/graphql"
+	"go-api/internal/testutil/factory"
+)
+
+type gqlResponse struct {
+	Data   map[string]any `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Path       []any          `json:"path"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+func newHandler(repo domainuser.UserRepository, opts graphqlapi.Options) *graphqlapi.Handler {
+	return graphqlapi.NewHandler(graphqlapi.Usecases{
+		GetMany: usecase.NewGetUsersUsecase(repo),
+		List:    usecase.NewListUsersUsecase(repo),
+		Create:  usecase.NewCreateUserUsecase(repo),
+		Update:  usecase.NewUpdateUserUsecase(repo),
+		Delete:  usecase.NewDeleteUserUsecase(repo),
+	}, opts, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+// newUser は作成日時を指定してユーザーを生成する。
+func newUser(t *testing.T, name string, createdAt time.Time) *domainuser.User {
+	t.Helper()
+	u := factory.NewUser(factory.WithName(name))
+	return domainuser.Reconstruct(u.ID(), u.Name(), u.Email(), createdAt)
+}
+
+func post(t *testing.T, h http.Handler, query string, variables map[string]any) (*httptest.ResponseRecorder, gqlResponse) {
+	t.Helper()
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	h.ServeHTTP(rec, req)
+
+	var resp gqlResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	return rec, resp
+}
+
+func TestHandler_Query(t *testing.T) {
+	t.Run("同じ階層のユーザーの取得を1回の問い合わせにまとめる", func(t *testing.T) {
+		a := factory.NewUser(factory.WithName("a"))
+		b := factory.NewUser(factory.WithName("b"))
+		missing := factory.NewUser()
+
+		repo := mocks.NewMockUserRepository(t)
+		repo.EXPECT().FindByIDs(mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, ids []valueobject.UserID) ([]*domainuser.User, error) {
+				assert.ElementsMatch(t, []valueobject.UserID{a.ID(), b.ID(), missing.ID()}, ids)
+				return []*domainuser.User{b, a}, nil
+			}).Once()
+		h := newHandler(repo, graphqlapi.Options{})
+
+		rec, resp := post(t, h, `query($ids: [ID!]!) {
+			first: user(id: "`+a.ID().String()+`") { name }
+			second: user(id: "`+b.ID().String()+`") { name }
+			many: usersByIds(ids: $ids) { name }
+		}`, map[string]any{"ids": []string{b.ID().String(), missing.ID().String(), a.ID().String()}})
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, resp.Errors)
+		assert.Equal(t, map[string]any{"name": "a"}, resp.Data["first"])
+		assert.Equal(t, map[string]any{"name": "b"}, resp.Data["second"])
+		assert.Equal(t, []any{map[string]any{"name": "b"}, nil, map[string]any{"name": "a"}}, resp.Data["many"])
+	})
+
+	t.Run("ユーザー一覧をカーソルでページングできる", func(t *testing.T) {
+		createdAt := time.Date(2025, 1, 2, 3, 4, 5, 123456000, time.UTC)
+		a := newUser(t, "a", createdAt)
+		b := newUser(t, "b", createdAt.Add(-time.Second))
+		c := newUser(t, "c", createdAt.Add(-2*time.Second))
+		repo := mocks.NewMockUserRepository(t)
+		repo.EXPECT().Count(mock.Anything).Return(3, nil)
+		repo.EXPECT().FindPage(mock.Anything, (*domainuser.PageCursor)(nil), 3).Return([]*domainuser.User{a, b, c}, nil).Once()
+		repo.EXPECT().FindPage(mock.Anything, mock.Anything, 3).
+			RunAndReturn(func(_ context.Context, after *domainuser.PageCursor, _ int) ([]*domainuser.User, error) {
+				// カーソルはIDだけでなく作成日時も復元する
+				require.NotNil(t, after)
+				assert.Equal(t, b.ID(), after.ID)
+				assert.True(t, b.CreatedAt().Equal(after.CreatedAt))
+				return []*domainuser.User{c}, nil
+			}).Once()
+		h := newHandler(repo, graphqlapi.Options{})
+		query := `query($after: String) {
+			users(first: 2, after: $after) { nodes { name } totalCount pageInfo { hasNextPage hasPreviousPage endCursor } }
+		}`
+
+		_, resp := post(t, h, query, nil)
+		require.Empty(t, resp.Errors)
+		conn := resp.Data["users"].(map[string]any)
+		assert.Equal(t, []any{map[string]any{"name": "a"}, map[string]any{"name": "b"}}, conn["nodes"])
+		assert.Equal(t, float64(3), conn["totalCount"])
+		pageInfo := conn["pageInfo"].(map[string]any)
+		assert.Equal(t, true, pageInfo["hasNextPage"])
+		assert.Equal(t, false, pageInfo["hasPreviousPage"])
+
+		_, resp = post(t, h, query, map[string]any{"after": pageInfo["endCursor"]})
+		require.Empty(t, resp.Errors)
+		conn = resp.Data["users"].(map[string]any)
+		assert.Equal(t, []any{map[string]any{"name": "c"}}, conn["nodes"])
+		assert.Equal(t, false, conn["pageInfo"].(map[string]any)["hasNextPage"])
+		assert.Equal(t, true, conn["pageInfo"].(map[string]any)["hasPreviousPage"])
+	})
+
+	t.Run("不正なカーソルはリポジトリを呼ばずにVALIDATION_ERROR", func(t *testing.T) {
+		repo := mocks.NewMockUserRepository(t)
+		h := newHandler(repo, graphqlapi.Options{})
+
+		_, resp := post(t, h, `{ users(after: "invalid") { totalCount } }`, nil)
+
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "VALIDATION_ERROR", resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("リポジトリのエラーは内部の文言を返さずINTERNAL_ERROR", func(t *testing.T) {
+		u := factory.NewUser()
+		repo := mocks.NewMockUserRepository(t)
+		repo.EXPECT().FindByIDs(mock.Anything, mock.Anything).Return(nil, assert.AnError)
+		h := newHandler(repo, graphqlapi.Options{})
+
+		_, resp := post(t, h, `{ user(id: "`+u.ID().String()+`") { name } }`, nil)
+
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "internal server error", resp.Errors[0].Message)
+		assert.Equal(t, "INTERNAL_ERROR", resp.Errors[0].Extensions["code"])
+		assert.Equal(t, []any{"user"}, resp.Errors[0].Path)
+	})
+}
+
+func TestHandler_Mutation(t *testing.T) {
+	t.Run("ユーザーを作成できる", func(t *testing.T) {
+		repo := mocks.NewMockUserRepository(t)
+		repo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+		h := newHandler(repo, graphqlapi.Options{})
+
+		_, resp := post(t, h, `mutation {
+			createUser(input: {name: "test", email: "test@example.com"}) { user { id name email } }
+		}`, nil)
+
+		assert.Empty(t, resp.Errors)
+		u := resp.Data["createUser"].(map[string]any)["user"].(map[string]any)
+		assert.NotEmpty(t, u["id"])
+		assert.Equal(t, "test", u["name"])
+		assert.Equal(t, "test@example.com", u["email"])
+	})
+
+	t.Run("入力が不正な場合は項目ごとの詳細を返す", func(t *testing.T) {
+		repo := mocks.NewMockUserRepository(t)
+		h := newHandler(repo, graphqlapi.Options{})
+
+		rec, resp := post(t, h, `mutation { createUser(input: {name: "", email: "invalid"}) { user { id } } }`, nil)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "VALIDATION_ERROR", resp.Errors[0].Extensions["code"])
+		details := resp.Errors[0].Extensions["details"].([]any)
+		require.Len(t, details, 2)
+		assert.Equal(t, "name", details[0].(map[string]any)["field"])
+		assert.Equal(t, "email", details[1].(map[string]any)["field"])
+	})
+
+	t.Run("存在しないユーザーの削除はNOT_FOUND", func(t *testing.T) {
+		u := factory.NewUser()
+		repo := mocks.NewMockUserRepository(t)
+		repo.EXPECT().FindByID(mock.Anything, u.ID()).Return(nil, domain.NotFound("user", "FindByID"))
+		h := newHandler(repo, graphqlapi.Options{})
+
+		_, resp := post(t, h, `mutation { deleteUser(id: "`+u.ID().String()+`") { deletedId } }`, nil)
+
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "NOT_FOUND", resp.Errors[0].Extensions["code"])
+		assert.Equal(t, "user not found", resp.Errors[0].Message)
+	})
+}
+
+func TestHandler_Request(t *testing.T) {
+	repo := mocks.NewMockUserRepository(t)
+	h := newHandler(repo, graphqlapi.Options{
+		Limits:   graphqlapi.Limits{MaxDepth: 3, MaxComplexity: 50},
+		GraphiQL: true,
+	})
+
+	t.Run("構文エラーは400とGRAPHQL_PARSE_FAILED", func(t *testing.T) {
+		rec, resp := post(t, h, `{ user(`, nil)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, graphqlapi.CodeParseFailed, resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("スキーマにないフィールドは400とGRAPHQL_VALIDATION_FAILED", func(t *testing.T) {
+		rec, resp := post(t, h, `{ users { unknown } }`, nil)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		require.NotEmpty(t, resp.Errors)
+		assert.Equal(t, graphqlapi.CodeValidationFailed, resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("深さの上限を超えるクエリは実行しない", func(t *testing.T) {
+		rec, resp := post(t, h, `{ users { edges { node { name } } } }`, nil)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, graphqlapi.CodeQueryTooDeep, resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("一覧の件数で重み付けした複雑度の上限を超えるクエリは実行しない", func(t *testing.T) {
+		// users(first: 100) の子の3フィールドは100倍して数える
+		rec, resp := post(t, h, `fragment f on User { id name email } { users(first: 100) { nodes { ...f } } }`, nil)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, graphqlapi.CodeQueryTooComplex, resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("GETでの変更は405", func(t *testing.T) {
+		q := url.Values{"query": {`mutation { deleteUser(id: "x") { deletedId } }`}}
+		req := httptest.NewRequest(http.MethodGet, "/graphql?"+q.Encode(), http.NoBody)
+		rec := httptest.NewRecorder()
+
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, http.MethodPost, rec.Header().Get("Allow"))
+	})
+
+	t.Run("ブラウザからのGETにはGraphiQLを返す", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/graphql", http.NoBody)
+		req.Header.Set("Accept", "text/html,application/xhtml+xml")
+		rec := httptest.NewRecorder()
+
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Body.String(), "GraphiQL")
+	})
+}
@@ -0,0 +1,127 @@
+package graphqlapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Limits はクエリの深さと複雑度の上限。0 の場合はその上限を設けない。
+type Limits struct {
+	MaxDepth      int // フィールドの入れ子の深さの上限
+	MaxComplexity int // フィールド数を一覧の件数で重み付けした複雑度の上限
+}
+
+// check は文書内の全ての操作が上限を超えていないかを検査する。超えている場合はエラーを返す。
+// 複雑度は各フィールドを1とし、一覧を返すフィールド（first・ids 引数を持つもの）の子は件数倍して数える。
+// イントロスペクション（__schema 等）は GraphiQL が深いクエリを送るため対象外とする。
+func (l Limits) check(doc *ast.Document, variables map[string]interface{}) *gqlerrors.FormattedError {
+	w := limitsWalker{
+		fragments: make(map[string]*ast.FragmentDefinition),
+		variables: variables,
+	}
+	for _, def := range doc.Definitions {
+		if fd, ok := def.(*ast.FragmentDefinition); ok {
+			w.fragments[fd.Name.Value] = fd
+		}
+	}
+
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		depth, complexity := w.walk(op.SelectionSet)
+		if l.MaxDepth > 0 && depth > l.MaxDepth {
+			err := newQueryError(fmt.Sprintf("query depth %d exceeds the limit of %d", depth, l.MaxDepth), CodeQueryTooDeep)
+			return &err
+		}
+		if l.MaxComplexity > 0 && complexity > l.MaxComplexity {
+			err := newQueryError(fmt.Sprintf("query complexity %d exceeds the limit of %d", complexity, l.MaxComplexity), CodeQueryTooComplex)
+			return &err
+		}
+	}
+	return nil
+}
+
+type limitsWalker struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+}
+
+// walk は選択セットの深さと複雑度を返す。フラグメントは展開して数える（循環は検証で除外済み）。
+func (w limitsWalker) walk(set *ast.SelectionSet) (depth, complexity int) {
+	if set == nil {
+		return 0, 0
+	}
+	for _, sel := range set.Selections {
+		var d, c int
+		switch s := sel.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name.Value, "__") {
+				continue
+			}
+			childDepth, childComplexity := w.walk(s.SelectionSet)
+			d, c = childDepth+1, 1+w.listSize(s)*childComplexity
+		case *ast.InlineFragment:
+			d, c = w.walk(s.SelectionSet)
+		case *ast.FragmentSpread:
+			if fd, ok := w.fragments[s.Name.Value]; ok {
+				d, c = w.walk(fd.SelectionSet)
+			}
+		}
+		depth = max(depth, d)
+		complexity += c
+	}
+	return depth, complexity
+}
+
+// listSize はフィールドが返す一覧の件数の見積もりを返す。一覧でない場合は 1。
+func (w limitsWalker) listSize(f *ast.Field) int {
+	for _, arg := range f.Arguments {
+		switch arg.Name.Value {
+		case "first":
+			if n, ok := w.intValue(arg.Value); ok {
+				return clampPageSize(n)
+			}
+			return DefaultPageSize
+		case "ids":
+			return max(w.listLen(arg.Value), 1)
+		}
+	}
+	if f.Name.Value == "users" {
+		return DefaultPageSize
+	}
+	return 1
+}
+
+func (w limitsWalker) intValue(v ast.Value) (int, bool) {
+	switch v := v.(type) {
+	case *ast.IntValue:
+		n, err := strconv.Atoi(v.Value)
+		return n, err == nil
+	case *ast.Variable:
+		switch n := w.variables[v.Name.Value].(type) {
+		case float64: // JSONの数値
+			return int(n), true
+		case int:
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+func (w limitsWalker) listLen(v ast.Value) int {
+	switch v := v.(type) {
+	case *ast.ListValue:
+		return len(v.Values)
+	case *ast.Variable:
+		if list, ok := w.variables[v.Name.Value].([]interface{}); ok {
+			return len(list)
+		}
+	}
+	return 1
+}
@@ -0,0 +1,99 @@
+package graphqlapi
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"go-api/internal/application/user"
+	"go-api/internal/domain/user/valueobject"
+)
+
+// userLoader は1リクエスト内のユーザーの個別取得をまとめて GetUsersUsecase の1回の呼び出しにする（DataLoader方式）。
+// load は取得を予約してサンクを返し、最初にサンクが評価された時点で予約済みのIDをまとめて取得する。
+// graphql-go はフィールドの解決後にサンクを評価するため、同じ階層の取得が1回にまとまる。
+type userLoader struct {
+	uc *user.GetUsersUsecase
+
+	mu      sync.Mutex
+	pending []valueobject.UserID
+	results map[valueobject.UserID]*loadResult
+}
+
+type loadResult struct {
+	user *user.UserDTO // 見つからない場合は nil
+	err  error
+}
+
+func newUserLoader(uc *user.GetUsersUsecase) *userLoader {
+	return &userLoader{uc: uc, results: make(map[valueobject.UserID]*loadResult)}
+}
+
+// load は id のユーザーの取得を予約し、結果を返すサンクを返す。
+func (l *userLoader) load(ctx context.Context, id valueobject.UserID) func() (*user.UserDTO, error) {
+	l.mu.Lock()
+	if _, ok := l.results[id]; !ok {
+		l.results[id] = nil
+		l.pending = append(l.pending, id)
+	}
+	l.mu.Unlock()
+
+	return func() (*user.UserDTO, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if l.results[id] == nil {
+			l.dispatch(ctx)
+		}
+		res := l.results[id]
+		return res.user, res.err
+	}
+}
+
+// prime は取得済みのユーザーを結果として登録し、以降の load で再取得しないようにする。
+func (l *userLoader) prime(users []user.UserDTO) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i := range users {
+		id, err := valueobject.ParseUserID(users[i].ID)
+		if err != nil {
+			continue
+		}
+		if l.results[id] == nil {
+			l.results[id] = &loadResult{user: &users[i]}
+		}
+	}
+}
+
+// dispatch は予約済みのIDをまとめて取得する。l.mu を保持して呼び出す。
+func (l *userLoader) dispatch(ctx context.Context) {
+	batch := l.pending
+	l.pending = nil
+
+	output, err := l.uc.Execute(ctx, batch)
+	found := make(map[string]*user.UserDTO)
+	if err == nil {
+		for i := range output.Users {
+			found[strings.ToLower(output.Users[i].ID)] = &output.Users[i]
+		}
+	}
+	for _, id := range batch {
+		if l.results[id] != nil {
+			continue // 予約後に prime されたもの
+		}
+		l.results[id] = &loadResult{user: found[strings.ToLower(id.String())], err: err}
+	}
+}
+
+type loaderKey struct{}
+
+// withLoader はリクエスト単位のローダーをコンテキストに格納する。
+// 取得結果をリクエストをまたいで使い回さないよう、リクエストごとに生成する。
+func withLoader(ctx context.Context, l *userLoader) context.Context {
+	return context.WithValue(ctx, loaderKey{}, l)
+}
+
+func loaderFrom(ctx context.Context) *userLoader {
+	return ctx.Value(loaderKey{}).(*userLoader)
+}
@@ -0,0 +1,248 @@
+package graphqlapi
+
+import (
+	"context"
+	"encoding/base64"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+
+	"go-api/internal/application/user"
+	"go-api/internal/domain"
+	domainuser "go-api/internal/domain/user"
+	"go-api/internal/domain/user/valueobject"
+)
+
+// ページングの件数。
+const (
+	DefaultPageSize = 20  // first を省略した場合の件数
+	MaxPageSize     = 100 // first の上限。超えた場合はこの件数に切り詰める
+)
+
+// cursorPrefix はカーソルに含める種別。カーソルは利用者にとって不透明な値として扱わせる。
+const cursorPrefix = "user:"
+
+// Usecases はリゾルバーが呼び出すユースケース。
+type Usecases struct {
+	GetMany *user.GetUsersUsecase
+	List    *user.ListUsersUsecase
+	Create  *user.CreateUserUsecase
+	Update  *user.UpdateUserUsecase
+	Delete  *user.DeleteUserUsecase
+}
+
+type resolver struct {
+	uc     Usecases
+	logger *slog.Logger
+}
+
+type userConnection struct {
+	Edges      []userEdge
+	Nodes      []user.UserDTO
+	PageInfo   pageInfo
+	TotalCount int
+}
+
+type userEdge struct {
+	Cursor string
+	Node   user.UserDTO
+}
+
+type pageInfo struct {
+	HasNextPage     bool
+	HasPreviousPage bool
+	StartCursor     *string
+	EndCursor       *string
+}
+
+type userPayload struct {
+	User user.UserDTO
+}
+
+type deleteUserPayload struct {
+	DeletedID string
+}
+
+// user はIDでユーザーを取得する。取得はローダーでまとめ、存在しない場合は null を返す。
+func (r *resolver) user(p graphql.ResolveParams) (interface{}, error) {
+	id, err := valueobject.ParseUserID(p.Args["id"].(string))
+	if err != nil {
+		return nil, r.resolverError(p.Context, err)
+	}
+	return r.load(p.Context, id), nil
+}
+
+// usersByIDs は複数のIDでユーザーを取得する。全てのIDを1回の取得にまとめる。
+func (r *resolver) usersByIDs(p graphql.ResolveParams) (interface{}, error) {
+	args := p.Args["ids"].([]interface{})
+	ids := make([]valueobject.UserID, len(args))
+	var errs valueobject.ValidationErrors
+	for i, arg := range args {
+		id, err := valueobject.ParseUserID(arg.(string))
+		errs.Add("ids", err)
+		ids[i] = id
+	}
+	if err := errs.Err(); err != nil {
+		return nil, r.resolverError(p.Context, err)
+	}
+
+	users := make([]interface{}, len(ids))
+	for i, id := range ids {
+		users[i] = r.load(p.Context, id)
+	}
+	return users, nil
+}
+
+// load はローダーでユーザーの取得を予約し、graphql-go が後から評価するサンクを返す。
+func (r *resolver) load(ctx context.Context, id valueobject.UserID) func() (interface{}, error) {
+	thunk := loaderFrom(ctx).load(ctx, id)
+	return func() (interface{}, error) {
+		u, err := thunk()
+		if err != nil {
+			return nil, r.resolverError(ctx, err)
+		}
+		if u == nil {
+			return nil, nil // 型付きの nil を返すと null にならない
+		}
+		return u, nil
+	}
+}
+
+// users はユーザー一覧をカーソルでページングして返す。
+// 一覧全体は読み込まず、カーソルの位置（作成日時とID）より後を first 件だけ取得する。
+func (r *resolver) users(p graphql.ResolveParams) (interface{}, error) {
+	const op = "ListUsers"
+	first := DefaultPageSize
+	if v, ok := p.Args["first"].(int); ok {
+		if v < 0 {
+			return nil, r.resolverError(p.Context, invalidArgument(op, "first must not be negative"))
+		}
+		first = clampPageSize(v)
+	}
+	var after *domainuser.PageCursor
+	if v, ok := p.Args["after"].(string); ok {
+		cursor, ok := decodeCursor(v)
+		if !ok {
+			return nil, r.resolverError(p.Context, invalidArgument(op, "invalid cursor"))
+		}
+		after = &cursor
+	}
+
+	output, err := r.uc.List.ExecutePage(p.Context, user.ListUsersPageInput{After: after, First: first})
+	if err != nil {
+		return nil, r.resolverError(p.Context, err)
+	}
+
+	conn := userConnection{
+		Edges:      make([]userEdge, len(output.Edges)),
+		Nodes:      make([]user.UserDTO, len(output.Edges)),
+		TotalCount: output.TotalCount,
+		PageInfo: pageInfo{
+			HasNextPage:     output.HasNextPage,
+			HasPreviousPage: after != nil,
+		},
+	}
+	for i, e := range output.Edges {
+		conn.Edges[i] = userEdge{Cursor: encodeCursor(e.Cursor), Node: e.User}
+		conn.Nodes[i] = e.User
+	}
+	// 一覧で取得したユーザーは同じリクエスト内の user クエリで再取得しない
+	loaderFrom(p.Context).prime(conn.Nodes)
+	if len(conn.Edges) > 0 {
+		conn.PageInfo.StartCursor = &conn.Edges[0].Cursor
+		conn.PageInfo.EndCursor = &conn.Edges[len(conn.Edges)-1].Cursor
+	}
+	return conn, nil
+}
+
+// createUser はユーザーを作成する。
+func (r *resolver) createUser(p graphql.ResolveParams) (interface{}, error) {
+	name, email, err := userInput(p.Args["input"])
+	if err != nil {
+		return nil, r.resolverError(p.Context, err)
+	}
+
+	output, err := r.uc.Create.Execute(p.Context, user.CreateUserInput{Name: name, Email: email})
+	if err != nil {
+		return nil, r.resolverError(p.Context, err)
+	}
+	return userPayload{User: output.User}, nil
+}
+
+// updateUser はユーザーを更新する。
+func (r *resolver) updateUser(p graphql.ResolveParams) (interface{}, error) {
+	name, email, err := userInput(p.Args["input"])
+	if err != nil {
+		return nil, r.resolverError(p.Context, err)
+	}
+
+	output, err := r.uc.Update.Execute(p.Context, p.Args["id"].(string), user.UpdateUserInput{Name: name, Email: email})
+	if err != nil {
+		return nil, r.resolverError(p.Context, err)
+	}
+	return userPayload{User: output.User}, nil
+}
+
+// deleteUser はユーザーを削除する。
+func (r *resolver) deleteUser(p graphql.ResolveParams) (interface{}, error) {
+	id := p.Args["id"].(string)
+	if err := r.uc.Delete.Execute(p.Context, id); err != nil {
+		return nil, r.resolverError(p.Context, err)
+	}
+	return deleteUserPayload{DeletedID: id}, nil
+}
+
+// userInput は UserInput から値オブジェクトを生成して入力を検証する。失敗した項目は全て valueobject.ValidationErrors で返す。
+func userInput(arg interface{}) (valueobject.UserName, valueobject.Email, error) {
+	input, _ := arg.(map[string]interface{})
+	rawName, _ := input["name"].(string)
+	rawEmail, _ := input["email"].(string)
+
+	var errs valueobject.ValidationErrors
+	name, err := valueobject.NewUserName(rawName)
+	errs.Add("name", err)
+	email, err := valueobject.NewEmail(rawEmail)
+	errs.Add("email", err)
+	return name, email, errs.Err()
+}
+
+// invalidArgument は op の引数が不正であることを表すエラーを返す。
+func invalidArgument(op, message string) error {
+	return &domain.DomainError{Kind: domain.ErrInvalidInput, Entity: "user", Op: op, Message: message}
+}
+
+func clampPageSize(n int) int {
+	return min(max(n, 0), MaxPageSize)
+}
+
+// encodeCursor は一覧での位置をカーソルにする。削除されたユーザーのカーソルでも続きを辿れるよう、IDだけでなく作成日時も含める。
+func encodeCursor(c domainuser.PageCursor) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + c.CreatedAt.UTC().Format(time.RFC3339Nano) + "," + c.ID.String()))
+}
+
+// decodeCursor はカーソルが指す一覧での位置を返す。カーソルが不正な場合は false。
+func decodeCursor(cursor string) (domainuser.PageCursor, bool) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return domainuser.PageCursor{}, false
+	}
+	rest, ok := strings.CutPrefix(string(b), cursorPrefix)
+	if !ok {
+		return domainuser.PageCursor{}, false
+	}
+	rawCreatedAt, rawID, ok := strings.Cut(rest, ",")
+	if !ok {
+		return domainuser.PageCursor{}, false
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, rawCreatedAt)
+	if err != nil {
+		return domainuser.PageCursor{}, false
+	}
+	id, err := valueobject.ParseUserID(rawID)
+	if err != nil {
+		return domainuser.PageCursor{}, false
+	}
+	return domainuser.PageCursor{CreatedAt: createdAt, ID: id}, true
+}
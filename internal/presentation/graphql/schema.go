@@ -0,0 +1,132 @@
+package graphqlapi
+
+import (
+	"github.com/graphql-go/graphql"
+)
+
+// newSchema はユーザーのスキーマを生成する。スキーマは固定のため、生成に失敗するのは定義の誤りのみ。
+func newSchema(r *resolver) (graphql.Schema, error) {
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "User",
+		Description: "ユーザー",
+		Fields: graphql.Fields{
+			"id":    &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"email": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+
+	pageInfoType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "PageInfo",
+		Description: "カーソルによるページングの情報",
+		Fields: graphql.Fields{
+			"hasNextPage":     &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"hasPreviousPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"startCursor":     &graphql.Field{Type: graphql.String},
+			"endCursor":       &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	userEdgeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "UserEdge",
+		Fields: graphql.Fields{
+			"cursor": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"node":   &graphql.Field{Type: graphql.NewNonNull(userType)},
+		},
+	})
+
+	userConnectionType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "UserConnection",
+		Description: "ユーザー一覧のページ",
+		Fields: graphql.Fields{
+			"edges":      &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(userEdgeType)))},
+			"nodes":      &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(userType)))},
+			"pageInfo":   &graphql.Field{Type: graphql.NewNonNull(pageInfoType)},
+			"totalCount": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+
+	userInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "UserInput",
+		Description: "ユーザーの作成・更新の入力",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"name":  &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"email": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+
+	userPayloadType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "UserPayload",
+		Fields: graphql.Fields{
+			"user": &graphql.Field{Type: graphql.NewNonNull(userType)},
+		},
+	})
+
+	deleteUserPayloadType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "DeleteUserPayload",
+		Fields: graphql.Fields{
+			"deletedId": &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"user": &graphql.Field{
+				Type:        userType,
+				Description: "IDでユーザーを取得する。存在しない場合は null。",
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: r.user,
+			},
+			"usersByIds": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(userType)),
+				Description: "複数のIDでユーザーを取得する。結果は ids と同じ順で、存在しないIDは null。",
+				Args: graphql.FieldConfigArgument{
+					"ids": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.ID)))},
+				},
+				Resolve: r.usersByIDs,
+			},
+			"users": &graphql.Field{
+				Type:        graphql.NewNonNull(userConnectionType),
+				Description: "ユーザー一覧を作成日時の新しい順に取得する。",
+				Args: graphql.FieldConfigArgument{
+					"first": &graphql.ArgumentConfig{Type: graphql.Int, Description: "取得件数（既定 20・最大 100）"},
+					"after": &graphql.ArgumentConfig{Type: graphql.String, Description: "このカーソルより後から取得する"},
+				},
+				Resolve: r.users,
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createUser": &graphql.Field{
+				Type: graphql.NewNonNull(userPayloadType),
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(userInputType)},
+				},
+				Resolve: r.createUser,
+			},
+			"updateUser": &graphql.Field{
+				Type: graphql.NewNonNull(userPayloadType),
+				Args: graphql.FieldConfigArgument{
+					"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(userInputType)},
+				},
+				Resolve: r.updateUser,
+			},
+			"deleteUser": &graphql.Field{
+				Type: graphql.NewNonNull(deleteUserPayloadType),
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: r.deleteUser,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+}
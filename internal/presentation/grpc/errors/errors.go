@@ -0,0 +1,113 @@
+// Package grpcerrors はgRPC APIのエラー応答（ステータス）の生成を提供する。
+package grpcerrors
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+
+	"go-api/internal/domain"
+	"go-api/internal/domain/user/valueobject"
+	httperrors "go-api/internal/presentation/http/errors"
+	"go-api/internal/presentation/http/i18n"
+	"go-api/internal/presentation/http/requestctx"
+)
+
+// ErrorDomain は errdetails.ErrorInfo の Domain に設定する値。
+const ErrorDomain = "go-api"
+
+// Code はHTTPステータスコードに対応するgRPCのステータスコードを返す。
+func Code(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	default:
+		return codes.Internal
+	}
+}
+
+// Status はエラーをgRPCのステータスエラーに変換する。
+// コードとメッセージはHTTP APIと同じ対応（httperrors の Registry）から決め、メッセージは
+// メタデータの accept-language から選んだ言語で返す。エラーコード（NOT_FOUND 等）は errdetails.ErrorInfo の Reason に、
+// 値オブジェクトの検証エラーは項目ごとに errdetails.BadRequest に付与する。
+// Internal の場合は詳細をログに記録し、呼び出し元には隠蔽する。
+func Status(ctx context.Context, err error, logger *slog.Logger) error {
+	m := httperrors.Lookup(err)
+	httpStatus, code := m.Status, m.Code
+	tr := i18n.Negotiate(firstMetadata(ctx, "accept-language"))
+	message := httperrors.Message(tr, err)
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	var ve valueobject.ValidationErrors
+	if errors.As(err, &ve) {
+		httpStatus, code = http.StatusBadRequest, "VALIDATION_ERROR"
+		for _, d := range httperrors.ValidationDetails(tr, ve) {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       d.Field,
+				Description: d.Message,
+				Reason:      d.Code,
+			})
+		}
+	}
+	grpcCode := Code(httpStatus)
+
+	// どのエンティティの操作で失敗したかをアクセスログに残す
+	info := requestctx.ErrorInfo{Code: code}
+	var de *domain.DomainError
+	if errors.As(err, &de) {
+		info.Entity, info.Op = de.Entity, de.Op
+	}
+	requestctx.SetErrorInfo(ctx, info)
+
+	if grpcCode == codes.Internal && logger != nil {
+		method, _ := grpc.Method(ctx)
+		attrs := []any{
+			"error", err.Error(),
+			"method", method,
+		}
+		if de != nil {
+			attrs = append(attrs, "entity", de.Entity, "op", de.Op)
+		}
+		logger.ErrorContext(ctx, "internal error", attrs...)
+	}
+
+	errInfo := &errdetails.ErrorInfo{Reason: code, Domain: ErrorDomain}
+	if id := requestctx.RequestID(ctx); id != "" {
+		errInfo.Metadata = map[string]string{"request_id": id}
+	}
+	details := []protoadapt.MessageV1{errInfo}
+	if len(violations) > 0 {
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+
+	st := status.New(grpcCode, message)
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+func firstMetadata(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+package grpcerrors_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go-api/internal/domain"
+	grpcerrors "go-api/internal/presentation/grpc/errors"
+)
+
+func TestCode(t *testing.T) {
+	tests := []struct {
+		status int
+		want   codes.Code
+	}{
+		{http.StatusBadRequest, codes.InvalidArgument},
+		{http.StatusUnauthorized, codes.Unauthenticated},
+		{http.StatusForbidden, codes.PermissionDenied},
+		{http.StatusNotFound, codes.NotFound},
+		{http.StatusConflict, codes.AlreadyExists},
+		{http.StatusTooManyRequests, codes.ResourceExhausted},
+		{http.StatusInternalServerError, codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			assert.Equal(t, tt.want, grpcerrors.Code(tt.status))
+		})
+	}
+}
+
+func TestStatus(t *testing.T) {
+	t.Run("ドメインエラーはHTTP APIと同じメッセージで返す", func(t *testing.T) {
+		err := grpcerrors.Status(context.Background(), domain.Conflict("user", "Save", errors.New("duplicate key")), nil)
+
+		st := status.Convert(err)
+		assert.Equal(t, codes.AlreadyExists, st.Code())
+		assert.Equal(t, "user already exists", st.Message())
+	})
+
+	t.Run("登録されていないエラーは内容を隠蔽する", func(t *testing.T) {
+		err := grpcerrors.Status(context.Background(), fmt.Errorf("connect: %w", errors.New("password=secret")), nil)
+
+		st := status.Convert(err)
+		assert.Equal(t, codes.Internal, st.Code())
+		assert.Equal(t, "internal server error", st.Message())
+	})
+}
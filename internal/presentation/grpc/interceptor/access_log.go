@@ -0,0 +1,121 @@
+package interceptor
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"go-api/internal/presentation/http/middleware"
+	"go-api/internal/presentation/http/requestctx"
+)
+
+// AccessLogOptions はアクセスログの設定。
+type AccessLogOptions struct {
+	// SuccessSampleRate は OK で終わった呼び出しを記録する割合（0〜1）。
+	// OK 以外の呼び出しは常に記録する。
+	SuccessSampleRate float64
+	// ClientIPs はクライアントIPの解決に使用する。メタデータの x-forwarded-for は信頼済みプロキシからの場合のみ参照する。
+	ClientIPs *middleware.ClientIPResolver
+}
+
+// AccessLog はアクセスログを記録するインターセプター。
+// HTTP API のアクセスログと同じ "access" メッセージで、ルートの代わりにメソッド名（/user.v1.UserService/GetUser 等）を記録する。
+// エラーの場合はエラーコードと、失敗した操作のエンティティ名・操作名も記録する。
+func AccessLog(logger *slog.Logger, opts AccessLogOptions) Interceptor {
+	return Interceptor{
+		Unary: func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			start := time.Now()
+			ctx, errorInfo := requestctx.WithErrorInfo(ctx)
+
+			resp, err := handler(ctx, req)
+
+			logAccess(ctx, logger, opts, info.FullMethod, start, err, errorInfo())
+			return resp, err
+		},
+		Stream: func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			start := time.Now()
+			ctx, errorInfo := requestctx.WithErrorInfo(ss.Context())
+
+			err := handler(srv, withContext(ss, ctx))
+
+			logAccess(ctx, logger, opts, info.FullMethod, start, err, errorInfo())
+			return err
+		},
+	}
+}
+
+func logAccess(ctx context.Context, logger *slog.Logger, opts AccessLogOptions, method string, start time.Time, err error, info requestctx.ErrorInfo) {
+	code := status.Code(err)
+	if code == codes.OK && !sampled(opts.SuccessSampleRate) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Duration("duration", time.Since(start)),
+		slog.String("user_agent", firstMetadata(ctx, "user-agent")),
+		slog.String("remote_ip", clientIP(ctx, opts.ClientIPs)),
+	}
+	if info.Code != "" {
+		attrs = append(attrs, slog.String("error_code", info.Code))
+		if info.Entity != "" {
+			attrs = append(attrs, slog.String("entity", info.Entity), slog.String("op", info.Op))
+		}
+	}
+	logger.LogAttrs(ctx, accessLogLevel(code), "access", attrs...)
+}
+
+// accessLogLevel はHTTPの 5xx に相当するサーバー側の失敗を Error、それ以外の失敗を Warn とする。
+func accessLogLevel(code codes.Code) slog.Level {
+	switch code {
+	case codes.OK:
+		return slog.LevelInfo
+	case codes.Unknown, codes.Internal, codes.Unavailable, codes.DataLoss, codes.Unimplemented, codes.DeadlineExceeded:
+		return slog.LevelError
+	default:
+		return slog.LevelWarn
+	}
+}
+
+// clientIP は接続元とメタデータの x-forwarded-for から HTTP API と同じ規則でクライアントIPを解決する。
+func clientIP(ctx context.Context, resolver *middleware.ClientIPResolver) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	if resolver == nil {
+		return p.Addr.String()
+	}
+	r := &http.Request{
+		RemoteAddr: p.Addr.String(),
+		Header:     http.Header{"X-Forwarded-For": metadata.ValueFromIncomingContext(ctx, "x-forwarded-for")},
+	}
+	return resolver.ClientIP(r)
+}
+
+func firstMetadata(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func sampled(rate float64) bool {
+	switch {
+	case rate >= 1:
+		return true
+	case rate <= 0:
+		return false
+	default:
+		return rand.Float64() < rate // #nosec G404 -- サンプリング用途のため暗号学的乱数は不要
+	}
+}
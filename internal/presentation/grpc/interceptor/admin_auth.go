@@ -0,0 +1,70 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/subtle"
+	"log/slog"
+	"strings"
+
+	"google.golang.org/grpc"
+
+	"go-api/internal/domain"
+	grpcerrors "go-api/internal/presentation/grpc/errors"
+	"go-api/internal/presentation/http/middleware"
+)
+
+// AdminAuth はメタデータの authorization: Bearer <token> で管理用トークンを検証するインターセプター。
+// services に含まれるサービス（例: "grpc.reflection.v1.ServerReflection"）のメソッドだけを対象とし、
+// 認証に成功した場合は管理者の Principal をコンテキストに格納する。
+// token が空の場合は対象のメソッドの呼び出しを全て拒否する。
+func AdminAuth(token string, logger *slog.Logger, services ...string) Interceptor {
+	protected := func(fullMethod string) bool {
+		service, _, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+		for _, s := range services {
+			if s == service {
+				return true
+			}
+		}
+		return false
+	}
+	authenticate := func(ctx context.Context) (context.Context, error) {
+		if !validAdminToken(ctx, token) {
+			return ctx, grpcerrors.Status(ctx, domain.ErrUnauthorized, logger)
+		}
+		return middleware.WithPrincipal(ctx, middleware.Principal{ID: middleware.AdminPrincipalID}), nil
+	}
+
+	return Interceptor{
+		Unary: func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if !protected(info.FullMethod) {
+				return handler(ctx, req)
+			}
+			ctx, err := authenticate(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		},
+		Stream: func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if !protected(info.FullMethod) {
+				return handler(srv, ss)
+			}
+			ctx, err := authenticate(ss.Context())
+			if err != nil {
+				return err
+			}
+			return handler(srv, withContext(ss, ctx))
+		},
+	}
+}
+
+func validAdminToken(ctx context.Context, token string) bool {
+	if token == "" {
+		return false
+	}
+	scheme, got, ok := strings.Cut(firstMetadata(ctx, "authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1
+}
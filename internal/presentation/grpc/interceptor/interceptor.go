@@ -0,0 +1,44 @@
+// Package interceptor はgRPC APIのインターセプターを提供する。
+// HTTP API のミドルウェア（middleware パッケージ）と同じ処理をgRPCの呼び出しに適用する。
+package interceptor
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// Interceptor は unary と server streaming の両方に適用するインターセプターの組。
+type Interceptor struct {
+	Unary  grpc.UnaryServerInterceptor
+	Stream grpc.StreamServerInterceptor
+}
+
+// ServerOptions は interceptors を先頭から外側の順に適用する grpc.ServerOption を返す。
+func ServerOptions(interceptors ...Interceptor) []grpc.ServerOption {
+	unary := make([]grpc.UnaryServerInterceptor, 0, len(interceptors))
+	stream := make([]grpc.StreamServerInterceptor, 0, len(interceptors))
+	for _, i := range interceptors {
+		unary = append(unary, i.Unary)
+		stream = append(stream, i.Stream)
+	}
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+}
+
+// serverStream はコンテキストを差し替えた grpc.ServerStream。
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// withContext は ctx を返す grpc.ServerStream を返す。
+func withContext(ss grpc.ServerStream, ctx context.Context) grpc.ServerStream {
+	return &serverStream{ServerStream: ss, ctx: ctx}
+}
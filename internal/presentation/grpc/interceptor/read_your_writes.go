@@ -0,0 +1,63 @@
+package interceptor
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"go-api/internal/infrastructure/database"
+)
+
+// LastWriteKey は呼び出し元の最後の書き込みの時刻（UNIXミリ秒）を受け渡すメタデータのキー。
+const LastWriteKey = "x-last-write"
+
+// ReadYourWrites はメタデータの最後の書き込みの時刻を読み込みの振り分けのセッションとしてコンテキストに格納するインターセプター。
+// 呼び出し中に書き込んだ場合はその時刻をトレーラーで返す。呼び出し元が後続の呼び出しでメタデータに送り返すと、
+// HTTP API のクッキーと同じく一定期間読み込みがプライマリに送られる。
+func ReadYourWrites() Interceptor {
+	return Interceptor{
+		Unary: func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			initial := lastWriteFromMetadata(ctx)
+			session := database.NewSession(initial)
+			resp, err := handler(database.WithSession(ctx, session), req)
+			if md, ok := lastWriteTrailer(session, initial); ok {
+				_ = grpc.SetTrailer(ctx, md)
+			}
+			return resp, err
+		},
+		Stream: func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			initial := lastWriteFromMetadata(ss.Context())
+			session := database.NewSession(initial)
+			err := handler(srv, withContext(ss, database.WithSession(ss.Context(), session)))
+			if md, ok := lastWriteTrailer(session, initial); ok {
+				ss.SetTrailer(md)
+			}
+			return err
+		},
+	}
+}
+
+// lastWriteFromMetadata はメタデータから最後の書き込みの時刻を取り出す。ないか不正な場合はゼロ値を返す。
+func lastWriteFromMetadata(ctx context.Context) time.Time {
+	values := metadata.ValueFromIncomingContext(ctx, LastWriteKey)
+	if len(values) == 0 {
+		return time.Time{}
+	}
+	ms, err := strconv.ParseInt(values[0], 10, 64)
+	if err != nil || ms <= 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
+
+// lastWriteTrailer は呼び出し中に書き込んでいれば最後の書き込みの時刻のトレーラーを返す。
+func lastWriteTrailer(session *database.Session, initial time.Time) (metadata.MD, bool) {
+	at := session.LastWrite()
+	if !at.After(initial) {
+		return nil, false
+	}
+	return metadata.Pairs(LastWriteKey, strconv.FormatInt(at.UnixMilli(), 10)), true
+}
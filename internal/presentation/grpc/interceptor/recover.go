@@ -0,0 +1,46 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"runtime/debug"
+
+	"google.golang.org/grpc"
+
+	grpcerrors "go-api/internal/presentation/grpc/errors"
+)
+
+// Recover はパニックリカバリーを行うインターセプター。
+// ハンドラー内でパニックが発生した場合、Internal エラーを返す。
+// 注意: goroutine内でのパニックは別途recoverが必要。
+func Recover(logger *slog.Logger) Interceptor {
+	recovered := func(ctx context.Context, method string, rec any) error {
+		logger.ErrorContext(ctx, "panic recovered",
+			"error", rec,
+			"stack", string(debug.Stack()),
+			"method", method,
+		)
+		// ログは上で記録済みのため logger は渡さない
+		return grpcerrors.Status(ctx, errors.New("panic recovered"), nil)
+	}
+
+	return Interceptor{
+		Unary: func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (_ any, err error) {
+			defer func() {
+				if rec := recover(); rec != nil {
+					err = recovered(ctx, info.FullMethod, rec)
+				}
+			}()
+			return handler(ctx, req)
+		},
+		Stream: func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+			defer func() {
+				if rec := recover(); rec != nil {
+					err = recovered(ss.Context(), info.FullMethod, rec)
+				}
+			}()
+			return handler(srv, ss)
+		},
+	}
+}
@@ -0,0 +1,41 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"go-api/internal/presentation/http/middleware"
+	"go-api/internal/presentation/http/requestctx"
+)
+
+// RequestIDKey はリクエストIDを受け渡すメタデータのキー。
+var RequestIDKey = strings.ToLower(middleware.RequestIDHeader)
+
+// RequestID はリクエストIDを付与するインターセプター。
+// メタデータの x-request-id が妥当な形式であれば引き継ぎ、なければ新規に生成する。
+// IDはコンテキストに格納し、レスポンスヘッダーにも返す。
+func RequestID() Interceptor {
+	return Interceptor{
+		Unary: func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			id := resolveRequestID(ctx)
+			_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, id))
+			return handler(requestctx.WithRequestID(ctx, id), req)
+		},
+		Stream: func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			id := resolveRequestID(ss.Context())
+			_ = ss.SetHeader(metadata.Pairs(RequestIDKey, id))
+			return handler(srv, withContext(ss, requestctx.WithRequestID(ss.Context(), id)))
+		},
+	}
+}
+
+func resolveRequestID(ctx context.Context) string {
+	var id string
+	if values := metadata.ValueFromIncomingContext(ctx, RequestIDKey); len(values) > 0 {
+		id = values[0]
+	}
+	return middleware.ResolveRequestID(id)
+}
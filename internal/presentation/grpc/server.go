@@ -0,0 +1,53 @@
+// Package grpcapi はgRPC APIのサーバー構築を提供する。
+package grpcapi
+
+import (
+	"log/slog"
+
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alphapb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+
+	"go-api/internal/presentation/grpc/gen/userv1"
+	"go-api/internal/presentation/grpc/interceptor"
+	healthservice "go-api/internal/presentation/grpc/service/health"
+	userservice "go-api/internal/presentation/grpc/service/user"
+)
+
+// ReflectionServices はサーバーリフレクションのサービス名。スキーマを公開するため管理者認証の対象とする。
+var ReflectionServices = []string{
+	reflectionpb.ServerReflection_ServiceDesc.ServiceName,
+	reflectionv1alphapb.ServerReflection_ServiceDesc.ServiceName,
+}
+
+// Dependencies はgRPCサーバー構築に必要な依存関係のインターフェース。
+// di.Container がこのインターフェースを満たす。
+type Dependencies interface {
+	UserService() *userservice.Service
+	HealthService() *healthservice.Service
+	GRPCAccessLog() interceptor.Interceptor
+	GRPCAdminAuth(services ...string) interceptor.Interceptor
+	GRPCReadYourWrites() interceptor.Interceptor
+	Logger() *slog.Logger
+}
+
+// NewServer はgRPCサーバーを生成する。
+// UserService に加え、ヘルスチェック（grpc.health.v1.Health）とサーバーリフレクションを登録する。
+func NewServer(deps Dependencies, opts ...grpc.ServerOption) *grpc.Server {
+	// インターセプターは HTTP API のミドルウェアと同じ順で外側から適用する
+	opts = append(opts, interceptor.ServerOptions(
+		interceptor.RequestID(),
+		deps.GRPCAccessLog(),
+		interceptor.Recover(deps.Logger()),
+		deps.GRPCAdminAuth(ReflectionServices...),
+		deps.GRPCReadYourWrites(),
+	)...)
+
+	s := grpc.NewServer(opts...)
+	userv1.RegisterUserServiceServer(s, deps.UserService())
+	healthpb.RegisterHealthServer(s, deps.HealthService())
+	reflection.Register(s)
+	return s
+}
@@ -0,0 +1,250 @@
+package grpcapi_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	usecase "go-api/internal/application/user"
+	"go-api/internal/domain"
+	domainuser "go-api/internal/domain/user"
+	"go-api/internal/domain/user/mocks"
+	"go-api/internal/health"
+	grpcapi "go-api/internal/presentation/grpc"
+	"go-api/internal/presentation/grpc/gen/userv1"
+	"go-api/internal/presentation/grpc/interceptor"
+	healthservice "go-api/internal/presentation/grpc/service/health"
+	userservice "go-api/internal/presentation/grpc/service/user"
+	"go-api/internal/testutil/factory"
+)
+
+const adminToken = "secret"
+
+// testDeps は grpcapi.Dependencies のテスト用実装。
+type testDeps struct {
+	repo   domainuser.UserRepository
+	logger *slog.Logger
+}
+
+func (d testDeps) UserService() *userservice.Service {
+	return userservice.NewService(userservice.Usecases{
+		Get:    usecase.NewGetUserUsecase(d.repo),
+		List:   usecase.NewListUsersUsecase(d.repo),
+		Create: usecase.NewCreateUserUsecase(d.repo),
+		Update: usecase.NewUpdateUserUsecase(d.repo),
+		Delete: usecase.NewDeleteUserUsecase(d.repo),
+	}, d.logger)
+}
+
+func (d testDeps) HealthService() *healthservice.Service {
+	registry := health.NewRegistry(health.NewReadiness(), health.Options{Timeout: time.Second})
+	return healthservice.NewService(registry, userv1.UserService_ServiceDesc.ServiceName)
+}
+
+func (d testDeps) GRPCAccessLog() interceptor.Interceptor {
+	return interceptor.AccessLog(d.logger, interceptor.AccessLogOptions{SuccessSampleRate: 1})
+}
+
+func (d testDeps) GRPCAdminAuth(services ...string) interceptor.Interceptor {
+	return interceptor.AdminAuth(adminToken, d.logger, services...)
+}
+
+func (d testDeps) GRPCReadYourWrites() interceptor.Interceptor {
+	return interceptor.ReadYourWrites()
+}
+
+func (d testDeps) Logger() *slog.Logger {
+	return d.logger
+}
+
+// newTestClient はメモリ上のリスナーで起動したサーバーに接続したクライアントを返す。
+func newTestClient(t *testing.T, repo domainuser.UserRepository, logger *slog.Logger) *grpc.ClientConn {
+	t.Helper()
+	ln := bufconn.Listen(1 << 20)
+	srv := grpcapi.NewServer(testDeps{repo: repo, logger: logger})
+	go func() { _ = srv.Serve(ln) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return ln.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestUserService(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ユーザーを取得できる", func(t *testing.T) {
+		testUser := factory.NewUser(factory.WithName("test"), factory.WithEmail("test@example.com"))
+		repo := mocks.NewMockUserRepository(t)
+		repo.EXPECT().FindByID(mock.Anything, testUser.ID()).Return(testUser, nil)
+		client := userv1.NewUserServiceClient(newTestClient(t, repo, discardLogger()))
+
+		var header metadata.MD
+		resp, err := client.GetUser(ctx, &userv1.GetUserRequest{Id: testUser.ID().String()}, grpc.Header(&header))
+		require.NoError(t, err)
+
+		assert.Equal(t, testUser.ID().String(), resp.GetUser().GetId())
+		assert.Equal(t, "test", resp.GetUser().GetName())
+		assert.Equal(t, "test@example.com", resp.GetUser().GetEmail())
+		assert.NotEmpty(t, header.Get("x-request-id"))
+	})
+
+	t.Run("存在しないユーザーの場合はNotFoundとエラーコードを返す", func(t *testing.T) {
+		testUser := factory.NewUser()
+		repo := mocks.NewMockUserRepository(t)
+		repo.EXPECT().FindByID(mock.Anything, testUser.ID()).Return(nil, domain.NotFound("user", "FindByID"))
+		client := userv1.NewUserServiceClient(newTestClient(t, repo, discardLogger()))
+
+		ctx := metadata.AppendToOutgoingContext(ctx, "x-request-id", "req-1", "accept-language", "ja")
+		_, err := client.GetUser(ctx, &userv1.GetUserRequest{Id: testUser.ID().String()})
+
+		st := status.Convert(err)
+		assert.Equal(t, codes.NotFound, st.Code())
+		assert.Equal(t, "ユーザーが見つかりません", st.Message())
+		require.Len(t, st.Details(), 1)
+		info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+		require.True(t, ok)
+		assert.Equal(t, "NOT_FOUND", info.GetReason())
+		assert.Equal(t, "req-1", info.GetMetadata()["request_id"])
+	})
+
+	t.Run("入力が不正な場合はInvalidArgumentと項目ごとの詳細を返す", func(t *testing.T) {
+		repo := mocks.NewMockUserRepository(t)
+		client := userv1.NewUserServiceClient(newTestClient(t, repo, discardLogger()))
+
+		_, err := client.CreateUser(ctx, &userv1.CreateUserRequest{Name: "", Email: "invalid"})
+
+		st := status.Convert(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		assert.Equal(t, "validation error", st.Message())
+		var violations []*errdetails.BadRequest_FieldViolation
+		for _, d := range st.Details() {
+			if br, ok := d.(*errdetails.BadRequest); ok {
+				violations = br.GetFieldViolations()
+			}
+		}
+		require.Len(t, violations, 2)
+		assert.Equal(t, "name", violations[0].GetField())
+		assert.Equal(t, "required", violations[0].GetReason())
+		assert.Equal(t, "email", violations[1].GetField())
+		assert.Equal(t, "invalid_format", violations[1].GetReason())
+	})
+
+	t.Run("ユーザー一覧を1件ずつ受信できる", func(t *testing.T) {
+		users := []*domainuser.User{factory.NewUser(factory.WithName("a")), factory.NewUser(factory.WithName("b"))}
+		repo := mocks.NewMockUserRepository(t)
+		repo.EXPECT().FindAll(mock.Anything).Return(users, nil)
+		client := userv1.NewUserServiceClient(newTestClient(t, repo, discardLogger()))
+
+		stream, err := client.ListUsers(ctx, &userv1.ListUsersRequest{})
+		require.NoError(t, err)
+
+		var names []string
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			names = append(names, resp.GetUser().GetName())
+		}
+		assert.Equal(t, []string{"a", "b"}, names)
+	})
+
+	t.Run("パニックした場合はInternalを返しログに記録する", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, nil))
+		repo := mocks.NewMockUserRepository(t)
+		repo.EXPECT().FindAll(mock.Anything).RunAndReturn(func(context.Context) ([]*domainuser.User, error) {
+			panic("boom")
+		})
+		client := userv1.NewUserServiceClient(newTestClient(t, repo, logger))
+
+		stream, err := client.ListUsers(ctx, &userv1.ListUsersRequest{})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+
+		st := status.Convert(err)
+		assert.Equal(t, codes.Internal, st.Code())
+		assert.Equal(t, "internal server error", st.Message())
+		assert.Contains(t, buf.String(), `"msg":"panic recovered"`)
+		assert.Contains(t, buf.String(), `"error_code":"INTERNAL_ERROR"`)
+	})
+}
+
+func TestHealth(t *testing.T) {
+	client := healthpb.NewHealthClient(newTestClient(t, mocks.NewMockUserRepository(t), discardLogger()))
+
+	t.Run("サーバー全体とUserServiceの状態を返す", func(t *testing.T) {
+		for _, service := range []string{"", "user.v1.UserService"} {
+			resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+			require.NoError(t, err)
+			assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+		}
+	})
+
+	t.Run("未知のサービスはNotFound", func(t *testing.T) {
+		_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "unknown"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestReflection(t *testing.T) {
+	client := reflectionpb.NewServerReflectionClient(newTestClient(t, mocks.NewMockUserRepository(t), discardLogger()))
+
+	listServices := func(ctx context.Context) (*reflectionpb.ServerReflectionResponse, error) {
+		stream, err := client.ServerReflectionInfo(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := stream.Send(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+		}); err != nil {
+			return nil, err
+		}
+		return stream.Recv()
+	}
+
+	t.Run("管理用トークンがない場合はUnauthenticated", func(t *testing.T) {
+		_, err := listServices(context.Background())
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("管理用トークンで認証するとサービス一覧を返す", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+adminToken)
+		resp, err := listServices(ctx)
+		require.NoError(t, err)
+
+		var names []string
+		for _, s := range resp.GetListServicesResponse().GetService() {
+			names = append(names, s.GetName())
+		}
+		assert.Contains(t, names, "user.v1.UserService")
+		assert.Contains(t, names, "grpc.health.v1.Health")
+	})
+}
@@ -0,0 +1,83 @@
+// Package health はgRPCヘルスチェックプロトコル（grpc.health.v1.Health）のサービスを提供する。
+package health
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"go-api/internal/health"
+)
+
+// DefaultWatchInterval は Watch で状態を確認する間隔のデフォルト値。
+const DefaultWatchInterval = 5 * time.Second
+
+// Service は /readyz と同じチェック（DB疎通・マイグレーションのバージョン・停止処理中か）で
+// サービスの状態を返す healthpb.HealthServer の実装。
+type Service struct {
+	healthpb.UnimplementedHealthServer
+
+	registry      *health.Registry
+	services      map[string]bool
+	watchInterval time.Duration
+}
+
+// NewService は Service を生成する。services は状態を問い合わせられるサービス名で、
+// サーバー全体を表す空文字列は常に問い合わせられる。
+func NewService(registry *health.Registry, services ...string) *Service {
+	known := map[string]bool{"": true}
+	for _, s := range services {
+		known[s] = true
+	}
+	return &Service{
+		registry:      registry,
+		services:      known,
+		watchInterval: DefaultWatchInterval,
+	}
+}
+
+// Check はサービスの状態を返す。未知のサービスは NotFound とする。
+func (s *Service) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if !s.services[req.GetService()] {
+		return nil, status.Error(codes.NotFound, "unknown service")
+	}
+	return &healthpb.HealthCheckResponse{Status: s.status(ctx)}, nil
+}
+
+// Watch はサービスの状態を送信し、以降は状態が変わるたびに送信する。
+// 未知のサービスは切断せず SERVICE_UNKNOWN を送信する（プロトコルの規定）。
+func (s *Service) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	ctx := stream.Context()
+	ticker := time.NewTicker(s.watchInterval)
+	defer ticker.Stop()
+
+	last := healthpb.HealthCheckResponse_UNKNOWN
+	for {
+		current := healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		if s.services[req.GetService()] {
+			current = s.status(ctx)
+		}
+		if current != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: current}); err != nil {
+				return err
+			}
+			last = current
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Service) status(ctx context.Context) healthpb.HealthCheckResponse_ServingStatus {
+	if s.registry.Check(ctx).Status != health.StatusOK {
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	return healthpb.HealthCheckResponse_SERVING
+}
@@ -0,0 +1,116 @@
+// Package user はユーザーのgRPCサービスを提供する。
+package user
+
+import (
+	"context"
+	"log/slog"
+
+	"go-api/internal/application/user"
+	"go-api/internal/domain/user/valueobject"
+	grpcerrors "go-api/internal/presentation/grpc/errors"
+	"go-api/internal/presentation/grpc/gen/userv1"
+)
+
+// Usecases は Service が呼び出すユースケース。
+type Usecases struct {
+	Get    *user.GetUserUsecase
+	List   *user.ListUsersUsecase
+	Create *user.CreateUserUsecase
+	Update *user.UpdateUserUsecase
+	Delete *user.DeleteUserUsecase
+}
+
+// Service は userv1.UserServiceServer の実装。HTTP API と同じユースケースを呼び出す。
+type Service struct {
+	userv1.UnimplementedUserServiceServer
+
+	uc     Usecases
+	logger *slog.Logger
+}
+
+// NewService は Service を生成する。
+func NewService(uc Usecases, logger *slog.Logger) *Service {
+	return &Service{
+		uc:     uc,
+		logger: logger,
+	}
+}
+
+// GetUser はユーザーを取得する。
+func (s *Service) GetUser(ctx context.Context, req *userv1.GetUserRequest) (*userv1.GetUserResponse, error) {
+	output, err := s.uc.Get.Execute(ctx, req.GetId())
+	if err != nil {
+		return nil, grpcerrors.Status(ctx, err, s.logger)
+	}
+	return &userv1.GetUserResponse{User: newUser(output.User)}, nil
+}
+
+// ListUsers はユーザー一覧を1件ずつ送信する。
+func (s *Service) ListUsers(_ *userv1.ListUsersRequest, stream userv1.UserService_ListUsersServer) error {
+	ctx := stream.Context()
+
+	output, err := s.uc.List.Execute(ctx)
+	if err != nil {
+		return grpcerrors.Status(ctx, err, s.logger)
+	}
+
+	for _, u := range output.Users {
+		// 送信に失敗した場合（クライアントの切断等）は gRPC のステータスのまま返す
+		if err := stream.Send(&userv1.ListUsersResponse{User: newUser(u)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateUser はユーザーを作成する。
+func (s *Service) CreateUser(ctx context.Context, req *userv1.CreateUserRequest) (*userv1.CreateUserResponse, error) {
+	var errs valueobject.ValidationErrors
+	name, err := valueobject.NewUserName(req.GetName())
+	errs.Add("name", err)
+	email, err := valueobject.NewEmail(req.GetEmail())
+	errs.Add("email", err)
+	if err := errs.Err(); err != nil {
+		return nil, grpcerrors.Status(ctx, err, s.logger)
+	}
+
+	output, err := s.uc.Create.Execute(ctx, user.CreateUserInput{Name: name, Email: email})
+	if err != nil {
+		return nil, grpcerrors.Status(ctx, err, s.logger)
+	}
+	return &userv1.CreateUserResponse{User: newUser(output.User)}, nil
+}
+
+// UpdateUser はユーザーを更新する。
+func (s *Service) UpdateUser(ctx context.Context, req *userv1.UpdateUserRequest) (*userv1.UpdateUserResponse, error) {
+	var errs valueobject.ValidationErrors
+	name, err := valueobject.NewUserName(req.GetName())
+	errs.Add("name", err)
+	email, err := valueobject.NewEmail(req.GetEmail())
+	errs.Add("email", err)
+	if err := errs.Err(); err != nil {
+		return nil, grpcerrors.Status(ctx, err, s.logger)
+	}
+
+	output, err := s.uc.Update.Execute(ctx, req.GetId(), user.UpdateUserInput{Name: name, Email: email})
+	if err != nil {
+		return nil, grpcerrors.Status(ctx, err, s.logger)
+	}
+	return &userv1.UpdateUserResponse{User: newUser(output.User)}, nil
+}
+
+// DeleteUser はユーザーを削除する。
+func (s *Service) DeleteUser(ctx context.Context, req *userv1.DeleteUserRequest) (*userv1.DeleteUserResponse, error) {
+	if err := s.uc.Delete.Execute(ctx, req.GetId()); err != nil {
+		return nil, grpcerrors.Status(ctx, err, s.logger)
+	}
+	return &userv1.DeleteUserResponse{}, nil
+}
+
+func newUser(dto user.UserDTO) *userv1.User {
+	return &userv1.User{
+		Id:    dto.ID,
+		Name:  dto.Name,
+		Email: dto.Email,
+	}
+}
@@ -4,16 +4,19 @@ package httperrors
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 	"log/slog"
 	"net/http"
 
-	"github.com/go-playground/validator/v10"
-
 	"go-api/internal/domain"
 	"go-api/internal/domain/user/valueobject"
+	"go-api/internal/presentation/http/i18n"
+	"go-api/internal/presentation/http/request"
+	"go-api/internal/presentation/http/requestctx"
 )
 
+// ErrTooManyRequests はレート制限の上限を超えた場合のエラー。
+var ErrTooManyRequests = errors.New("too many requests")
+
 // ErrorResponse はAPIエラーレスポンスのJSON構造。
 type ErrorResponse struct {
 	Error ErrorDetail `json:"error"`
@@ -21,9 +24,10 @@ type ErrorResponse struct {
 
 // ErrorDetail はエラーの詳細情報。
 type ErrorDetail struct {
-	Code    string       `json:"code"`              // エラーコード（機械可読）
-	Message string       `json:"message"`           // エラーメッセージ（人間可読）
-	Details []FieldError `json:"details,omitempty"` // フィールドエラー詳細
+	Code      string       `json:"code"`                 // エラーコード（機械可読）
+	Message   string       `json:"message"`              // エラーメッセージ（人間可読）
+	Details   []FieldError `json:"details,omitempty"`    // フィールドエラー詳細
+	RequestID string       `json:"request_id,omitempty"` // 問い合わせ時にログを特定するためのリクエストID
 }
 
 // FieldError はフィールド単位のエラー（ValidationError用）。
@@ -31,146 +35,119 @@ type FieldError struct {
 	Field   string `json:"field"`
 	Code    string `json:"code"`
 	Message string `json:"message"`
+	Offset  int64  `json:"offset,omitempty"` // リクエストボディ内の位置（JSONの解析エラー用）
 }
 
-// StatusFromError はドメインエラーからHTTPステータスコードを導出する。
+// StatusFromError はエラーからHTTPステータスコードを導出する。登録されていないエラーは 500 とする。
 func StatusFromError(err error) int {
-	switch {
-	case errors.Is(err, domain.ErrNotFound):
-		return http.StatusNotFound
-	case errors.Is(err, domain.ErrConflict):
-		return http.StatusConflict
-	case errors.Is(err, domain.ErrInvalidInput),
-		errors.Is(err, valueobject.ErrInvalidID),
-		errors.Is(err, valueobject.ErrNameRequired),
-		errors.Is(err, valueobject.ErrNameTooLong),
-		errors.Is(err, valueobject.ErrEmailRequired),
-		errors.Is(err, valueobject.ErrEmailTooLong),
-		errors.Is(err, valueobject.ErrEmailInvalid):
-		return http.StatusBadRequest
-	case errors.Is(err, domain.ErrUnauthorized):
-		return http.StatusUnauthorized
-	case errors.Is(err, domain.ErrForbidden):
-		return http.StatusForbidden
-	default:
-		return http.StatusInternalServerError
-	}
+	return Lookup(err).Status
 }
 
 // CodeFromError はエラーからエラーコード文字列を導出する。
 func CodeFromError(err error) string {
-	switch {
-	case errors.Is(err, domain.ErrNotFound):
-		return "NOT_FOUND"
-	case errors.Is(err, domain.ErrConflict):
-		return "CONFLICT"
-	case errors.Is(err, domain.ErrInvalidInput),
-		errors.Is(err, valueobject.ErrInvalidID),
-		errors.Is(err, valueobject.ErrNameRequired),
-		errors.Is(err, valueobject.ErrNameTooLong),
-		errors.Is(err, valueobject.ErrEmailRequired),
-		errors.Is(err, valueobject.ErrEmailTooLong),
-		errors.Is(err, valueobject.ErrEmailInvalid):
-		return "VALIDATION_ERROR"
-	case errors.Is(err, domain.ErrUnauthorized):
-		return "UNAUTHORIZED"
-	case errors.Is(err, domain.ErrForbidden):
-		return "FORBIDDEN"
-	default:
-		return "INTERNAL_ERROR"
-	}
+	return Lookup(err).Code
 }
 
 // WriteError はエラーレスポンスをJSONで書き込む。
+// 形式は Accept ヘッダーとコンテキストの Options から決め、従来形式か Problem Details（RFC 9457）で返す。
+// メッセージは Accept-Language から選んだ言語で返す（code は言語によらない）。
 // 500系エラーの場合は詳細をログに記録し、ユーザーには隠蔽する。
 func WriteError(w http.ResponseWriter, r *http.Request, err error, logger *slog.Logger) {
-	// validator.ValidationErrors の場合は特別処理
-	var ve validator.ValidationErrors
-	if errors.As(err, &ve) {
-		writeValidationError(w, ve)
-		return
+	m := Lookup(err)
+	status, code := m.Status, m.Code
+	tr := i18n.Negotiate(r.Header.Get("Accept-Language"))
+	message := localizedMessage(tr, err, m)
+	var details []FieldError
+
+	var (
+		ve valueobject.ValidationErrors
+		be *request.BodyError
+	)
+	switch {
+	case errors.As(err, &ve):
+		// 値オブジェクトの生成エラーは項目ごとの詳細を返す
+		status, code = http.StatusBadRequest, "VALIDATION_ERROR"
+		message = translate(tr, "error.VALIDATION_ERROR", "validation error")
+		details = ValidationDetails(tr, ve)
+	case errors.As(err, &be):
+		// JSONの解析エラーは不正な箇所を details で返す
+		details = []FieldError{{
+			Field:   be.Field,
+			Code:    be.Code,
+			Message: bodyErrorMessage(tr, be),
+			Offset:  be.Offset,
+		}}
 	}
 
-	status := StatusFromError(err)
-	code := CodeFromError(err)
+	// どのエンティティの操作で失敗したかをアクセスログに残す
+	info := requestctx.ErrorInfo{Code: code}
+	var de *domain.DomainError
+	if errors.As(err, &de) {
+		info.Entity, info.Op = de.Entity, de.Op
+	}
+	requestctx.SetErrorInfo(r.Context(), info)
 
 	// 500系はログに詳細を記録
 	if status >= 500 && logger != nil {
-		logger.Error("internal error",
+		attrs := []any{
 			"error", err.Error(),
 			"path", r.URL.Path,
 			"method", r.Method,
-		)
+		}
+		if de != nil {
+			attrs = append(attrs, "entity", de.Entity, "op", de.Op)
+		}
+		logger.ErrorContext(r.Context(), "internal error", attrs...)
 	}
 
-	resp := ErrorResponse{
-		Error: ErrorDetail{
-			Code:    code,
-			Message: userFacingMessage(err, status),
-		},
+	requestID := requestctx.RequestID(r.Context())
+	opts := optionsFrom(r.Context())
+
+	var (
+		contentType string
+		body        any
+	)
+	if negotiate(r, opts.Format) == FormatProblem {
+		contentType = ProblemContentType
+		body = Problem{
+			Type:      problemType(opts.ProblemTypeBaseURI, code),
+			Title:     http.StatusText(status),
+			Status:    status,
+			Detail:    message,
+			Instance:  r.URL.Path,
+			Code:      code,
+			RequestID: requestID,
+			Errors:    details,
+		}
+	} else {
+		contentType = "application/json"
+		body = ErrorResponse{
+			Error: ErrorDetail{
+				Code:      code,
+				Message:   message,
+				Details:   details,
+				RequestID: requestID,
+			},
+		}
 	}
 
-	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Language", tr.Language())
+	w.Header().Add("Vary", "Accept")
+	w.Header().Add("Vary", "Accept-Language")
 	w.WriteHeader(status)
-	_ = json.NewEncoder(w).Encode(resp)
+	_ = json.NewEncoder(w).Encode(body)
 }
 
-func writeValidationError(w http.ResponseWriter, ve validator.ValidationErrors) {
+// ValidationDetails は値オブジェクトの検証エラーを項目ごとの詳細にする。メッセージは tr の言語で返す。
+func ValidationDetails(tr *i18n.Translator, ve valueobject.ValidationErrors) []FieldError {
 	details := make([]FieldError, len(ve))
 	for i, fe := range ve {
 		details[i] = FieldError{
-			Field:   fe.Field(),
-			Code:    tagToCode(fe.Tag()),
-			Message: fieldErrorMessage(fe),
+			Field:   fe.Field,
+			Code:    fe.Code(),
+			Message: localizedMessage(tr, fe.Err, Lookup(fe.Err)),
 		}
 	}
-
-	resp := ErrorResponse{
-		Error: ErrorDetail{
-			Code:    "VALIDATION_ERROR",
-			Message: "validation error",
-			Details: details,
-		},
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusBadRequest)
-	_ = json.NewEncoder(w).Encode(resp)
-}
-
-func tagToCode(tag string) string {
-	switch tag {
-	case "required":
-		return "required"
-	case "max":
-		return "too_long"
-	case "email":
-		return "invalid_format"
-	default:
-		return tag
-	}
-}
-
-func fieldErrorMessage(fe validator.FieldError) string {
-	switch fe.Tag() {
-	case "required":
-		return fmt.Sprintf("%s is required", fe.Field())
-	case "max":
-		return fmt.Sprintf("%s must be %s characters or less", fe.Field(), fe.Param())
-	case "email":
-		return fmt.Sprintf("%s must be a valid email address", fe.Field())
-	default:
-		return fmt.Sprintf("%s is invalid", fe.Field())
-	}
-}
-
-// userFacingMessage は本番環境向けのエラーメッセージを返す。
-// 内部エラーの詳細は隠蔽する。
-func userFacingMessage(err error, status int) string {
-	// 4xx系はエラーメッセージをそのまま返す
-	if status >= 400 && status < 500 {
-		return err.Error()
-	}
-	// 5xx系は詳細を隠蔽
-	return "internal server error"
+	return details
 }
@@ -1,19 +1,22 @@
 package httperrors_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
-	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"go-api/internal/domain"
+	"go-api/internal/domain/user/valueobject"
 	httperrors "go-api/internal/presentation/http/errors"
-	"go-api/internal/presentation/http/validation"
+	"go-api/internal/presentation/http/request"
+	"go-api/internal/presentation/http/requestctx"
 )
 
 func TestStatusFromError(t *testing.T) {
@@ -27,6 +30,10 @@ func TestStatusFromError(t *testing.T) {
 		{"ErrInvalidInput", domain.ErrInvalidInput, http.StatusBadRequest},
 		{"ErrUnauthorized", domain.ErrUnauthorized, http.StatusUnauthorized},
 		{"ErrForbidden", domain.ErrForbidden, http.StatusForbidden},
+		{"ErrTooManyRequests", httperrors.ErrTooManyRequests, http.StatusTooManyRequests},
+		{"BodyError", &request.BodyError{Code: request.CodeSyntaxError}, http.StatusBadRequest},
+		{"ErrBodyTooLarge", request.ErrBodyTooLarge, http.StatusRequestEntityTooLarge},
+		{"ErrUnsupportedMediaType", request.ErrUnsupportedMediaType, http.StatusUnsupportedMediaType},
 		{"unknown error", errors.New("unknown"), http.StatusInternalServerError},
 		{"DomainError NotFound", domain.NotFound("user", "FindByID"), http.StatusNotFound},
 		{"DomainError Conflict", domain.Conflict("user", "Save", nil), http.StatusConflict},
@@ -51,6 +58,10 @@ func TestCodeFromError(t *testing.T) {
 		{"ErrInvalidInput", domain.ErrInvalidInput, "VALIDATION_ERROR"},
 		{"ErrUnauthorized", domain.ErrUnauthorized, "UNAUTHORIZED"},
 		{"ErrForbidden", domain.ErrForbidden, "FORBIDDEN"},
+		{"ErrTooManyRequests", httperrors.ErrTooManyRequests, "RATE_LIMITED"},
+		{"BodyError", &request.BodyError{Code: request.CodeSyntaxError}, "INVALID_BODY"},
+		{"ErrBodyTooLarge", request.ErrBodyTooLarge, "PAYLOAD_TOO_LARGE"},
+		{"ErrUnsupportedMediaType", request.ErrUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE"},
 		{"unknown error", errors.New("unknown"), "INTERNAL_ERROR"},
 	}
 
@@ -85,13 +96,9 @@ func TestWriteError(t *testing.T) {
 		w := httptest.NewRecorder()
 		r := httptest.NewRequest(http.MethodPost, "/users", http.NoBody)
 
-		// validator.ValidationErrors を生成するためにバリデーションを実行
-		type testReq struct {
-			Email string `json:"email" validate:"required,email"`
-			Name  string `json:"name" validate:"required,max=100"`
-		}
-		req := testReq{Email: "", Name: strings.Repeat("a", 101)}
-		ve := validation.Struct(req)
+		var ve valueobject.ValidationErrors
+		ve.Add("email", valueobject.ErrEmailRequired)
+		ve.Add("name", valueobject.ErrNameTooLong)
 
 		httperrors.WriteError(w, r, ve, nil)
 
@@ -122,4 +129,250 @@ func TestWriteError(t *testing.T) {
 		assert.Equal(t, "INTERNAL_ERROR", resp.Error.Code)
 		assert.Equal(t, "internal server error", resp.Error.Message)
 	})
+
+	t.Run("内部エラーのログに失敗した操作を含める", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, nil))
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/users", http.NoBody)
+		err := &domain.DomainError{Kind: errors.New("connection refused"), Entity: "user", Op: "Save"}
+
+		httperrors.WriteError(w, r, err, logger)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		var record map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+		assert.Equal(t, "connection refused", record["error"])
+		assert.Equal(t, "user", record["entity"])
+		assert.Equal(t, "Save", record["op"])
+	})
+
+	t.Run("リクエストIDをレスポンスに含める", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/users", http.NoBody)
+		r = r.WithContext(requestctx.WithRequestID(r.Context(), "req-123"))
+
+		httperrors.WriteError(w, r, errors.New("database connection failed"), nil)
+
+		var resp httperrors.ErrorResponse
+		err := json.Unmarshal(w.Body.Bytes(), &resp)
+		require.NoError(t, err)
+
+		assert.Equal(t, "req-123", resp.Error.RequestID)
+	})
+
+	t.Run("JSONの解析エラーは不正な箇所を details に含める", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/users", http.NoBody)
+		bodyErr := &request.BodyError{
+			Code:     request.CodeInvalidType,
+			Field:    "name",
+			Offset:   12,
+			Message:  "name must be a string, got number (offset 12)",
+			Expected: "string",
+			Actual:   "number",
+		}
+
+		httperrors.WriteError(w, r, bodyErr, nil)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		var resp httperrors.ErrorResponse
+		err := json.Unmarshal(w.Body.Bytes(), &resp)
+		require.NoError(t, err)
+
+		assert.Equal(t, "INVALID_BODY", resp.Error.Code)
+		assert.Equal(t, []httperrors.FieldError{{
+			Field:   "name",
+			Code:    "invalid_type",
+			Message: "name must be a string, got a number (offset 12)",
+			Offset:  12,
+		}}, resp.Error.Details)
+	})
+}
+
+func TestWriteError_ProblemDetails(t *testing.T) {
+	decode := func(t *testing.T, w *httptest.ResponseRecorder) httperrors.Problem {
+		t.Helper()
+		var p httperrors.Problem
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+		return p
+	}
+
+	t.Run("Accept で application/problem+json を指定すると Problem Details で返す", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/users/123", http.NoBody)
+		r.Header.Set("Accept", "application/problem+json")
+		r = r.WithContext(requestctx.WithRequestID(r.Context(), "req-123"))
+
+		httperrors.WriteError(w, r, domain.NotFound("user", "FindByID"), nil)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+		assert.Equal(t, "Accept", w.Header().Get("Vary"))
+		assert.Equal(t, httperrors.Problem{
+			Type:      "/problems/not-found",
+			Title:     "Not Found",
+			Status:    http.StatusNotFound,
+			Detail:    "user not found",
+			Instance:  "/users/123",
+			Code:      "NOT_FOUND",
+			RequestID: "req-123",
+		}, decode(t, w))
+	})
+
+	t.Run("フィールドエラーは errors 拡張に含める", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/users", http.NoBody)
+		r.Header.Set("Accept", "application/problem+json")
+
+		var ve valueobject.ValidationErrors
+		ve.Add("email", valueobject.ErrEmailRequired)
+		httperrors.WriteError(w, r, ve, nil)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		p := decode(t, w)
+		assert.Equal(t, "/problems/validation-error", p.Type)
+		assert.Equal(t, "Bad Request", p.Title)
+		assert.Equal(t, 400, p.Status)
+		require.Len(t, p.Errors, 1)
+		assert.Equal(t, "email", p.Errors[0].Field)
+		assert.Equal(t, "required", p.Errors[0].Code)
+	})
+
+	t.Run("内部エラーはメッセージを隠蔽する", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/users", http.NoBody)
+		r.Header.Set("Accept", "application/problem+json")
+
+		httperrors.WriteError(w, r, errors.New("database connection failed"), nil)
+
+		p := decode(t, w)
+		assert.Equal(t, "/problems/internal-error", p.Type)
+		assert.Equal(t, "internal server error", p.Detail)
+	})
+
+	t.Run("設定で形式とtypeの接頭辞を指定できる", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/users", http.NoBody)
+		r = r.WithContext(httperrors.WithOptions(r.Context(), httperrors.Options{
+			Format:             httperrors.FormatProblem,
+			ProblemTypeBaseURI: "https://docs.example.com/problems/",
+		}))
+
+		httperrors.WriteError(w, r, httperrors.ErrTooManyRequests, nil)
+
+		assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+		assert.Equal(t, "https://docs.example.com/problems/rate-limited", decode(t, w).Type)
+	})
+
+	t.Run("Accept による形式の選択", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			accept string
+			format httperrors.Format
+			want   string
+		}{
+			{"指定なしは設定の形式（従来形式）", "", httperrors.FormatLegacy, "application/json"},
+			{"指定なしは設定の形式（Problem Details）", "", httperrors.FormatProblem, "application/problem+json"},
+			{"ワイルドカードは設定の形式", "*/*", httperrors.FormatProblem, "application/problem+json"},
+			{"application/json のみは従来形式", "application/json", httperrors.FormatProblem, "application/json"},
+			{"優先度が同じなら Problem Details", "application/json, application/problem+json", httperrors.FormatLegacy, "application/problem+json"},
+			{"application/json を優先", "application/problem+json;q=0.5, application/json", httperrors.FormatProblem, "application/json"},
+			{"q=0 の Problem Details は選ばない", "application/problem+json;q=0", httperrors.FormatLegacy, "application/json"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest(http.MethodGet, "/users", http.NoBody)
+				if tt.accept != "" {
+					r.Header.Set("Accept", tt.accept)
+				}
+				r = r.WithContext(httperrors.WithOptions(r.Context(), httperrors.Options{Format: tt.format}))
+
+				httperrors.WriteError(w, r, domain.ErrNotFound, nil)
+
+				assert.Equal(t, tt.want, w.Header().Get("Content-Type"))
+			})
+		}
+	})
+}
+
+func TestWriteError_Localized(t *testing.T) {
+	write := func(t *testing.T, err error) (*httptest.ResponseRecorder, httperrors.ErrorResponse) {
+		t.Helper()
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/users", http.NoBody)
+		r.Header.Set("Accept-Language", "ja-JP,ja;q=0.9,en;q=0.8")
+
+		httperrors.WriteError(w, r, err, nil)
+
+		var resp httperrors.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return w, resp
+	}
+
+	t.Run("Accept-Language の言語でメッセージを返す", func(t *testing.T) {
+		w, resp := write(t, domain.NotFound("user", "FindByID"))
+
+		assert.Equal(t, "ja", w.Header().Get("Content-Language"))
+		assert.Contains(t, w.Header().Values("Vary"), "Accept-Language")
+		assert.Equal(t, "NOT_FOUND", resp.Error.Code)
+		assert.Equal(t, "ユーザーが見つかりません", resp.Error.Message)
+	})
+
+	t.Run("項目ごとのバリデーションエラーを翻訳する", func(t *testing.T) {
+		var ve valueobject.ValidationErrors
+		ve.Add("email", valueobject.ErrEmailInvalid)
+		ve.Add("name", valueobject.ErrNameTooLong)
+
+		_, resp := write(t, ve)
+
+		assert.Equal(t, "VALIDATION_ERROR", resp.Error.Code)
+		assert.Equal(t, "入力内容に誤りがあります", resp.Error.Message)
+		assert.Equal(t, []httperrors.FieldError{
+			{Field: "email", Code: "invalid_format", Message: "メールアドレスの形式が正しくありません"},
+			{Field: "name", Code: "too_long", Message: "名前は100文字以内で入力してください"},
+		}, resp.Error.Details)
+	})
+
+	t.Run("値オブジェクトのエラーを翻訳する", func(t *testing.T) {
+		_, resp := write(t, valueobject.ErrEmailInvalid)
+
+		assert.Equal(t, "VALIDATION_ERROR", resp.Error.Code)
+		assert.Equal(t, "メールアドレスの形式が正しくありません", resp.Error.Message)
+	})
+
+	t.Run("リクエストボディの解析エラーを翻訳する", func(t *testing.T) {
+		_, resp := write(t, &request.BodyError{
+			Code:     request.CodeInvalidType,
+			Field:    "name",
+			Offset:   12,
+			Expected: "string",
+			Actual:   "number",
+		})
+
+		assert.Equal(t, "INVALID_BODY", resp.Error.Code)
+		require.Len(t, resp.Error.Details, 1)
+		assert.Equal(t, "名前は文字列で指定してください（数値が指定されています、12バイト目）", resp.Error.Details[0].Message)
+	})
+
+	t.Run("内部エラーも翻訳したうえで詳細を隠蔽する", func(t *testing.T) {
+		_, resp := write(t, errors.New("database connection failed"))
+
+		assert.Equal(t, "INTERNAL_ERROR", resp.Error.Code)
+		assert.Equal(t, "サーバーでエラーが発生しました", resp.Error.Message)
+	})
+
+	t.Run("指定がなければ英語で返す", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+
+		httperrors.WriteError(w, r, request.ErrBodyTooLarge, nil)
+
+		var resp httperrors.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "en", w.Header().Get("Content-Language"))
+		assert.Equal(t, request.ErrBodyTooLarge.Error(), resp.Error.Message)
+	})
 }
@@ -0,0 +1,47 @@
+package httperrors
+
+import (
+	"net/http"
+	"sync"
+
+	"go-api/internal/domain"
+	"go-api/internal/presentation/http/request"
+)
+
+// categoryStatus はドメインのエラーの分類に対応するHTTPステータスコード。
+var categoryStatus = map[domain.Category]int{
+	domain.CategoryInvalidInput: http.StatusBadRequest,
+	domain.CategoryUnauthorized: http.StatusUnauthorized,
+	domain.CategoryForbidden:    http.StatusForbidden,
+	domain.CategoryNotFound:     http.StatusNotFound,
+	domain.CategoryConflict:     http.StatusConflict,
+}
+
+// newDefaultRegistry はHTTP層のエラーを登録した Registry を生成する。
+// ドメインのエラーは各パッケージが domain.Define で宣言したものを registerDomainKinds で登録する。
+func newDefaultRegistry() *Registry {
+	r := NewRegistry()
+	r.Register(ErrTooManyRequests, Mapping{http.StatusTooManyRequests, "RATE_LIMITED", "too many requests", "error.RATE_LIMITED"})
+	r.Register(request.ErrInvalidBody, Mapping{http.StatusBadRequest, "INVALID_BODY", "invalid request body", "error.INVALID_BODY"})
+	r.Register(request.ErrBodyTooLarge, Mapping{http.StatusRequestEntityTooLarge, "PAYLOAD_TOO_LARGE", request.ErrBodyTooLarge.Error(), "error.PAYLOAD_TOO_LARGE"})
+	r.Register(request.ErrUnsupportedMediaType, Mapping{http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE", request.ErrUnsupportedMediaType.Error(), "error.UNSUPPORTED_MEDIA_TYPE"})
+	return r
+}
+
+// registerDomainKinds は domain.Define で宣言されたエラーの種類を defaultRegistry に登録する。
+// 全てのパッケージの初期化が済んだ後に宣言を読むよう、最初の Lookup・Registered の呼び出し時に実行する。
+var registerDomainKinds = sync.OnceFunc(func() {
+	for _, k := range domain.Kinds() {
+		defaultRegistry.Register(k.Err, kindMapping(k))
+	}
+})
+
+// kindMapping はドメインのエラーの種類に対応するHTTPレスポンスを返す。
+// 分類に対応するステータスがない場合は 500 とする。
+func kindMapping(k domain.Kind) Mapping {
+	status, ok := categoryStatus[k.Category]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+	return Mapping{Status: status, Code: k.Code, Message: k.Err.Error(), MessageKey: k.MessageKey}
+}
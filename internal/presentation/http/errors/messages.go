@@ -0,0 +1,79 @@
+package httperrors
+
+import (
+	"errors"
+	"strconv"
+
+	"go-api/internal/domain"
+	"go-api/internal/domain/user/valueobject"
+	"go-api/internal/presentation/http/i18n"
+	"go-api/internal/presentation/http/request"
+)
+
+// Message はエラーの利用者向けメッセージを tr の言語で返す。
+// HTTP以外（gRPC等）でも WriteError と同じメッセージを返すために使う。
+func Message(tr *i18n.Translator, err error) string {
+	var ve valueobject.ValidationErrors
+	if errors.As(err, &ve) {
+		return translate(tr, "error.VALIDATION_ERROR", "validation error")
+	}
+	return localizedMessage(tr, err, Lookup(err))
+}
+
+// localizedMessage はエラーの利用者向けメッセージを tr の言語で返す。カタログにない場合は m.Message を返す。
+func localizedMessage(tr *i18n.Translator, err error, m Mapping) string {
+	// エンティティを特定できるエラーは「ユーザーが見つかりません」のようにエンティティ名を含める
+	var de *domain.DomainError
+	if m.Status < 500 && errors.As(err, &de) && de.Entity != "" {
+		if msg, ok := tr.T(m.MessageKey+".entity", translate(tr, "entity."+de.Entity, de.Entity)); ok {
+			return msg
+		}
+	}
+
+	var params []string
+	switch {
+	case errors.Is(err, request.ErrBodyTooLarge):
+		params = append(params, strconv.Itoa(request.MaxBodyBytes))
+	case errors.Is(err, valueobject.ErrNameTooLong):
+		params = append(params, strconv.Itoa(valueobject.MaxUserNameLength))
+	case errors.Is(err, valueobject.ErrEmailTooLong):
+		params = append(params, strconv.Itoa(valueobject.MaxEmailLength))
+	}
+	return translate(tr, m.MessageKey, m.Message, params...)
+}
+
+// bodyErrorMessage はリクエストボディの解析エラーのメッセージを tr の言語で返す。
+func bodyErrorMessage(tr *i18n.Translator, be *request.BodyError) string {
+	offset := strconv.FormatInt(be.Offset, 10)
+	key := "body." + be.Code
+	switch be.Code {
+	case request.CodeSyntaxError:
+		return translate(tr, key, be.Message, offset)
+	case request.CodeInvalidType:
+		field := be.Field
+		if field == "" {
+			field = translate(tr, "field.request_body", "request body")
+		} else {
+			field = fieldName(tr, field)
+		}
+		expected := translate(tr, "type."+be.Expected, be.Expected)
+		actual := translate(tr, "type."+be.Actual, be.Actual)
+		return translate(tr, key, be.Message, field, expected, actual, offset)
+	case request.CodeUnknownField:
+		return translate(tr, key, be.Message, be.Field, offset)
+	default:
+		return translate(tr, key, be.Message)
+	}
+}
+
+// fieldName はフィールド名を tr の言語の表示名にする。カタログにない場合はそのまま返す。
+func fieldName(tr *i18n.Translator, field string) string {
+	return translate(tr, "field."+field, field)
+}
+
+func translate(tr *i18n.Translator, key, fallback string, params ...string) string {
+	if msg, ok := tr.T(key, params...); ok {
+		return msg
+	}
+	return fallback
+}
@@ -0,0 +1,105 @@
+package httperrors
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ProblemContentType は Problem Details（RFC 9457）のメディアタイプ。
+const ProblemContentType = "application/problem+json"
+
+// DefaultProblemTypeBaseURI は Problem Details の type を組み立てるデフォルトのベースURI。
+const DefaultProblemTypeBaseURI = "/problems/"
+
+// Format はエラーレスポンスの形式。
+type Format string
+
+const (
+	// FormatLegacy は {"error":{"code","message","details"}} 形式。
+	FormatLegacy Format = "legacy"
+	// FormatProblem は Problem Details（application/problem+json）形式。
+	FormatProblem Format = "problem"
+)
+
+// Problem は Problem Details（RFC 9457）のJSON構造。
+// code・request_id・errors は拡張メンバー。
+type Problem struct {
+	Type      string       `json:"type"`               // エラーの種類を識別するURI（エラーコードごと）
+	Title     string       `json:"title"`              // ステータスの概要
+	Status    int          `json:"status"`             // HTTPステータスコード
+	Detail    string       `json:"detail,omitempty"`   // このエラーの説明
+	Instance  string       `json:"instance,omitempty"` // エラーが発生したリクエストのパス
+	Code      string       `json:"code"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// Options はエラーレスポンスの形式の設定。
+type Options struct {
+	// Format は Accept ヘッダーで形式が決まらない場合に使う形式。空の場合は FormatLegacy。
+	Format Format
+	// ProblemTypeBaseURI は Problem Details の type の接頭辞。空の場合は DefaultProblemTypeBaseURI。
+	ProblemTypeBaseURI string
+}
+
+type optionsKey struct{}
+
+// WithOptions はエラーレスポンスの形式の設定をコンテキストに格納する。
+func WithOptions(ctx context.Context, opts Options) context.Context {
+	return context.WithValue(ctx, optionsKey{}, opts)
+}
+
+func optionsFrom(ctx context.Context) Options {
+	opts, _ := ctx.Value(optionsKey{}).(Options)
+	if opts.Format == "" {
+		opts.Format = FormatLegacy
+	}
+	if opts.ProblemTypeBaseURI == "" {
+		opts.ProblemTypeBaseURI = DefaultProblemTypeBaseURI
+	}
+	return opts
+}
+
+// negotiate は Accept ヘッダーから形式を決める。
+// application/problem+json を application/json 以上の優先度で受け付ける場合は Problem Details、
+// application/json のみを明示している場合は従来形式、どちらもなければ def を返す。
+func negotiate(r *http.Request, def Format) Format {
+	problemQ, jsonQ := -1.0, -1.0
+	for _, v := range r.Header.Values("Accept") {
+		for part := range strings.SplitSeq(v, ",") {
+			mediaType, params, err := mime.ParseMediaType(part)
+			if err != nil {
+				continue
+			}
+			q := 1.0
+			if s, ok := params["q"]; ok {
+				if q, err = strconv.ParseFloat(s, 64); err != nil {
+					continue
+				}
+			}
+			switch mediaType {
+			case ProblemContentType:
+				problemQ = max(problemQ, q)
+			case "application/json":
+				jsonQ = max(jsonQ, q)
+			}
+		}
+	}
+
+	switch {
+	case problemQ > 0 && problemQ >= jsonQ:
+		return FormatProblem
+	case jsonQ > 0:
+		return FormatLegacy
+	default:
+		return def
+	}
+}
+
+// problemType はエラーコードに対応する type のURIを返す（例: NOT_FOUND → /problems/not-found）。
+func problemType(base, code string) string {
+	return base + strings.ReplaceAll(strings.ToLower(code), "_", "-")
+}
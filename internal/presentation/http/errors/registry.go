@@ -0,0 +1,106 @@
+package httperrors
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// Mapping はエラーに対応するHTTPレスポンスの内容。
+type Mapping struct {
+	Status int    // HTTPステータスコード
+	Code   string // エラーコード（機械可読、言語によらず一定）
+	// Message は利用者に返してよいメッセージ（英語）。エラー自体の文言は内部の情報を含み得るため使わない。
+	Message string
+	// MessageKey は Message を翻訳するためのカタログ（i18n）のキー。
+	MessageKey string
+}
+
+// internalError は登録されていないエラーの対応。
+var internalError = Mapping{
+	Status:     http.StatusInternalServerError,
+	Code:       "INTERNAL_ERROR",
+	Message:    "internal server error",
+	MessageKey: "error.INTERNAL_ERROR",
+}
+
+// Registry はエラーとHTTPレスポンスの対応を保持する。
+// errors.Is で判定するため、ラップされたエラーや Is を実装したエラー（domain.DomainError 等）にも対応する。
+type Registry struct {
+	mu      sync.RWMutex
+	entries []registryEntry
+}
+
+type registryEntry struct {
+	err     error
+	mapping Mapping
+}
+
+// NewRegistry は空の Registry を生成する。
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register は err に対応するHTTPレスポンスを登録する。
+// 既に登録されたエラーとしても判定されるエラー（例: domain.ErrInvalidInput として判定される値オブジェクトのエラー）は
+// より具体的な対応として優先する。
+func (r *Registry) Register(err error, m Mapping) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := len(r.entries)
+	for j, e := range r.entries {
+		if errors.Is(err, e.err) {
+			i = j
+			break
+		}
+	}
+	r.entries = append(r.entries[:i], append([]registryEntry{{err: err, mapping: m}}, r.entries[i:]...)...)
+}
+
+// Lookup は err に対応するHTTPレスポンスを返す。登録されていないエラーは 500 INTERNAL_ERROR とする。
+func (r *Registry) Lookup(err error) Mapping {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, e := range r.entries {
+		if errors.Is(err, e.err) {
+			return e.mapping
+		}
+	}
+	return internalError
+}
+
+// Registered は err 自体が登録されているかを返す。
+func (r *Registry) Registered(err error) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, e := range r.entries {
+		if e.err == err {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultRegistry は WriteError 等が使う Registry。
+var defaultRegistry = newDefaultRegistry()
+
+// Register は WriteError 等が使う Registry に err の対応を登録する。
+// 起動時（init 等）に呼び出すこと。
+func Register(err error, m Mapping) {
+	defaultRegistry.Register(err, m)
+}
+
+// Lookup は WriteError 等が使う Registry から err に対応するHTTPレスポンスを返す。
+func Lookup(err error) Mapping {
+	registerDomainKinds()
+	return defaultRegistry.Lookup(err)
+}
+
+// Registered は err が WriteError 等が使う Registry に登録されているかを返す。
+func Registered(err error) bool {
+	registerDomainKinds()
+	return defaultRegistry.Registered(err)
+}
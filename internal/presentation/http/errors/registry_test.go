@@ -0,0 +1,145 @@
+package httperrors_test
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/domain"
+	"go-api/internal/domain/user/valueobject"
+	httperrors "go-api/internal/presentation/http/errors"
+)
+
+func TestRegistry(t *testing.T) {
+	errKind := errors.New("kind")
+	errSpecific := fmt.Errorf("specific: %w", errKind)
+
+	t.Run("登録されていないエラーは500を返す", func(t *testing.T) {
+		r := httperrors.NewRegistry()
+
+		m := r.Lookup(errors.New("unknown"))
+		assert.Equal(t, http.StatusInternalServerError, m.Status)
+		assert.Equal(t, "INTERNAL_ERROR", m.Code)
+		assert.Equal(t, "internal server error", m.Message)
+	})
+
+	t.Run("ラップされたエラーも判定する", func(t *testing.T) {
+		r := httperrors.NewRegistry()
+		r.Register(errKind, httperrors.Mapping{Status: http.StatusBadRequest, Code: "KIND"})
+
+		assert.Equal(t, "KIND", r.Lookup(fmt.Errorf("op: %w", errKind)).Code)
+	})
+
+	t.Run("具体的なエラーは登録順によらず優先する", func(t *testing.T) {
+		for name, order := range map[string][]error{
+			"一般的なエラーが先": {errKind, errSpecific},
+			"具体的なエラーが先": {errSpecific, errKind},
+		} {
+			t.Run(name, func(t *testing.T) {
+				r := httperrors.NewRegistry()
+				for _, err := range order {
+					code := "KIND"
+					if err == errSpecific {
+						code = "SPECIFIC"
+					}
+					r.Register(err, httperrors.Mapping{Status: http.StatusBadRequest, Code: code})
+				}
+
+				assert.Equal(t, "SPECIFIC", r.Lookup(errSpecific).Code)
+				assert.Equal(t, "KIND", r.Lookup(errKind).Code)
+			})
+		}
+	})
+
+	t.Run("DomainError は種類のエラーとして判定する", func(t *testing.T) {
+		m := httperrors.Lookup(domain.Conflict("user", "Save", errors.New("duplicate key")))
+
+		assert.Equal(t, http.StatusConflict, m.Status)
+		assert.Equal(t, "CONFLICT", m.Code)
+	})
+
+	t.Run("値オブジェクトのエラーは ErrInvalidInput より優先する", func(t *testing.T) {
+		m := httperrors.Lookup(valueobject.ErrNameTooLong)
+
+		assert.Equal(t, "user.name_too_long", m.MessageKey)
+	})
+}
+
+func TestRegistry_DomainSentinels(t *testing.T) {
+	sentinels := exportedSentinels(t, "../../../domain")
+
+	t.Run("internal/domain 配下の公開エラーは全て domain.Define で宣言されている", func(t *testing.T) {
+		for name, defined := range sentinels {
+			assert.True(t, defined, "%s must be declared with domain.Define", name)
+		}
+		assert.Len(t, domain.Kinds(), len(sentinels), "宣言された公開エラーと登録された種類の数が一致するべき")
+	})
+
+	t.Run("internal/domain 配下の公開エラーは全て登録されている", func(t *testing.T) {
+		for _, k := range domain.Kinds() {
+			assert.True(t, httperrors.Registered(k.Err), "%q is not registered in httperrors", k.Err)
+			assert.NotEqual(t, http.StatusInternalServerError, httperrors.Lookup(k.Err).Status, k.Err.Error())
+		}
+	})
+}
+
+// exportedSentinels は dir 配下のパッケージで宣言されている公開の Err* 変数を "パッケージ名.変数名" をキーに返す。
+// 値は domain.Define（または同じパッケージの define）の呼び出しで初期化されているか。
+func exportedSentinels(t *testing.T, dir string) map[string]bool {
+	t.Helper()
+	sentinels := make(map[string]bool)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return err
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				vs := spec.(*ast.ValueSpec)
+				for i, ident := range vs.Names {
+					if ident.IsExported() && strings.HasPrefix(ident.Name, "Err") {
+						sentinels[f.Name.Name+"."+ident.Name] = i < len(vs.Values) && isDefineCall(vs.Values[i])
+					}
+				}
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, sentinels)
+	return sentinels
+}
+
+// isDefineCall は expr が Define・domain.Define・define の呼び出しかを返す。
+func isDefineCall(expr ast.Expr) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	switch fn := call.Fun.(type) {
+	case *ast.Ident:
+		return fn.Name == "Define" || fn.Name == "define"
+	case *ast.SelectorExpr:
+		pkg, ok := fn.X.(*ast.Ident)
+		return ok && pkg.Name == "domain" && fn.Sel.Name == "Define"
+	default:
+		return false
+	}
+}
@@ -0,0 +1,67 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"go-api/internal/health"
+)
+
+// detailResponse はチェックごとの結果を含むJSONレスポンス。
+type detailResponse struct {
+	Status string        `json:"status"`
+	Checks []checkResult `json:"checks"`
+}
+
+type checkResult struct {
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	LatencyMS   float64    `json:"latency_ms"`
+	CheckedAt   time.Time  `json:"checked_at"`
+	Error       string     `json:"error,omitempty"`
+	LastError   string     `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+}
+
+func newDetailResponse(report health.Report) detailResponse {
+	checks := make([]checkResult, len(report.Checks))
+	for i, res := range report.Checks {
+		checks[i] = checkResult{
+			Name:      res.Name,
+			Status:    string(res.Status),
+			LatencyMS: float64(res.Latency.Microseconds()) / 1000,
+			CheckedAt: res.CheckedAt,
+		}
+		if res.Err != nil {
+			checks[i].Error = res.Err.Error()
+		}
+		if res.LastErr != nil {
+			lastErrAt := res.LastErrAt
+			checks[i].LastError = res.LastErr.Error()
+			checks[i].LastErrorAt = &lastErrAt
+		}
+	}
+	return detailResponse{Status: string(report.Status), Checks: checks}
+}
+
+// DetailHandler はチェックごとの状態・レイテンシ・最後のエラーを返すハンドラー。
+// エラーの内容を含むため管理者認証の内側に配置する。
+type DetailHandler struct {
+	registry *health.Registry
+}
+
+// NewDetailHandler は DetailHandler を生成する。
+func NewDetailHandler(registry *health.Registry) *DetailHandler {
+	return &DetailHandler{registry: registry}
+}
+
+// ServeHTTP はチェックごとの結果を返す。ステータスコードは ReadyHandler と同じ。
+// GET /health?verbose
+func (h *DetailHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	report := h.registry.Check(r.Context())
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode(report.Status))
+	_ = json.NewEncoder(w).Encode(newDetailResponse(report))
+}
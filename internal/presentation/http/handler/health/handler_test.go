@@ -0,0 +1,98 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/health"
+	handler "go-api/internal/presentation/http/handler/health"
+)
+
+func newRegistry(dbErr error) (*health.Registry, *health.Readiness) {
+	readiness := health.NewReadiness()
+	r := health.NewRegistry(readiness, health.Options{Timeout: time.Second})
+	r.Register("database", func(context.Context) error { return dbErr })
+	return r, readiness
+}
+
+func TestLiveHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	handler.NewLiveHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/livez", http.NoBody))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+}
+
+func TestReadyHandler(t *testing.T) {
+	t.Run("チェックが成功した場合は200", func(t *testing.T) {
+		registry, _ := newRegistry(nil)
+		rec := httptest.NewRecorder()
+
+		handler.NewReadyHandler(registry).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", http.NoBody))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+	})
+
+	t.Run("チェックが失敗した場合は503", func(t *testing.T) {
+		registry, _ := newRegistry(errors.New("connection refused"))
+		rec := httptest.NewRecorder()
+
+		handler.NewReadyHandler(registry).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", http.NoBody))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		// エラーの内容は公開しない
+		assert.JSONEq(t, `{"status":"unavailable"}`, rec.Body.String())
+	})
+
+	t.Run("停止処理中は503", func(t *testing.T) {
+		registry, readiness := newRegistry(nil)
+		readiness.SetShuttingDown()
+		rec := httptest.NewRecorder()
+
+		handler.NewReadyHandler(registry).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", http.NoBody))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.JSONEq(t, `{"status":"shutting_down"}`, rec.Body.String())
+	})
+}
+
+func TestDetailHandler(t *testing.T) {
+	registry, _ := newRegistry(errors.New("connection refused"))
+	rec := httptest.NewRecorder()
+
+	handler.NewDetailHandler(registry).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health?verbose", http.NoBody))
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	var resp struct {
+		Status string `json:"status"`
+		Checks []struct {
+			Name        string     `json:"name"`
+			Status      string     `json:"status"`
+			LatencyMS   *float64   `json:"latency_ms"`
+			Error       string     `json:"error"`
+			LastError   string     `json:"last_error"`
+			LastErrorAt *time.Time `json:"last_error_at"`
+		} `json:"checks"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "unavailable", resp.Status)
+	require.Len(t, resp.Checks, 1)
+	check := resp.Checks[0]
+	assert.Equal(t, "database", check.Name)
+	assert.Equal(t, "unavailable", check.Status)
+	assert.NotNil(t, check.LatencyMS)
+	assert.Equal(t, "connection refused", check.Error)
+	assert.Equal(t, "connection refused", check.LastError)
+	assert.NotNil(t, check.LastErrorAt)
+}
@@ -0,0 +1,22 @@
+// Package health はヘルスチェックのHTTPハンドラーを提供する。
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// LiveHandler はプロセスが応答できることを示すハンドラー。依存先の状態は確認しない。
+type LiveHandler struct{}
+
+// NewLiveHandler は LiveHandler を生成する。
+func NewLiveHandler() *LiveHandler {
+	return &LiveHandler{}
+}
+
+// ServeHTTP は常に200を返す。
+// GET /livez
+func (h *LiveHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(statusResponse{Status: "ok"})
+}
@@ -0,0 +1,40 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go-api/internal/health"
+)
+
+// statusResponse はヘルスチェックの概要のJSONレスポンス。
+type statusResponse struct {
+	Status string `json:"status"`
+}
+
+// ReadyHandler はトラフィックを受け付けられるかを返すハンドラー。
+type ReadyHandler struct {
+	registry *health.Registry
+}
+
+// NewReadyHandler は ReadyHandler を生成する。
+func NewReadyHandler(registry *health.Registry) *ReadyHandler {
+	return &ReadyHandler{registry: registry}
+}
+
+// ServeHTTP は全チェックが成功していれば200、失敗または停止処理中であれば503を返す。
+// GET /readyz
+func (h *ReadyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	report := h.registry.Check(r.Context())
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode(report.Status))
+	_ = json.NewEncoder(w).Encode(statusResponse{Status: string(report.Status)})
+}
+
+func statusCode(s health.Status) int {
+	if s == health.StatusOK {
+		return http.StatusOK
+	}
+	return http.StatusServiceUnavailable
+}
@@ -17,6 +17,7 @@ import (
 	usecase "go-api/internal/application/user"
 	"go-api/internal/domain/user/mocks"
 	handler "go-api/internal/presentation/http/handler/user"
+	"go-api/internal/presentation/http/request"
 )
 
 func TestCreateHandler(t *testing.T) {
@@ -62,6 +63,81 @@ func TestCreateHandler(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
 	})
 
+	t.Run("Content-Type がJSONでない場合は415エラーを返す", func(t *testing.T) {
+		uc := usecase.NewCreateUserUsecase(nil)
+		h := handler.NewCreateHandler(uc, logger)
+
+		body := `{"name": "test", "email": "test@example.com"}`
+		req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+		req.Header.Set("Content-Type", "text/plain")
+		rec := httptest.NewRecorder()
+
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	})
+
+	t.Run("ボディが上限を超える場合は413エラーを返す", func(t *testing.T) {
+		uc := usecase.NewCreateUserUsecase(nil)
+		h := handler.NewCreateHandler(uc, logger)
+
+		body := `{"name": "` + strings.Repeat("a", request.MaxBodyBytes) + `", "email": "test@example.com"}`
+		req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	})
+
+	t.Run("未知のフィールドを含む場合は400エラーを返す", func(t *testing.T) {
+		uc := usecase.NewCreateUserUsecase(nil)
+		h := handler.NewCreateHandler(uc, logger)
+
+		body := `{"name": "test", "email": "test@example.com", "admin": true}`
+		req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var errResp map[string]interface{}
+		err := json.NewDecoder(rec.Body).Decode(&errResp)
+		require.NoError(t, err)
+
+		errorObj := errResp["error"].(map[string]interface{})
+		assert.Equal(t, "INVALID_BODY", errorObj["code"])
+		details := errorObj["details"].([]interface{})
+		assert.Equal(t, "admin", details[0].(map[string]interface{})["field"])
+	})
+
+	t.Run("値オブジェクトと同じルールで検証する", func(t *testing.T) {
+		uc := usecase.NewCreateUserUsecase(nil)
+		h := handler.NewCreateHandler(uc, logger)
+
+		// ドメインのないアドレスは Email の形式を満たさない
+		body := `{"name": "test", "email": "test@localhost"}`
+		req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var errResp map[string]interface{}
+		err := json.NewDecoder(rec.Body).Decode(&errResp)
+		require.NoError(t, err)
+
+		details := errResp["error"].(map[string]interface{})["details"].([]interface{})
+		require.Len(t, details, 1)
+		assert.Equal(t, "email", details[0].(map[string]interface{})["field"])
+		assert.Equal(t, "invalid_format", details[0].(map[string]interface{})["code"])
+	})
+
 	t.Run("バリデーションエラーの場合は400エラーとフィールド詳細を返す", func(t *testing.T) {
 		uc := usecase.NewCreateUserUsecase(nil)
 		h := handler.NewCreateHandler(uc, logger)
@@ -0,0 +1,295 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-api/internal/application/user"
+	"go-api/internal/domain"
+	domainuser "go-api/internal/domain/user"
+	"go-api/internal/domain/user/valueobject"
+	httperrors "go-api/internal/presentation/http/errors"
+)
+
+// EventsHandler のデフォルト値。
+const (
+	DefaultEventsHeartbeatInterval = 15 * time.Second
+	DefaultEventsBufferSize        = 64
+	DefaultEventsWriteTimeout      = 10 * time.Second
+)
+
+// EventsOptions は EventsHandler の設定。
+type EventsOptions struct {
+	// HeartbeatInterval はイベントがない間にコメント行を送る間隔。プロキシによる無通信の切断を防ぐ。
+	HeartbeatInterval time.Duration
+	// BufferSize は接続ごとに送信待ちにできるイベントの件数。超えた場合は接続を終了し、再接続で続きから配信する。
+	BufferSize int
+	// WriteTimeout は1回の書き込みの上限。サーバーの WriteTimeout の代わりに書き込みごとに適用する。
+	WriteTimeout time.Duration
+	// Shutdown は停止処理の開始時に閉じられるチャネル。閉じられたら配信を終了する。
+	Shutdown <-chan struct{}
+}
+
+// resetEvent は Last-Event-ID のイベントが保持期間を過ぎて削除され、続きを配信できないことを知らせるイベントの種類。
+// クライアントは受け取ったら状態を取得し直す。以降は接続した時点より後のイベントを配信する。
+const resetEvent = "reset"
+
+// eventResponse はイベントの data に送るJSON。
+type eventResponse struct {
+	Type       string            `json:"type"`
+	User       eventResponseUser `json:"user"`
+	OccurredAt time.Time         `json:"occurred_at"`
+}
+
+type eventResponseUser struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// EventsHandler はユーザーの変更イベントを Server-Sent Events で配信するHTTPハンドラー。
+type EventsHandler struct {
+	feed   *user.EventFeed
+	opts   EventsOptions
+	logger *slog.Logger
+}
+
+// NewEventsHandler は EventsHandler を生成する。
+func NewEventsHandler(feed *user.EventFeed, opts EventsOptions, logger *slog.Logger) *EventsHandler {
+	if opts.HeartbeatInterval <= 0 {
+		opts.HeartbeatInterval = DefaultEventsHeartbeatInterval
+	}
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = DefaultEventsBufferSize
+	}
+	if opts.WriteTimeout <= 0 {
+		opts.WriteTimeout = DefaultEventsWriteTimeout
+	}
+	return &EventsHandler{
+		feed:   feed,
+		opts:   opts,
+		logger: logger,
+	}
+}
+
+// ServeHTTP はユーザーの変更イベントを配信する。
+// GET /users/events?ids=...&types=...
+// Last-Event-ID ヘッダーがあればそのイベントより後から、なければ接続した時点より後のイベントを配信する。
+// Last-Event-ID のイベントが見つからない場合は reset イベントを送ってから、接続した時点より後のイベントを配信する。
+// クライアントの切断、送信待ちの上限超過、停止処理の開始のいずれかで終了する。
+func (h *EventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter, err := parseEventFilter(r)
+	if err != nil {
+		httperrors.WriteError(w, r, err, h.logger)
+		return
+	}
+
+	after, reset, err := h.resumePosition(ctx, r.Header.Get("Last-Event-ID"))
+	if err != nil {
+		httperrors.WriteError(w, r, err, h.logger)
+		return
+	}
+
+	// 記録済みのイベントを送っている間に記録されたイベントを取りこぼさないよう、先に購読を始める
+	sub := h.feed.Subscribe(after, filter, h.opts.BufferSize)
+	defer sub.Close()
+
+	s := &eventStream{w: w, rc: http.NewResponseController(w), timeout: h.opts.WriteTimeout}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // リバースプロキシにバッファリングさせない
+	w.WriteHeader(http.StatusOK)
+	if err := s.flush(); err != nil {
+		return
+	}
+	if reset {
+		if err := s.reset(after.Seq); err != nil {
+			return
+		}
+	}
+
+	sent, err := h.replay(r, s, after, filter)
+	if err != nil {
+		if ctx.Err() == nil {
+			h.logger.ErrorContext(ctx, "failed to replay user events", "error", err.Error())
+		}
+		return
+	}
+
+	heartbeat := time.NewTicker(h.opts.HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.opts.Shutdown:
+			return
+		case e, ok := <-sub.Events():
+			if !ok {
+				if err := sub.Err(); err != nil {
+					h.logger.WarnContext(ctx, "user event stream closed", "reason", err.Error())
+				}
+				return
+			}
+			if !sent.Before(e.Position) {
+				continue // 記録済みのイベントとして送信済み
+			}
+			if err := s.event(e); err != nil {
+				return
+			}
+			sent = e.Position
+		case <-heartbeat.C:
+			if err := s.comment("heartbeat"); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// resumePosition は Last-Event-ID ヘッダーの値から配信を始める位置を返す。
+// ヘッダーがない場合は最新の位置を返す。そのイベントが見つからない場合は最新の位置と reset に true を返す。
+func (h *EventsHandler) resumePosition(ctx context.Context, lastEventID string) (_ domainuser.EventPosition, reset bool, _ error) {
+	seq, err := parseLastEventID(lastEventID)
+	if err != nil {
+		return domainuser.EventPosition{}, false, err
+	}
+	if seq >= 0 {
+		pos, found, err := h.feed.Position(ctx, seq)
+		if err != nil || found {
+			return pos, false, err
+		}
+		reset = true
+	}
+	latest, err := h.feed.Latest(ctx)
+	return latest, reset, err
+}
+
+// replay は after より後の記録済みのイベントを全て送り、送った最後の位置を返す。
+func (h *EventsHandler) replay(r *http.Request, s *eventStream, after domainuser.EventPosition, filter user.EventFilter) (domainuser.EventPosition, error) {
+	for {
+		output, err := h.feed.Replay(r.Context(), after, filter)
+		if err != nil {
+			return after, err
+		}
+		for _, e := range output.Events {
+			if err := s.event(e); err != nil {
+				return after, err
+			}
+		}
+		after = output.Last
+		if !output.More {
+			return after, nil
+		}
+	}
+}
+
+// parseEventFilter はクエリパラメーターから配信するイベントの条件を生成する。
+// ids・types はカンマ区切りか、パラメーターの繰り返しで複数指定できる。
+func parseEventFilter(r *http.Request) (user.EventFilter, error) {
+	var filter user.EventFilter
+	var errs valueobject.ValidationErrors
+	q := r.URL.Query()
+	for _, v := range splitValues(q["ids"]) {
+		id, err := valueobject.ParseUserID(v)
+		errs.Add("ids", err)
+		filter.UserIDs = append(filter.UserIDs, id)
+	}
+	for _, v := range splitValues(q["types"]) {
+		typ, err := valueobject.ParseEventType(v)
+		errs.Add("types", err)
+		filter.Types = append(filter.Types, typ)
+	}
+	return filter, errs.Err()
+}
+
+func splitValues(values []string) []string {
+	var out []string
+	for _, v := range values {
+		for part := range strings.SplitSeq(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				out = append(out, part)
+			}
+		}
+	}
+	return out
+}
+
+// parseLastEventID は Last-Event-ID ヘッダーの値を返す。ヘッダーがない場合は -1 を返す。
+func parseLastEventID(v string) (int64, error) {
+	if v == "" {
+		return -1, nil
+	}
+	seq, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || seq < 0 {
+		return 0, &domain.DomainError{Kind: domain.ErrInvalidInput, Entity: "user", Op: "StreamUserEvents", Message: "invalid Last-Event-ID"}
+	}
+	return seq, nil
+}
+
+// eventStream は text/event-stream 形式で書き込む。
+// 書き込みごとに期限を延ばし、サーバーの WriteTimeout で長時間の接続が切断されないようにする。
+type eventStream struct {
+	w       http.ResponseWriter
+	rc      *http.ResponseController
+	timeout time.Duration
+}
+
+func (s *eventStream) event(e user.EventDTO) error {
+	data, err := json.Marshal(eventResponse{
+		Type: e.Type,
+		User: eventResponseUser{
+			ID:    e.User.ID,
+			Name:  e.User.Name,
+			Email: e.User.Email,
+		},
+		OccurredAt: e.OccurredAt,
+	})
+	if err != nil {
+		return err
+	}
+	return s.write(fmt.Sprintf("id: %d\nevent: %s\ndata: %s\n\n", e.Seq, e.Type, data))
+}
+
+// reset は reset イベントを送る。id を再開する位置の Seq にし、再接続で再び reset を受け取らないようにする。
+func (s *eventStream) reset(seq int64) error {
+	return s.write(fmt.Sprintf("id: %d\nevent: %s\ndata: {\"type\":%q}\n\n", seq, resetEvent, resetEvent))
+}
+
+func (s *eventStream) comment(text string) error {
+	return s.write(": " + text + "\n\n")
+}
+
+func (s *eventStream) write(msg string) error {
+	if err := s.extendDeadline(); err != nil {
+		return err
+	}
+	if _, err := s.w.Write([]byte(msg)); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+func (s *eventStream) flush() error {
+	if err := s.extendDeadline(); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
+
+func (s *eventStream) extendDeadline() error {
+	err := s.rc.SetWriteDeadline(time.Now().Add(s.timeout))
+	if errors.Is(err, http.ErrNotSupported) {
+		return nil // httptest.ResponseRecorder 等の期限を設定できない ResponseWriter
+	}
+	return err
+}
@@ -0,0 +1,330 @@
+package user_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	usecase "go-api/internal/application/user"
+	"go-api/internal/domain/user"
+	"go-api/internal/domain/user/mocks"
+	"go-api/internal/domain/user/valueobject"
+	httperrors "go-api/internal/presentation/http/errors"
+	handler "go-api/internal/presentation/http/handler/user"
+	"go-api/internal/testutil/factory"
+)
+
+// eventLog は記録済みのイベントを保持し、EventRepository のモックから返す。
+type eventLog struct {
+	mu     sync.Mutex
+	events []*user.Event
+}
+
+func (l *eventLog) append(events ...*user.Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, events...)
+}
+
+// startEventFeed は l のイベントを配信する EventFeed を起動する。
+func startEventFeed(t *testing.T, l *eventLog) *usecase.EventFeed {
+	t.Helper()
+	repo := mocks.NewMockEventRepository(t)
+	repo.EXPECT().FindAfter(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, after user.EventPosition, limit int) ([]*user.Event, error) {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			var found []*user.Event
+			for _, e := range l.events {
+				if after.Before(e.Position()) && len(found) < limit {
+					found = append(found, e)
+				}
+			}
+			return found, nil
+		}).Maybe()
+	repo.EXPECT().Latest(mock.Anything).
+		RunAndReturn(func(context.Context) (user.EventPosition, error) {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			if len(l.events) == 0 {
+				return user.EventPosition{}, nil
+			}
+			return l.events[len(l.events)-1].Position(), nil
+		}).Maybe()
+	repo.EXPECT().FindPosition(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, seq int64) (user.EventPosition, bool, error) {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			for _, e := range l.events {
+				if e.Seq == seq {
+					return e.Position(), true, nil
+				}
+			}
+			return user.EventPosition{}, false, nil
+		}).Maybe()
+
+	feed := usecase.NewEventFeed(repo, usecase.FeedOptions{PollInterval: time.Hour, BatchSize: 2})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		feed.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return feed
+}
+
+// sseMessage は受信した text/event-stream のメッセージ。
+type sseMessage struct {
+	ID      string
+	Event   string
+	Data    string
+	Comment string
+}
+
+// sseClient は text/event-stream のレスポンスを読む。
+type sseClient struct {
+	resp   *http.Response
+	reader *bufio.Reader
+}
+
+func connectEvents(t *testing.T, srv *httptest.Server, query, lastEventID string) *sseClient {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/users/events"+query, http.NoBody)
+	require.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	return &sseClient{resp: resp, reader: bufio.NewReader(resp.Body)}
+}
+
+// next は次のメッセージを読む。ストリームが終了した場合は io.EOF を返す。
+func (c *sseClient) next() (sseMessage, error) {
+	var msg sseMessage
+	for {
+		line, err := c.reader.ReadString('\n')
+		if err != nil {
+			return msg, err
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return msg, nil
+		}
+		field, value, _ := strings.Cut(line, ": ")
+		switch field {
+		case "":
+			msg.Comment = value
+		case "id":
+			msg.ID = value
+		case "event":
+			msg.Event = value
+		case "data":
+			msg.Data = value
+		}
+	}
+}
+
+// nextEvent はハートビートを読み飛ばして次のイベントを読む。
+func (c *sseClient) nextEvent(t *testing.T) sseMessage {
+	t.Helper()
+	for {
+		msg, err := c.next()
+		require.NoError(t, err)
+		if msg.Comment == "" {
+			return msg
+		}
+	}
+}
+
+func newEventsServer(t *testing.T, feed *usecase.EventFeed, opts handler.EventsOptions) *httptest.Server {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	srv := httptest.NewUnstartedServer(handler.NewEventsHandler(feed, opts, logger))
+	srv.Config.WriteTimeout = 100 * time.Millisecond
+	srv.Start()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestEventsHandler(t *testing.T) {
+	t.Run("Last-Event-IDより後の記録済みのイベントを送ってから新しいイベントを配信する", func(t *testing.T) {
+		u := factory.NewUser(factory.WithName("test"), factory.WithEmail("test@example.com"))
+		log := &eventLog{}
+		log.append(
+			factory.NewEvent(1, valueobject.EventUserCreated, u),
+			factory.NewEvent(2, valueobject.EventUserUpdated, u),
+			factory.NewEvent(3, valueobject.EventUserUpdated, u),
+		)
+		feed := startEventFeed(t, log)
+		srv := newEventsServer(t, feed, handler.EventsOptions{})
+
+		c := connectEvents(t, srv, "", "1")
+
+		msg := c.nextEvent(t)
+		assert.Equal(t, "2", msg.ID)
+		assert.Equal(t, "user.updated", msg.Event)
+		var data map[string]any
+		require.NoError(t, json.Unmarshal([]byte(msg.Data), &data))
+		assert.Equal(t, "user.updated", data["type"])
+		assert.Equal(t, map[string]any{"id": u.ID().String(), "name": "test", "email": "test@example.com"}, data["user"])
+		assert.Equal(t, "3", c.nextEvent(t).ID)
+
+		log.append(factory.NewEvent(4, valueobject.EventUserDeleted, u))
+		feed.Wake()
+
+		msg = c.nextEvent(t)
+		assert.Equal(t, "4", msg.ID)
+		assert.Equal(t, "user.deleted", msg.Event)
+	})
+
+	t.Run("Last-Event-IDのイベントより後にコミットされたイベントはSeqが小さくても配信する", func(t *testing.T) {
+		u := factory.NewUser()
+		// Seq 1 を採番したトランザクションが、Seq 2 のトランザクションより後にコミットした
+		committedFirst := factory.NewEvent(2, valueobject.EventUserCreated, u)
+		committedFirst.TxID = 10
+		late := factory.NewEvent(1, valueobject.EventUserUpdated, u)
+		late.TxID = 11
+		log := &eventLog{}
+		log.append(committedFirst, late)
+		feed := startEventFeed(t, log)
+		srv := newEventsServer(t, feed, handler.EventsOptions{})
+
+		c := connectEvents(t, srv, "", "2")
+
+		assert.Equal(t, "1", c.nextEvent(t).ID)
+
+		next := factory.NewEvent(3, valueobject.EventUserDeleted, u)
+		next.TxID = 12
+		log.append(next)
+		feed.Wake()
+
+		assert.Equal(t, "3", c.nextEvent(t).ID)
+	})
+
+	t.Run("保持期間を過ぎて削除されたLast-Event-IDはresetイベントを送ってから接続した時点より後のイベントを配信する", func(t *testing.T) {
+		u := factory.NewUser()
+		log := &eventLog{}
+		log.append( // 1〜4 は削除済み
+			factory.NewEvent(5, valueobject.EventUserUpdated, u),
+			factory.NewEvent(6, valueobject.EventUserUpdated, u),
+		)
+		feed := startEventFeed(t, log)
+		srv := newEventsServer(t, feed, handler.EventsOptions{})
+
+		c := connectEvents(t, srv, "", "2")
+
+		msg := c.nextEvent(t)
+		assert.Equal(t, "reset", msg.Event)
+		assert.Equal(t, "6", msg.ID, "再接続で再びresetを受け取らないよう、再開する位置をidにするべき")
+		assert.JSONEq(t, `{"type":"reset"}`, msg.Data)
+
+		log.append(factory.NewEvent(7, valueobject.EventUserDeleted, u))
+		feed.Wake()
+
+		assert.Equal(t, "7", c.nextEvent(t).ID, "resetの後は接続した時点より前のイベントを送らないべき")
+	})
+
+	t.Run("idsとtypesで配信するイベントを絞り込める", func(t *testing.T) {
+		a := factory.NewUser()
+		b := factory.NewUser()
+		log := &eventLog{}
+		log.append(
+			factory.NewEvent(1, valueobject.EventUserCreated, a),
+			factory.NewEvent(2, valueobject.EventUserCreated, b),
+			factory.NewEvent(3, valueobject.EventUserUpdated, a),
+			factory.NewEvent(4, valueobject.EventUserDeleted, a),
+			factory.NewEvent(5, valueobject.EventUserDeleted, b),
+		)
+		feed := startEventFeed(t, log)
+		srv := newEventsServer(t, feed, handler.EventsOptions{})
+
+		c := connectEvents(t, srv, "?ids="+a.ID().String()+"&types=user.created,user.deleted", "0")
+
+		assert.Equal(t, "1", c.nextEvent(t).ID)
+		assert.Equal(t, "4", c.nextEvent(t).ID)
+	})
+
+	t.Run("サーバーのWriteTimeoutを超えても切断せずハートビートを送る", func(t *testing.T) {
+		u := factory.NewUser()
+		log := &eventLog{}
+		feed := startEventFeed(t, log)
+		srv := newEventsServer(t, feed, handler.EventsOptions{HeartbeatInterval: 20 * time.Millisecond})
+
+		c := connectEvents(t, srv, "", "")
+		deadline := time.Now().Add(300 * time.Millisecond) // WriteTimeout（100ms）より長く待つ
+		heartbeats := 0
+		for time.Now().Before(deadline) {
+			msg, err := c.next()
+			require.NoError(t, err)
+			if msg.Comment == "heartbeat" {
+				heartbeats++
+			}
+		}
+		assert.Greater(t, heartbeats, 1)
+
+		log.append(factory.NewEvent(1, valueobject.EventUserCreated, u))
+		feed.Wake()
+		assert.Equal(t, "1", c.nextEvent(t).ID)
+	})
+
+	t.Run("停止処理の開始で配信を終了する", func(t *testing.T) {
+		feed := startEventFeed(t, &eventLog{})
+		shutdown := make(chan struct{})
+		srv := newEventsServer(t, feed, handler.EventsOptions{Shutdown: shutdown})
+		c := connectEvents(t, srv, "", "")
+
+		close(shutdown)
+
+		_, err := io.ReadAll(c.reader)
+		assert.NoError(t, err, "ストリームが正常に終了するべき")
+	})
+
+	t.Run("不正な条件は400エラーを返す", func(t *testing.T) {
+		feed := startEventFeed(t, &eventLog{})
+		h := handler.NewEventsHandler(feed, handler.EventsOptions{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		req := httptest.NewRequest(http.MethodGet, "/users/events?ids=invalid&types=user.renamed", http.NoBody)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		var resp httperrors.ErrorResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		assert.Equal(t, "VALIDATION_ERROR", resp.Error.Code)
+		require.Len(t, resp.Error.Details, 2)
+		assert.Equal(t, "ids", resp.Error.Details[0].Field)
+		assert.Equal(t, "types", resp.Error.Details[1].Field)
+	})
+
+	t.Run("不正なLast-Event-IDは400エラーを返す", func(t *testing.T) {
+		feed := startEventFeed(t, &eventLog{})
+		h := handler.NewEventsHandler(feed, handler.EventsOptions{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		req := httptest.NewRequest(http.MethodGet, "/users/events", http.NoBody)
+		req.Header.Set("Last-Event-ID", "abc")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
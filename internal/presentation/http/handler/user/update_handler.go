@@ -6,22 +6,29 @@ import (
 	"net/http"
 
 	"go-api/internal/application/user"
-	"go-api/internal/domain"
+	"go-api/internal/domain/user/valueobject"
 	httperrors "go-api/internal/presentation/http/errors"
-	"go-api/internal/presentation/http/validation"
+	"go-api/internal/presentation/http/request"
 )
 
 // updateUserRequest はユーザー更新のJSONリクエスト。
 type updateUserRequest struct {
-	Name  string `json:"name" validate:"required,max=100"`
-	Email string `json:"email" validate:"required,max=255,email"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
 }
 
-func (r updateUserRequest) toInput() user.UpdateUserInput {
+// toInput は値オブジェクトを生成して入力を検証する。失敗した項目は全て valueobject.ValidationErrors で返す。
+func (r updateUserRequest) toInput() (user.UpdateUserInput, error) {
+	var errs valueobject.ValidationErrors
+	name, err := valueobject.NewUserName(r.Name)
+	errs.Add("name", err)
+	email, err := valueobject.NewEmail(r.Email)
+	errs.Add("email", err)
+
 	return user.UpdateUserInput{
-		Name:  r.Name,
-		Email: r.Email,
-	}
+		Name:  name,
+		Email: email,
+	}, errs.Err()
 }
 
 // updateUserResponse はユーザー更新のJSONレスポンス。
@@ -65,17 +72,18 @@ func (h *UpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 
 	var req updateUserRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		httperrors.WriteError(w, r, domain.ErrInvalidInput, h.logger)
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		httperrors.WriteError(w, r, err, h.logger)
 		return
 	}
 
-	if err := validation.Struct(req); err != nil {
+	input, err := req.toInput()
+	if err != nil {
 		httperrors.WriteError(w, r, err, h.logger)
 		return
 	}
 
-	output, err := h.uc.Execute(r.Context(), id, req.toInput())
+	output, err := h.uc.Execute(r.Context(), id, input)
 	if err != nil {
 		httperrors.WriteError(w, r, err, h.logger)
 		return
@@ -17,6 +17,7 @@ import (
 	"go-api/internal/domain"
 	"go-api/internal/domain/user/mocks"
 	handler "go-api/internal/presentation/http/handler/user"
+	"go-api/internal/presentation/http/request"
 	"go-api/internal/testutil/factory"
 )
 
@@ -134,4 +135,70 @@ func TestUpdateHandler(t *testing.T) {
 
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
 	})
+
+	t.Run("Content-Type がJSONでない場合は415エラーを返す", func(t *testing.T) {
+		testUser := factory.NewUser()
+
+		repo := mocks.NewMockUserRepository(t)
+
+		uc := usecase.NewUpdateUserUsecase(repo)
+		h := handler.NewUpdateHandler(uc, logger)
+
+		body := `{"name": "new", "email": "new@example.com"}`
+		req := httptest.NewRequest(http.MethodPut, "/users/"+testUser.ID().String(), strings.NewReader(body))
+		req.SetPathValue("id", testUser.ID().String())
+		req.Header.Set("Content-Type", "text/plain")
+		rec := httptest.NewRecorder()
+
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	})
+
+	t.Run("ボディが上限を超える場合は413エラーを返す", func(t *testing.T) {
+		testUser := factory.NewUser()
+
+		repo := mocks.NewMockUserRepository(t)
+
+		uc := usecase.NewUpdateUserUsecase(repo)
+		h := handler.NewUpdateHandler(uc, logger)
+
+		body := `{"name": "` + strings.Repeat("a", request.MaxBodyBytes) + `", "email": "new@example.com"}`
+		req := httptest.NewRequest(http.MethodPut, "/users/"+testUser.ID().String(), strings.NewReader(body))
+		req.SetPathValue("id", testUser.ID().String())
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	})
+
+	t.Run("未知のフィールドを含む場合は400エラーを返す", func(t *testing.T) {
+		testUser := factory.NewUser()
+
+		repo := mocks.NewMockUserRepository(t)
+
+		uc := usecase.NewUpdateUserUsecase(repo)
+		h := handler.NewUpdateHandler(uc, logger)
+
+		body := `{"name": "new", "email": "new@example.com", "admin": true}`
+		req := httptest.NewRequest(http.MethodPut, "/users/"+testUser.ID().String(), strings.NewReader(body))
+		req.SetPathValue("id", testUser.ID().String())
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var errResp map[string]interface{}
+		err := json.NewDecoder(rec.Body).Decode(&errResp)
+		require.NoError(t, err)
+
+		errorObj := errResp["error"].(map[string]interface{})
+		assert.Equal(t, "INVALID_BODY", errorObj["code"])
+		details := errorObj["details"].([]interface{})
+		assert.Equal(t, "admin", details[0].(map[string]interface{})["field"])
+	})
 }
@@ -0,0 +1,151 @@
+// Package i18n はエラーメッセージの多言語化を提供する。
+//
+// メッセージは locales/<言語>.yaml のカタログに定義し、Accept-Language から選んだ言語で返す。
+package i18n
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/ja"
+	ut "github.com/go-playground/universal-translator"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultLanguage は Accept-Language で言語が決まらない場合の言語。
+const DefaultLanguage = "en"
+
+//go:embed locales/*.yaml
+var catalogs embed.FS
+
+// supported は対応する言語のロケール。
+var supported = []locales.Translator{en.New(), ja.New()}
+
+var universal = mustLoad(catalogs)
+
+// Translator は1つの言語のメッセージを返す。
+type Translator struct {
+	trans ut.Translator
+}
+
+// Language は言語タグ（例: "ja"）を返す。
+func (t *Translator) Language() string {
+	return t.trans.Locale()
+}
+
+// T は key のメッセージを params で置き換えて返す。カタログにない場合は false を返す。
+func (t *Translator) T(key string, params ...string) (string, bool) {
+	msg, err := t.trans.T(key, params...)
+	if err != nil {
+		return "", false
+	}
+	return msg, true
+}
+
+// Get は言語タグに対応する Translator を返す。対応していない言語の場合は DefaultLanguage を返す。
+func Get(lang string) *Translator {
+	trans, _ := universal.GetTranslator(lang)
+	return &Translator{trans: trans}
+}
+
+// Negotiate は Accept-Language ヘッダーの値から最も優先度の高い対応言語の Translator を返す。
+// "ja-JP" のような地域付きのタグは言語部分で照合する。
+func Negotiate(acceptLanguage string) *Translator {
+	type candidate struct {
+		lang string
+		q    float64
+	}
+	var candidates []candidate
+	for part := range strings.SplitSeq(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			var err error
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		lang, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if q > 0 && lang != "" && lang != "*" {
+			candidates = append(candidates, candidate{lang: lang, q: q})
+		}
+	}
+	// 優先度の高い順（同じ優先度はヘッダーでの出現順）
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		switch {
+		case a.q > b.q:
+			return -1
+		case a.q < b.q:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	langs := make([]string, len(candidates))
+	for i, c := range candidates {
+		langs[i] = c.lang
+	}
+	trans, _ := universal.FindTranslator(langs...)
+	return &Translator{trans: trans}
+}
+
+// mustLoad はカタログを読み込む。カタログの誤りは起動時に検出するため panic する。
+func mustLoad(fsys fs.FS) *ut.UniversalTranslator {
+	uni, err := load(fsys)
+	if err != nil {
+		panic(err)
+	}
+	return uni
+}
+
+func load(fsys fs.FS) (*ut.UniversalTranslator, error) {
+	fallback := supported[0]
+	uni := ut.New(fallback, supported...)
+
+	for _, l := range supported {
+		name := path.Join("locales", l.Locale()+".yaml")
+		b, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		var messages map[string]string
+		if err := yaml.Unmarshal(b, &messages); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		trans, _ := uni.GetTranslator(l.Locale())
+		for key, text := range messages {
+			if err := checkPlaceholders(text); err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", name, key, err)
+			}
+			if err := trans.Add(key, text, false); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+		}
+	}
+	return uni, nil
+}
+
+// checkPlaceholders は {0}, {1}, ... が番号順に1回ずつ現れるかを検証する。
+// universal-translator は出現順に置き換えるため、順序が入れ替わった文は正しく組み立てられない。
+func checkPlaceholders(text string) error {
+	last := -1
+	for i := 0; ; i++ {
+		idx := strings.Index(text, "{"+strconv.Itoa(i)+"}")
+		if idx == -1 {
+			return nil
+		}
+		if idx < last {
+			return fmt.Errorf("placeholder {%d} must appear after {%d}", i, i-1)
+		}
+		last = idx
+	}
+}
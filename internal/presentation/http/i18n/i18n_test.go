@@ -0,0 +1,86 @@
+package i18n
+
+import (
+	"io/fs"
+	"maps"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{"指定なしはデフォルトの言語", "", "en"},
+		{"日本語", "ja", "ja"},
+		{"地域付きのタグは言語部分で照合する", "ja-JP", "ja"},
+		{"優先度の高い言語を選ぶ", "en;q=0.5, ja;q=0.8", "ja"},
+		{"同じ優先度は先に指定された言語を選ぶ", "en-US, ja", "en"},
+		{"対応していない言語は読み飛ばす", "fr-FR, ja;q=0.9, en;q=0.1", "ja"},
+		{"対応する言語がなければデフォルトの言語", "fr, de", "en"},
+		{"q=0 の言語は選ばない", "ja;q=0, en;q=0.1", "en"},
+		{"ワイルドカードはデフォルトの言語", "*", "en"},
+		{"不正な q の値は無視する", "ja;q=abc, en;q=0.5", "en"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Negotiate(tt.header).Language())
+		})
+	}
+}
+
+func TestTranslator_T(t *testing.T) {
+	t.Run("引数を置き換える", func(t *testing.T) {
+		msg, ok := Get("ja").T("error.NOT_FOUND.entity", "ユーザー")
+
+		require.True(t, ok)
+		assert.Equal(t, "ユーザーが見つかりません", msg)
+	})
+
+	t.Run("カタログにないキーは false を返す", func(t *testing.T) {
+		_, ok := Get("en").T("unknown.key")
+
+		assert.False(t, ok)
+	})
+
+	t.Run("対応していない言語はデフォルトの言語で返す", func(t *testing.T) {
+		tr := Get("fr")
+
+		assert.Equal(t, DefaultLanguage, tr.Language())
+	})
+}
+
+func TestCatalogs(t *testing.T) {
+	read := func(t *testing.T, lang string) map[string]string {
+		t.Helper()
+		b, err := fs.ReadFile(catalogs, "locales/"+lang+".yaml")
+		require.NoError(t, err)
+		var m map[string]string
+		require.NoError(t, yaml.Unmarshal(b, &m))
+		return m
+	}
+
+	t.Run("全ての言語で同じキーを定義する", func(t *testing.T) {
+		want := slices.Sorted(maps.Keys(read(t, DefaultLanguage)))
+		for _, l := range supported {
+			assert.Equal(t, want, slices.Sorted(maps.Keys(read(t, l.Locale()))), l.Locale())
+		}
+	})
+
+	t.Run("引数の順序が入れ替わったメッセージは読み込めない", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"locales/en.yaml": {Data: []byte(`a: "{0} {1}"`)},
+			"locales/ja.yaml": {Data: []byte(`a: "{1}の{0}"`)},
+		}
+
+		_, err := load(fsys)
+		assert.ErrorContains(t, err, "locales/ja.yaml: a: placeholder {1} must appear after {0}")
+	})
+}
@@ -0,0 +1,162 @@
+package middleware
+
+import (
+	"bufio"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"time"
+
+	"go-api/internal/presentation/http/requestctx"
+)
+
+// AccessLogOptions はアクセスログの設定。
+type AccessLogOptions struct {
+	// SuccessSampleRate は2xxレスポンスを記録する割合（0〜1）。
+	// 2xx以外のレスポンスは常に記録する。
+	SuccessSampleRate float64
+	// ClientIPs はクライアントIPの解決に使用する。
+	ClientIPs *ClientIPResolver
+}
+
+// AccessLog はアクセスログを記録するミドルウェア。
+// パスではなくマッチしたルートパターンを記録し、ログのカーディナリティを抑える。
+// エラーレスポンスの場合はエラーコードと、失敗した操作のエンティティ名・操作名も記録する。
+func AccessLog(logger *slog.Logger, opts AccessLogOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			body := &countingReader{ReadCloser: r.Body}
+			if r.Body != nil && r.Body != http.NoBody {
+				r.Body = body
+			}
+			rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+			ctx, errorInfo := requestctx.WithErrorInfo(r.Context())
+
+			next.ServeHTTP(rw, r.WithContext(ctx))
+
+			if rw.status < 300 && rw.status >= 200 && !sampled(opts.SuccessSampleRate) {
+				return
+			}
+
+			attrs := []slog.Attr{
+				slog.String("method", r.Method),
+				slog.String("route", routeLabel(r)),
+				slog.Int("status", rw.status),
+				slog.Duration("duration", time.Since(start)),
+				slog.Int64("bytes_in", body.n),
+				slog.Int64("bytes_out", rw.bytes),
+				slog.String("user_agent", r.UserAgent()),
+				slog.String("remote_ip", opts.ClientIPs.ClientIP(r)),
+			}
+			if info := errorInfo(); info.Code != "" {
+				attrs = append(attrs, slog.String("error_code", info.Code))
+				if info.Entity != "" {
+					attrs = append(attrs, slog.String("entity", info.Entity), slog.String("op", info.Op))
+				}
+			}
+			logger.LogAttrs(r.Context(), accessLogLevel(rw.status), "access", attrs...)
+		})
+	}
+}
+
+// routeLabel はログやメトリクスに記録するルートを返す。
+// ルートにマッチしなかったリクエストは任意のパスを含み得るため固定値にまとめる。
+func routeLabel(r *http.Request) string {
+	if pattern := requestctx.RoutePattern(r.Context()); pattern != "" {
+		return pattern
+	}
+	return "unmatched"
+}
+
+func accessLogLevel(status int) slog.Level {
+	switch {
+	case status >= 500:
+		return slog.LevelError
+	case status >= 400:
+		return slog.LevelWarn
+	default:
+		return slog.LevelInfo
+	}
+}
+
+func sampled(rate float64) bool {
+	switch {
+	case rate >= 1:
+		return true
+	case rate <= 0:
+		return false
+	default:
+		return rand.Float64() < rate // #nosec G404 -- サンプリング用途のため暗号学的乱数は不要
+	}
+}
+
+// countingReader はリクエストボディの読み取りバイト数を記録する。
+type countingReader struct {
+	io.ReadCloser
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// responseWriter はレスポンスのステータスと書き込みバイト数を記録するラッパー。
+// Unwrap を実装しているため http.ResponseController から元の機能を利用でき、
+// http.Flusher / http.Hijacker による型アサーションも引き続き機能する。
+type responseWriter struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+func (rw *responseWriter) WriteHeader(code int) {
+	// 1xx（101を除く）は中間レスポンスのため最終ステータスとして扱わない
+	if !rw.wroteHeader && (code >= 200 || code == http.StatusSwitchingProtocols) {
+		rw.status = code
+		rw.wroteHeader = true
+	}
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	rw.wroteHeader = true
+	n, err := rw.ResponseWriter.Write(b)
+	rw.bytes += int64(n)
+	return n, err
+}
+
+// ReadFrom は元の ResponseWriter の io.ReaderFrom（sendfile等）を活かす。
+func (rw *responseWriter) ReadFrom(src io.Reader) (int64, error) {
+	rw.wroteHeader = true
+	n, err := io.Copy(rw.ResponseWriter, src)
+	rw.bytes += n
+	return n, err
+}
+
+// Flush は http.Flusher を実装する。
+func (rw *responseWriter) Flush() {
+	rw.wroteHeader = true
+	_ = http.NewResponseController(rw.ResponseWriter).Flush()
+}
+
+// Hijack は http.Hijacker を実装する。
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, buf, err := http.NewResponseController(rw.ResponseWriter).Hijack()
+	if err == nil && !rw.wroteHeader {
+		rw.status = http.StatusSwitchingProtocols
+		rw.wroteHeader = true
+	}
+	return conn, buf, err
+}
+
+// Unwrap は http.ResponseController が元の ResponseWriter を辿るために使用する。
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
@@ -0,0 +1,178 @@
+package middleware_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/domain"
+	httperrors "go-api/internal/presentation/http/errors"
+	"go-api/internal/presentation/http/middleware"
+)
+
+// lockedBuffer はサーバーのgoroutineから書き込まれるログを安全に読むためのバッファ。
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return bytes.Clone(b.buf.Bytes())
+}
+
+func (b *lockedBuffer) String() string {
+	return string(b.Bytes())
+}
+
+func newAccessLogger(t *testing.T, rate float64) (func(http.Handler) http.Handler, *lockedBuffer) {
+	t.Helper()
+	var buf lockedBuffer
+	resolver, err := middleware.NewClientIPResolver(nil)
+	require.NoError(t, err)
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	return middleware.AccessLog(logger, middleware.AccessLogOptions{
+		SuccessSampleRate: rate,
+		ClientIPs:         resolver,
+	}), &buf
+}
+
+func TestAccessLog(t *testing.T) {
+	t.Run("ルートパターンやサイズを記録する", func(t *testing.T) {
+		accessLog, buf := newAccessLogger(t, 1)
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("POST /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.Copy(io.Discard, r.Body)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte("hello"))
+		})
+		h := middleware.RoutePattern(mux)(accessLog(mux))
+
+		req := httptest.NewRequest(http.MethodPost, "/users/123", strings.NewReader(`{"a":1}`))
+		req.Header.Set("User-Agent", "test-agent")
+		req.RemoteAddr = "203.0.113.5:1234"
+		h.ServeHTTP(httptest.NewRecorder(), req)
+
+		var record map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+		assert.Equal(t, "access", record["msg"])
+		assert.Equal(t, "INFO", record["level"])
+		assert.Equal(t, "POST", record["method"])
+		assert.Equal(t, "POST /users/{id}", record["route"])
+		assert.InDelta(t, 201, record["status"], 0)
+		assert.InDelta(t, 7, record["bytes_in"], 0)
+		assert.InDelta(t, 5, record["bytes_out"], 0)
+		assert.Equal(t, "test-agent", record["user_agent"])
+		assert.Equal(t, "203.0.113.5", record["remote_ip"])
+		assert.Contains(t, record, "duration")
+	})
+
+	t.Run("ルートにマッチしない場合はパスを記録しない", func(t *testing.T) {
+		accessLog, buf := newAccessLogger(t, 1)
+
+		mux := http.NewServeMux()
+		h := middleware.RoutePattern(mux)(accessLog(mux))
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/random/path", http.NoBody))
+
+		var record map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+		assert.Equal(t, "unmatched", record["route"])
+		assert.Equal(t, "WARN", record["level"])
+	})
+
+	t.Run("サンプリング対象外の2xxは記録しないがエラーは記録する", func(t *testing.T) {
+		accessLog, buf := newAccessLogger(t, 0)
+
+		status := http.StatusOK
+		h := accessLog(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users", http.NoBody))
+		assert.Empty(t, buf.String())
+
+		status = http.StatusInternalServerError
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users", http.NoBody))
+		assert.Contains(t, buf.String(), `"status":500`)
+	})
+
+	t.Run("エラーコードと失敗した操作を記録する", func(t *testing.T) {
+		accessLog, buf := newAccessLogger(t, 1)
+		h := accessLog(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			httperrors.WriteError(w, r, domain.NotFound("user", "FindByID"), nil)
+		}))
+
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/123", http.NoBody))
+
+		var record map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+		assert.InDelta(t, 404, record["status"], 0)
+		assert.Equal(t, "NOT_FOUND", record["error_code"])
+		assert.Equal(t, "user", record["entity"])
+		assert.Equal(t, "FindByID", record["op"])
+	})
+
+	t.Run("ラップ後もFlusherとResponseControllerが機能する", func(t *testing.T) {
+		accessLog, _ := newAccessLogger(t, 1)
+
+		h := accessLog(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, ok := w.(http.Flusher)
+			assert.True(t, ok, "http.Flusher を満たすべき")
+			assert.NoError(t, http.NewResponseController(w).Flush())
+		}))
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users", http.NoBody))
+
+		assert.True(t, rec.Flushed)
+	})
+
+	t.Run("ラップ後もHijackできる", func(t *testing.T) {
+		accessLog, buf := newAccessLogger(t, 1)
+
+		srv := httptest.NewServer(accessLog(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			conn, rw, err := w.(http.Hijacker).Hijack()
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer conn.Close()
+			_, _ = rw.WriteString("HTTP/1.1 204 No Content\r\nConnection: close\r\n\r\n")
+			_ = rw.Flush()
+		})))
+		defer srv.Close()
+
+		conn, err := net.Dial("tcp", srv.Listener.Addr().String())
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, err = conn.Write([]byte("GET / HTTP/1.1\r\nHost: example.com\r\n\r\n"))
+		require.NoError(t, err)
+
+		resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Eventually(t, func() bool { return strings.Contains(buf.String(), `"status":101`) }, time.Second, 10*time.Millisecond)
+	})
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"go-api/internal/domain"
+	httperrors "go-api/internal/presentation/http/errors"
+)
+
+// AdminPrincipalID は管理用トークンで認証された呼び出し元のID。
+const AdminPrincipalID = "admin"
+
+// AdminAuth は Authorization: Bearer <token> で管理用トークンを検証するミドルウェア。
+// 認証に成功した場合は管理者の Principal をコンテキストに格納する。
+// token が空の場合は全てのリクエストを拒否する。
+func AdminAuth(token string, logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !validAdminToken(r, token) {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+				httperrors.WriteError(w, r, domain.ErrUnauthorized, logger)
+				return
+			}
+			ctx := WithPrincipal(r.Context(), Principal{ID: AdminPrincipalID})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func validAdminToken(r *http.Request, token string) bool {
+	if token == "" {
+		return false
+	}
+	scheme, got, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1
+}
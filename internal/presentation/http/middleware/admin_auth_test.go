@@ -0,0 +1,54 @@
+package middleware_test
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-api/internal/presentation/http/middleware"
+)
+
+func TestAdminAuth(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, _ := middleware.PrincipalFromContext(r.Context())
+		_, _ = io.WriteString(w, p.ID)
+	})
+
+	tests := []struct {
+		name          string
+		token         string
+		authorization string
+		wantStatus    int
+	}{
+		{name: "正しいトークンは通過する", token: "secret", authorization: "Bearer secret", wantStatus: http.StatusOK},
+		{name: "スキームの大文字小文字は区別しない", token: "secret", authorization: "bearer secret", wantStatus: http.StatusOK},
+		{name: "誤ったトークンは401", token: "secret", authorization: "Bearer wrong", wantStatus: http.StatusUnauthorized},
+		{name: "ヘッダーがない場合は401", token: "secret", wantStatus: http.StatusUnauthorized},
+		{name: "Bearer以外のスキームは401", token: "secret", authorization: "Basic secret", wantStatus: http.StatusUnauthorized},
+		{name: "トークン未設定の場合は常に401", token: "", authorization: "Bearer ", wantStatus: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := middleware.AdminAuth(tt.token, logger)(next)
+			req := httptest.NewRequest(http.MethodGet, "/health?verbose", http.NoBody)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, middleware.AdminPrincipalID, rec.Body.String())
+			} else {
+				assert.Equal(t, `Bearer realm="admin"`, rec.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
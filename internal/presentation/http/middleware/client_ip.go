@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// ClientIPResolver は信頼済みプロキシを考慮してクライアントIPを解決する。
+// X-Forwarded-For は信頼済みプロキシから届いた場合のみ参照し、
+// 右端から信頼済みプロキシを読み飛ばした最初のアドレスをクライアントとみなす。
+type ClientIPResolver struct {
+	trusted []netip.Prefix
+}
+
+// NewClientIPResolver は ClientIPResolver を生成する。
+// trustedProxies にはCIDRまたは単一のIPアドレスを指定する。
+func NewClientIPResolver(trustedProxies []string) (*ClientIPResolver, error) {
+	trusted := make([]netip.Prefix, 0, len(trustedProxies))
+	for _, s := range trustedProxies {
+		p, err := parsePrefix(s)
+		if err != nil {
+			return nil, fmt.Errorf("parse trusted proxy %q: %w", s, err)
+		}
+		trusted = append(trusted, p)
+	}
+	return &ClientIPResolver{trusted: trusted}, nil
+}
+
+// ClientIP はリクエストの送信元クライアントIPを返す。
+func (c *ClientIPResolver) ClientIP(r *http.Request) string {
+	remote, ok := parseRemoteAddr(r.RemoteAddr)
+	if !ok {
+		return r.RemoteAddr
+	}
+	if !c.isTrusted(remote) {
+		return remote.String()
+	}
+
+	hops := forwardedFor(r.Header)
+	for i := len(hops) - 1; i >= 0; i-- {
+		addr, err := netip.ParseAddr(hops[i])
+		if err != nil {
+			// 解析できない値はそれ以上遡らず、直前の信頼済みホップを採用する
+			break
+		}
+		addr = addr.Unmap()
+		if !c.isTrusted(addr) {
+			return addr.String()
+		}
+		remote = addr
+	}
+	return remote.String()
+}
+
+func (c *ClientIPResolver) isTrusted(addr netip.Addr) bool {
+	for _, p := range c.trusted {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func parsePrefix(s string) (netip.Prefix, error) {
+	if strings.Contains(s, "/") {
+		p, err := netip.ParsePrefix(s)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		return p.Masked(), nil
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+func parseRemoteAddr(remoteAddr string) (netip.Addr, bool) {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap(), true
+}
+
+// forwardedFor は複数行に分かれたものも含めて X-Forwarded-For を順に展開する。
+func forwardedFor(h http.Header) []string {
+	var hops []string
+	for _, v := range h.Values("X-Forwarded-For") {
+		for _, hop := range strings.Split(v, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+	return hops
+}
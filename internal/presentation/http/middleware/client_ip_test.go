@@ -0,0 +1,51 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/presentation/http/middleware"
+)
+
+func TestClientIPResolver_ClientIP(t *testing.T) {
+	resolver, err := middleware.NewClientIPResolver([]string{"10.0.0.0/8", "192.168.1.1"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor []string
+		expected     string
+	}{
+		{"プロキシを経由しない場合はRemoteAddrを返す", "203.0.113.5:1234", nil, "203.0.113.5"},
+		{"信頼していない送信元のX-Forwarded-Forは無視する", "203.0.113.5:1234", []string{"198.51.100.1"}, "203.0.113.5"},
+		{"信頼済みプロキシ経由ではX-Forwarded-Forを参照する", "10.0.0.1:1234", []string{"198.51.100.1"}, "198.51.100.1"},
+		{"右端から信頼済みプロキシを読み飛ばす", "10.0.0.1:1234", []string{"198.51.100.9, 198.51.100.1, 192.168.1.1"}, "198.51.100.1"},
+		{"複数行のヘッダーも順に解釈する", "10.0.0.1:1234", []string{"198.51.100.1", "10.0.0.2"}, "198.51.100.1"},
+		{"全て信頼済みの場合は最も遠いホップを返す", "10.0.0.1:1234", []string{"10.0.0.3, 10.0.0.2"}, "10.0.0.3"},
+		{"解析できない値より先は遡らない", "10.0.0.1:1234", []string{"198.51.100.1, unknown"}, "10.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			r.RemoteAddr = tt.remoteAddr
+			for _, v := range tt.forwardedFor {
+				r.Header.Add("X-Forwarded-For", v)
+			}
+
+			assert.Equal(t, tt.expected, resolver.ClientIP(r))
+		})
+	}
+}
+
+func TestNewClientIPResolver(t *testing.T) {
+	t.Run("不正なCIDRはエラーを返す", func(t *testing.T) {
+		_, err := middleware.NewClientIPResolver([]string{"10.0.0.0/33"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSOptions はCORSの設定。
+type CORSOptions struct {
+	// AllowedOrigins は許可するオリジン。完全一致のほか "https://*.example.com" の形式で
+	// サブドメインを、"*" で全てのオリジンを許可する。
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	// MaxAge はプリフライトの結果をブラウザがキャッシュする期間。
+	MaxAge time.Duration
+}
+
+// CORS はCORSのレスポンスヘッダーを付与し、プリフライトリクエストに応答するミドルウェア。
+// プリフライトは ServeMux に渡さずに応答するため、OPTIONS のルートがなくても405にならない。
+func CORS(opts CORSOptions) func(http.Handler) http.Handler {
+	origins := newOriginMatcher(opts.AllowedOrigins)
+	allowMethods := strings.Join(opts.AllowedMethods, ", ")
+	allowHeaders := strings.Join(opts.AllowedHeaders, ", ")
+	exposeHeaders := strings.Join(opts.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(opts.MaxAge.Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h := w.Header()
+			origin := r.Header.Get("Origin")
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+			// 全オリジン許可でも認証情報付きの場合はオリジンごとに応答が変わるため Vary を付与する
+			if !origins.any || opts.AllowCredentials {
+				h.Add("Vary", "Origin")
+			}
+			if preflight {
+				h.Add("Vary", "Access-Control-Request-Method")
+				h.Add("Vary", "Access-Control-Request-Headers")
+			}
+
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !origins.match(origin) {
+				if preflight {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if origins.any && !opts.AllowCredentials {
+				h.Set("Access-Control-Allow-Origin", "*")
+			} else {
+				h.Set("Access-Control-Allow-Origin", origin)
+			}
+			if opts.AllowCredentials {
+				h.Set("Access-Control-Allow-Credentials", "true")
+			}
+
+			if preflight {
+				h.Set("Access-Control-Allow-Methods", allowMethods)
+				if allowHeaders != "" {
+					h.Set("Access-Control-Allow-Headers", allowHeaders)
+				}
+				if opts.MaxAge > 0 {
+					h.Set("Access-Control-Max-Age", maxAge)
+				}
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			if exposeHeaders != "" {
+				h.Set("Access-Control-Expose-Headers", exposeHeaders)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// originMatcher は許可するオリジンの判定。
+type originMatcher struct {
+	any       bool
+	exact     []string
+	wildcards []wildcardOrigin
+}
+
+// wildcardOrigin は "https://*.example.com" を prefix "https://" と suffix ".example.com" に分けたもの。
+type wildcardOrigin struct {
+	prefix string
+	suffix string
+}
+
+func newOriginMatcher(patterns []string) originMatcher {
+	var m originMatcher
+	for _, p := range patterns {
+		p = strings.ToLower(p)
+		switch {
+		case p == "*":
+			m.any = true
+		case strings.Contains(p, "*"):
+			prefix, suffix, _ := strings.Cut(p, "*")
+			m.wildcards = append(m.wildcards, wildcardOrigin{prefix: prefix, suffix: suffix})
+		default:
+			m.exact = append(m.exact, p)
+		}
+	}
+	return m
+}
+
+func (m originMatcher) match(origin string) bool {
+	if m.any {
+		return true
+	}
+	origin = strings.ToLower(origin)
+	if slices.Contains(m.exact, origin) {
+		return true
+	}
+	for _, w := range m.wildcards {
+		if len(origin) > len(w.prefix)+len(w.suffix) &&
+			strings.HasPrefix(origin, w.prefix) && strings.HasSuffix(origin, w.suffix) &&
+			isSubdomain(origin[len(w.prefix):len(origin)-len(w.suffix)]) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSubdomain はワイルドカードに当たる部分がホスト名のラベルのみで構成されているかを返す。
+func isSubdomain(s string) bool {
+	for _, c := range s {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' && c != '.' {
+			return false
+		}
+	}
+	return !strings.HasPrefix(s, ".") && !strings.HasSuffix(s, ".")
+}
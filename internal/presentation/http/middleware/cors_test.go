@@ -0,0 +1,127 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-api/internal/presentation/http/middleware"
+)
+
+func TestCORS(t *testing.T) {
+	newHandler := func(opts middleware.CORSOptions) http.Handler {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /users", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+		return middleware.CORS(opts)(mux)
+	}
+	opts := middleware.CORSOptions{
+		AllowedOrigins: []string{"https://app.example.com", "https://*.example.org"},
+		AllowedMethods: []string{"GET", "POST"},
+		AllowedHeaders: []string{"Content-Type", "X-Request-ID"},
+		ExposedHeaders: []string{"ETag", "X-Request-ID"},
+		MaxAge:         10 * time.Minute,
+	}
+
+	preflight := func(origin string) *http.Request {
+		req := httptest.NewRequest(http.MethodOptions, "/users", http.NoBody)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", "POST")
+		req.Header.Set("Access-Control-Request-Headers", "content-type")
+		return req
+	}
+
+	t.Run("プリフライトにServeMuxより先に応答する", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		newHandler(opts).ServeHTTP(rec, preflight("https://app.example.com"))
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, "https://app.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "GET, POST", rec.Header().Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "Content-Type, X-Request-ID", rec.Header().Get("Access-Control-Allow-Headers"))
+		assert.Equal(t, "600", rec.Header().Get("Access-Control-Max-Age"))
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Credentials"))
+		assert.Equal(t, []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"}, rec.Header().Values("Vary"))
+	})
+
+	t.Run("通常のリクエストに公開ヘッダーを付与する", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users", http.NoBody)
+		req.Header.Set("Origin", "https://app.example.com")
+		rec := httptest.NewRecorder()
+
+		newHandler(opts).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "https://app.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "ETag, X-Request-ID", rec.Header().Get("Access-Control-Expose-Headers"))
+		assert.Equal(t, "Origin", rec.Header().Get("Vary"))
+	})
+
+	t.Run("ワイルドカードでサブドメインを許可する", func(t *testing.T) {
+		for origin, allowed := range map[string]bool{
+			"https://a.example.org":         true,
+			"https://a.b.example.org":       true,
+			"https://example.org":           false,
+			"http://a.example.org":          false,
+			"https://a.example.org.evil":    false,
+			"https://evil.com/.example.org": false,
+		} {
+			rec := httptest.NewRecorder()
+			newHandler(opts).ServeHTTP(rec, preflight(origin))
+
+			if allowed {
+				assert.Equal(t, http.StatusNoContent, rec.Code, origin)
+				assert.Equal(t, origin, rec.Header().Get("Access-Control-Allow-Origin"), origin)
+			} else {
+				assert.Equal(t, http.StatusForbidden, rec.Code, origin)
+				assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"), origin)
+			}
+		}
+	})
+
+	t.Run("許可されていないオリジンの通常のリクエストはCORSヘッダーなしで処理する", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users", http.NoBody)
+		req.Header.Set("Origin", "https://evil.example.com")
+		rec := httptest.NewRecorder()
+
+		newHandler(opts).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("Originのないリクエストはそのまま処理する", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		newHandler(opts).ServeHTTP(rec, httptest.NewRequest(http.MethodOptions, "/users", http.NoBody))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+
+	t.Run("全オリジン許可の場合はワイルドカードを返す", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users", http.NoBody)
+		req.Header.Set("Origin", "https://any.example.net")
+		rec := httptest.NewRecorder()
+
+		newHandler(middleware.CORSOptions{AllowedOrigins: []string{"*"}, AllowedMethods: []string{"GET"}}).ServeHTTP(rec, req)
+
+		assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, rec.Header().Values("Vary"))
+	})
+
+	t.Run("認証情報を許可する場合はCredentialsヘッダーを付与する", func(t *testing.T) {
+		withCredentials := opts
+		withCredentials.AllowCredentials = true
+		rec := httptest.NewRecorder()
+
+		newHandler(withCredentials).ServeHTTP(rec, preflight("https://app.example.com"))
+
+		assert.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Credentials"))
+		assert.Equal(t, "https://app.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
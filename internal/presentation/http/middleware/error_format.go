@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"net/http"
+
+	httperrors "go-api/internal/presentation/http/errors"
+)
+
+// ErrorFormat はエラーレスポンスの形式の設定をコンテキストに格納するミドルウェア。
+// 内側のハンドラーやミドルウェアが httperrors.WriteError で書き込むエラーに適用される。
+func ErrorFormat(opts httperrors.Options) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(httperrors.WithOptions(r.Context(), opts)))
+		})
+	}
+}
@@ -2,9 +2,12 @@
 package middleware
 
 import (
+	"errors"
 	"log/slog"
 	"net/http"
 	"runtime/debug"
+
+	httperrors "go-api/internal/presentation/http/errors"
 )
 
 // Recover はパニックリカバリーを行うミドルウェア。
@@ -15,16 +18,15 @@ func Recover(logger *slog.Logger) func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			defer func() {
 				if rec := recover(); rec != nil {
-					logger.Error("panic recovered",
+					logger.ErrorContext(r.Context(), "panic recovered",
 						"error", rec,
 						"stack", string(debug.Stack()),
 						"path", r.URL.Path,
 						"method", r.Method,
 					)
 
-					w.Header().Set("Content-Type", "application/json")
-					w.WriteHeader(http.StatusInternalServerError)
-					_, _ = w.Write([]byte(`{"error":{"code":"INTERNAL_ERROR","message":"internal server error"}}`))
+					// ログは上で記録済みのため logger は渡さない
+					httperrors.WriteError(w, r, errors.New("panic recovered"), nil)
 				}
 			}()
 
@@ -32,34 +34,3 @@ func Recover(logger *slog.Logger) func(http.Handler) http.Handler {
 		})
 	}
 }
-
-// responseWriter はレスポンスステータスを記録するためのラッパー。
-type responseWriter struct {
-	http.ResponseWriter
-	status int
-}
-
-func (rw *responseWriter) WriteHeader(code int) {
-	rw.status = code
-	rw.ResponseWriter.WriteHeader(code)
-}
-
-// RequestLogger はリクエストログを記録するミドルウェア。
-func RequestLogger(logger *slog.Logger) func(http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
-
-			next.ServeHTTP(rw, r)
-
-			// エラーレスポンスの場合はログを記録
-			if rw.status >= 400 {
-				logger.Warn("error response",
-					"status", rw.status,
-					"path", r.URL.Path,
-					"method", r.Method,
-				)
-			}
-		})
-	}
-}
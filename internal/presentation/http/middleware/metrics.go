@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+)
+
+// HTTPMetrics はHTTPリクエストのメトリクスを記録するインターフェース。
+type HTTPMetrics interface {
+	ObserveRequest(method, route string, status int, d time.Duration)
+}
+
+// Metrics はリクエスト数とレイテンシをルートパターン・ステータス別に記録するミドルウェア。
+// ルートパターンを参照するため RoutePattern より内側に配置する。
+func Metrics(m HTTPMetrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(rw, r)
+
+			m.ObserveRequest(methodLabel(r.Method), routeLabel(r), rw.status, time.Since(start))
+		})
+	}
+}
+
+// methodLabel は任意のメソッド名によるラベルの増加を防ぐため、既知でないメソッドを "OTHER" にする。
+func methodLabel(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut,
+		http.MethodPatch, http.MethodDelete, http.MethodOptions:
+		return method
+	default:
+		return "OTHER"
+	}
+}
@@ -0,0 +1,45 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-api/internal/presentation/http/middleware"
+)
+
+type recordedRequest struct {
+	method string
+	route  string
+	status int
+}
+
+type fakeHTTPMetrics struct {
+	observed []recordedRequest
+}
+
+func (f *fakeHTTPMetrics) ObserveRequest(method, route string, status int, _ time.Duration) {
+	f.observed = append(f.observed, recordedRequest{method: method, route: route, status: status})
+}
+
+func TestMetrics(t *testing.T) {
+	m := &fakeHTTPMetrics{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	h := middleware.RoutePattern(mux)(middleware.Metrics(m)(mux))
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/123", http.NoBody))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown", http.NoBody))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("BREW", "/users/123", http.NoBody))
+
+	assert.Equal(t, []recordedRequest{
+		{method: http.MethodGet, route: "GET /users/{id}", status: http.StatusNotFound},
+		{method: http.MethodGet, route: "unmatched", status: http.StatusNotFound},
+		{method: "OTHER", route: "unmatched", status: http.StatusMethodNotAllowed},
+	}, m.observed)
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import "context"
+
+// Principal は認証済みの呼び出し元。
+type Principal struct {
+	ID     string // ユーザーやサービスアカウントのID
+	Tenant string // 所属テナント
+}
+
+type principalKey struct{}
+
+// WithPrincipal は認証済みの呼び出し元をコンテキストに格納する。
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext はコンテキストから認証済みの呼び出し元を取り出す。
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
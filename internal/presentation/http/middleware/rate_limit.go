@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-api/internal/infrastructure/ratelimit"
+	httperrors "go-api/internal/presentation/http/errors"
+)
+
+// RateLimitOptions はルートグループ単位のレート制限設定。
+type RateLimitOptions struct {
+	Group   string          // ルートグループ名（バケットのキーに含める）
+	Limit   ratelimit.Limit // トークンバケットの設定
+	KeyFunc func(*http.Request) string
+}
+
+// RateLimit はトークンバケット方式でリクエスト数を制限するミドルウェア。
+// 応答には RateLimit-* ヘッダーを付与し、上限超過時は429と Retry-After を返す。
+// バックエンドの障害時はリクエストを通過させる（fail open）。
+func RateLimit(limiter ratelimit.Limiter, opts RateLimitOptions, logger *slog.Logger) func(http.Handler) http.Handler {
+	policy := strconv.Itoa(opts.Limit.Burst) + ";w=" + strconv.Itoa(ceilSeconds(opts.Limit.Window()))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := opts.Group + ":" + opts.KeyFunc(r)
+
+			res, err := limiter.Allow(r.Context(), key, opts.Limit)
+			if err != nil {
+				logger.WarnContext(r.Context(), "rate limiter unavailable",
+					"error", err.Error(),
+					"group", opts.Group,
+				)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Set("RateLimit-Policy", policy)
+			h.Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+			h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.ResetAfter)))
+
+			if !res.Allowed {
+				h.Set("Retry-After", strconv.Itoa(max(1, ceilSeconds(res.RetryAfter))))
+				httperrors.WriteError(w, r, httperrors.ErrTooManyRequests, logger)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RateLimitKey はクライアントIPをレート制限のキーとする関数を返す。
+func RateLimitKey(ips *ClientIPResolver) func(*http.Request) string {
+	return func(r *http.Request) string {
+		return "ip:" + ips.ClientIP(r)
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
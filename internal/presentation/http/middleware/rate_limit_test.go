@@ -0,0 +1,91 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/infrastructure/ratelimit"
+	httperrors "go-api/internal/presentation/http/errors"
+	"go-api/internal/presentation/http/middleware"
+)
+
+type failingLimiter struct{}
+
+func (failingLimiter) Allow(context.Context, string, ratelimit.Limit) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("connection refused")
+}
+
+func TestRateLimit(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	resolver, err := middleware.NewClientIPResolver(nil)
+	require.NoError(t, err)
+	opts := middleware.RateLimitOptions{
+		Group:   "test",
+		Limit:   ratelimit.Limit{Rate: 1, Burst: 1},
+		KeyFunc: middleware.RateLimitKey(resolver),
+	}
+
+	t.Run("許可したリクエストにRateLimitヘッダーを付与する", func(t *testing.T) {
+		h := middleware.RateLimit(ratelimit.NewMemoryLimiter(), opts, logger)(ok)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users", http.NoBody))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "1;w=1", rec.Header().Get("RateLimit-Policy"))
+		assert.Equal(t, "1", rec.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "1", rec.Header().Get("RateLimit-Reset"))
+		assert.Empty(t, rec.Header().Get("Retry-After"))
+	})
+
+	t.Run("上限を超えると429とRetry-Afterを返す", func(t *testing.T) {
+		h := middleware.RateLimit(ratelimit.NewMemoryLimiter(), opts, logger)(ok)
+
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users", http.NoBody))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users", http.NoBody))
+
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+
+		var resp httperrors.ErrorResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "RATE_LIMITED", resp.Error.Code)
+	})
+
+	t.Run("クライアントIPごとにバケットを分ける", func(t *testing.T) {
+		h := middleware.RateLimit(ratelimit.NewMemoryLimiter(), opts, logger)(ok)
+
+		for _, addr := range []string{"192.0.2.1:12345", "192.0.2.2:12345"} {
+			req := httptest.NewRequest(http.MethodGet, "/users", http.NoBody)
+			req.RemoteAddr = addr
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code, addr)
+		}
+	})
+
+	t.Run("バックエンドの障害時はリクエストを通過させる", func(t *testing.T) {
+		h := middleware.RateLimit(failingLimiter{}, opts, logger)(ok)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users", http.NoBody))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("RateLimit-Limit"))
+	})
+}
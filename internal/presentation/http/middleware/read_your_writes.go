@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-api/internal/infrastructure/database"
+)
+
+// LastWriteCookie は呼び出し元の最後の書き込みの時刻（UNIXミリ秒）を運ぶクッキーの名前。
+const LastWriteCookie = "last_write"
+
+// ReadYourWrites はリクエストのクッキーの最後の書き込みの時刻を読み込みの振り分けのセッションとしてコンテキストに格納する。
+// リクエスト中に書き込んだ場合はその時刻を window の間有効なクッキーで返し、
+// 後続のリクエストの読み込みはどのインスタンスが受けても一定期間レプリカではなくプライマリに送られる。
+func ReadYourWrites(window time.Duration) func(http.Handler) http.Handler {
+	maxAge := int(math.Ceil(window.Seconds()))
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lastWrite := lastWriteFromRequest(r)
+			session := database.NewSession(lastWrite)
+			lw := &lastWriteWriter{
+				ResponseWriter: w,
+				session:        session,
+				initial:        lastWrite,
+				maxAge:         maxAge,
+				secure:         r.TLS != nil,
+			}
+			next.ServeHTTP(lw, r.WithContext(database.WithSession(r.Context(), session)))
+			lw.setCookie()
+		})
+	}
+}
+
+// lastWriteFromRequest はクッキーから最後の書き込みの時刻を取り出す。クッキーがないか不正な場合はゼロ値を返す。
+func lastWriteFromRequest(r *http.Request) time.Time {
+	c, err := r.Cookie(LastWriteCookie)
+	if err != nil {
+		return time.Time{}
+	}
+	ms, err := strconv.ParseInt(c.Value, 10, 64)
+	if err != nil || ms <= 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
+
+// lastWriteWriter はヘッダーを送る前に、リクエスト中に書き込んでいれば最後の書き込みの時刻のクッキーを付けるラッパー。
+type lastWriteWriter struct {
+	http.ResponseWriter
+	session     *database.Session
+	initial     time.Time
+	maxAge      int
+	secure      bool
+	wroteHeader bool
+}
+
+func (lw *lastWriteWriter) setCookie() {
+	if lw.wroteHeader {
+		return
+	}
+	lw.wroteHeader = true
+	at := lw.session.LastWrite()
+	if !at.After(lw.initial) {
+		return
+	}
+	http.SetCookie(lw.ResponseWriter, &http.Cookie{
+		Name:     LastWriteCookie,
+		Value:    strconv.FormatInt(at.UnixMilli(), 10),
+		Path:     "/",
+		MaxAge:   lw.maxAge,
+		HttpOnly: true,
+		Secure:   lw.secure,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func (lw *lastWriteWriter) WriteHeader(code int) {
+	lw.setCookie()
+	lw.ResponseWriter.WriteHeader(code)
+}
+
+func (lw *lastWriteWriter) Write(b []byte) (int, error) {
+	lw.setCookie()
+	return lw.ResponseWriter.Write(b)
+}
+
+// Flush は http.Flusher を実装する。
+func (lw *lastWriteWriter) Flush() {
+	lw.setCookie()
+	_ = http.NewResponseController(lw.ResponseWriter).Flush()
+}
+
+// Unwrap は http.ResponseController が元の ResponseWriter を辿るために使用する。
+func (lw *lastWriteWriter) Unwrap() http.ResponseWriter {
+	return lw.ResponseWriter
+}
@@ -0,0 +1,80 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/infrastructure/database"
+	"go-api/internal/presentation/http/middleware"
+)
+
+func TestReadYourWrites(t *testing.T) {
+	t.Run("リクエスト中に書き込んだ場合は最後の書き込みの時刻をクッキーで返す", func(t *testing.T) {
+		at := time.UnixMilli(1_700_000_000_000)
+		h := middleware.ReadYourWrites(1500 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			session, ok := database.SessionFromContext(r.Context())
+			require.True(t, ok)
+			session.MarkWritten(at)
+			w.WriteHeader(http.StatusCreated)
+		}))
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users", http.NoBody))
+
+		cookies := rec.Result().Cookies()
+		require.Len(t, cookies, 1)
+		assert.Equal(t, middleware.LastWriteCookie, cookies[0].Name)
+		assert.Equal(t, strconv.FormatInt(at.UnixMilli(), 10), cookies[0].Value)
+		assert.Equal(t, 2, cookies[0].MaxAge, "期間の秒数を切り上げる")
+		assert.True(t, cookies[0].HttpOnly)
+	})
+
+	t.Run("書き込まなかった場合はクッキーを返さない", func(t *testing.T) {
+		h := middleware.ReadYourWrites(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("ok"))
+		}))
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users", http.NoBody))
+
+		assert.Empty(t, rec.Result().Cookies())
+	})
+
+	t.Run("リクエストのクッキーの時刻をセッションの最後の書き込みとして格納する", func(t *testing.T) {
+		at := time.UnixMilli(1_700_000_000_000)
+		var got time.Time
+		h := middleware.ReadYourWrites(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			session, ok := database.SessionFromContext(r.Context())
+			require.True(t, ok)
+			got = session.LastWrite()
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/users", http.NoBody)
+		req.AddCookie(&http.Cookie{Name: middleware.LastWriteCookie, Value: strconv.FormatInt(at.UnixMilli(), 10)})
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		assert.True(t, at.Equal(got))
+		assert.Empty(t, rec.Result().Cookies(), "書き込んでいないため返し直さない")
+	})
+
+	t.Run("不正なクッキーは書き込んでいないものとして扱う", func(t *testing.T) {
+		var got time.Time
+		h := middleware.ReadYourWrites(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			session, _ := database.SessionFromContext(r.Context())
+			got = session.LastWrite()
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/users", http.NoBody)
+		req.AddCookie(&http.Cookie{Name: middleware.LastWriteCookie, Value: "invalid"})
+		h.ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.True(t, got.IsZero())
+	})
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"regexp"
+
+	"github.com/google/uuid"
+
+	"go-api/internal/presentation/http/requestctx"
+)
+
+// RequestIDHeader はリクエストIDを受け渡すヘッダー名。
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID はクライアントから受け取るリクエストIDの許容形式。
+// ログへの不正な文字列の混入を防ぐため、英数字と一部の記号のみ許可する。
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:\-]{1,128}$`)
+
+// RequestID はリクエストIDを付与するミドルウェア。
+// X-Request-ID ヘッダーが妥当な形式であれば引き継ぎ、なければ新規に生成する。
+// IDはコンテキストに格納し、レスポンスヘッダーにも返す。
+func RequestID() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := ResolveRequestID(r.Header.Get(RequestIDHeader))
+			w.Header().Set(RequestIDHeader, id)
+			next.ServeHTTP(w, r.WithContext(requestctx.WithRequestID(r.Context(), id)))
+		})
+	}
+}
+
+// ResolveRequestID はクライアントから受け取ったリクエストIDが妥当な形式であればそのまま返し、
+// そうでなければ新規に生成したIDを返す。
+func ResolveRequestID(id string) string {
+	if !validRequestID.MatchString(id) {
+		return uuid.NewString()
+	}
+	return id
+}
+
+// RoutePattern はマッチするルートパターンをコンテキストに格納するミドルウェア。
+// ServeMux はパターンをハンドラーに渡すリクエストにしか設定しないため、
+// 外側のミドルウェアやログからも参照できるよう事前に解決しておく。
+func RoutePattern(mux *http.ServeMux) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, pattern := mux.Handler(r)
+			next.ServeHTTP(w, r.WithContext(requestctx.WithRoutePattern(r.Context(), pattern)))
+		})
+	}
+}
+
+// LogAttrs はリクエストスコープのログ属性をコンテキストから取り出す。
+// logging.NewContextHandler に渡して使用する。
+func LogAttrs(ctx context.Context) []slog.Attr {
+	var attrs []slog.Attr
+	if id := requestctx.RequestID(ctx); id != "" {
+		attrs = append(attrs, slog.String("request_id", id))
+	}
+	if pattern := requestctx.RoutePattern(ctx); pattern != "" {
+		attrs = append(attrs, slog.String("route", pattern))
+	}
+	if p, ok := PrincipalFromContext(ctx); ok {
+		if p.ID != "" {
+			attrs = append(attrs, slog.String("principal", p.ID))
+		}
+		if p.Tenant != "" {
+			attrs = append(attrs, slog.String("tenant", p.Tenant))
+		}
+	}
+	return attrs
+}
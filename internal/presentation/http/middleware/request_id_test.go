@@ -0,0 +1,79 @@
+package middleware_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-api/internal/presentation/http/middleware"
+	"go-api/internal/presentation/http/requestctx"
+)
+
+func TestRequestID(t *testing.T) {
+	var got string
+	h := middleware.RequestID()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = requestctx.RequestID(r.Context())
+	}))
+
+	t.Run("ヘッダーのリクエストIDを引き継ぐ", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users", http.NoBody)
+		req.Header.Set(middleware.RequestIDHeader, "abc-123")
+		rec := httptest.NewRecorder()
+
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, "abc-123", got)
+		assert.Equal(t, "abc-123", rec.Header().Get(middleware.RequestIDHeader))
+	})
+
+	t.Run("ヘッダーがない場合は生成する", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users", http.NoBody))
+
+		assert.NotEmpty(t, got)
+		assert.Equal(t, got, rec.Header().Get(middleware.RequestIDHeader))
+	})
+
+	t.Run("不正な形式のIDは置き換える", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users", http.NoBody)
+		req.Header.Set(middleware.RequestIDHeader, "bad id\n")
+		rec := httptest.NewRecorder()
+
+		h.ServeHTTP(rec, req)
+
+		assert.NotEqual(t, "bad id\n", got)
+		assert.Equal(t, got, rec.Header().Get(middleware.RequestIDHeader))
+	})
+}
+
+func TestRoutePattern(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{id}", func(http.ResponseWriter, *http.Request) {})
+
+	var got string
+	h := middleware.RoutePattern(mux)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = requestctx.RoutePattern(r.Context())
+	}))
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/123", http.NoBody))
+
+	assert.Equal(t, "GET /users/{id}", got)
+}
+
+func TestLogAttrs(t *testing.T) {
+	ctx := requestctx.WithRequestID(context.Background(), "req-1")
+	ctx = requestctx.WithRoutePattern(ctx, "GET /users")
+	ctx = middleware.WithPrincipal(ctx, middleware.Principal{ID: "user-1", Tenant: "acme"})
+
+	assert.Equal(t, []slog.Attr{
+		slog.String("request_id", "req-1"),
+		slog.String("route", "GET /users"),
+		slog.String("principal", "user-1"),
+		slog.String("tenant", "acme"),
+	}, middleware.LogAttrs(ctx))
+}
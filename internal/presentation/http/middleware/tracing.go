@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"go-api/internal/presentation/http/requestctx"
+)
+
+// TracingOptions はトレースミドルウェアの設定。
+type TracingOptions struct {
+	TracerProvider trace.TracerProvider
+	// Propagator は受信したヘッダー（traceparent 等）から親スパンを復元する。
+	Propagator propagation.TextMapPropagator
+	// ClientIPs はクライアントIPの解決に使用する。
+	ClientIPs *ClientIPResolver
+}
+
+// Tracing はリクエストごとにサーバースパンを開始するミドルウェア。
+// W3C Trace Context の traceparent ヘッダーがあれば、そのトレースの子スパンとする。
+// ルートパターンを参照するため RoutePattern より内側に配置する。
+func Tracing(opts TracingOptions) func(http.Handler) http.Handler {
+	tracer := opts.TracerProvider.Tracer("go-api/http")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := opts.Propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+			route := httpRoute(requestctx.RoutePattern(ctx))
+			name := r.Method
+			if route != "" {
+				name += " " + route
+			}
+
+			ctx, span := tracer.Start(ctx, name,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(serverSpanAttrs(r, route, opts.ClientIPs.ClientIP(r))...),
+			)
+			defer span.End()
+
+			rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rw, r.WithContext(ctx))
+
+			span.SetAttributes(semconv.HTTPResponseStatusCode(rw.status))
+			// サーバースパンでは4xxはクライアント側の問題のためエラーとしない
+			if rw.status >= 500 {
+				span.SetAttributes(semconv.ErrorTypeKey.String(strconv.Itoa(rw.status)))
+				span.SetStatus(codes.Error, http.StatusText(rw.status))
+			}
+		})
+	}
+}
+
+// httpRoute はServeMuxのパターンから http.route 属性の値（パスのテンプレート）を返す。
+// "GET /users/{id}" のようにメソッドを含むパターンはメソッド部分を取り除く。
+func httpRoute(pattern string) string {
+	if i := strings.IndexByte(pattern, ' '); i >= 0 {
+		pattern = strings.TrimSpace(pattern[i+1:])
+	}
+	return pattern
+}
+
+// serverSpanAttrs はHTTPサーバースパンのセマンティック規約上の属性を返す。
+func serverSpanAttrs(r *http.Request, route, clientIP string) []attribute.KeyValue {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	attrs := append(httpMethodAttrs(r.Method),
+		semconv.URLPath(r.URL.Path),
+		semconv.URLScheme(scheme),
+		semconv.ClientAddress(clientIP),
+		semconv.NetworkProtocolVersion(fmt.Sprintf("%d.%d", r.ProtoMajor, r.ProtoMinor)),
+	)
+	if route != "" {
+		attrs = append(attrs, semconv.HTTPRoute(route))
+	}
+	if ua := r.UserAgent(); ua != "" {
+		attrs = append(attrs, semconv.UserAgentOriginal(ua))
+	}
+
+	host, port, err := net.SplitHostPort(r.Host)
+	if err != nil {
+		host = r.Host
+	}
+	if host != "" {
+		attrs = append(attrs, semconv.ServerAddress(host))
+	}
+	if p, err := strconv.Atoi(port); err == nil {
+		attrs = append(attrs, semconv.ServerPort(p))
+	}
+	return attrs
+}
+
+// httpMethodAttrs は http.request.method 属性を返す。
+// 既知でないメソッドは規約に従い "_OTHER" とし、元の値を http.request.method_original に残す。
+func httpMethodAttrs(method string) []attribute.KeyValue {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return []attribute.KeyValue{semconv.HTTPRequestMethodKey.String(method)}
+	default:
+		return []attribute.KeyValue{
+			semconv.HTTPRequestMethodKey.String("_OTHER"),
+			semconv.HTTPRequestMethodOriginal(method),
+		}
+	}
+}
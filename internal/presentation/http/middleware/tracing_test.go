@@ -0,0 +1,121 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"go-api/internal/presentation/http/middleware"
+)
+
+func TestTracing(t *testing.T) {
+	newHandler := func(t *testing.T, status int) (http.Handler, *tracetest.SpanRecorder) {
+		t.Helper()
+		sr := tracetest.NewSpanRecorder()
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+		clientIPs, err := middleware.NewClientIPResolver(nil)
+		require.NoError(t, err)
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+			// ハンドラーのコンテキストにサーバースパンが伝播していること
+			assert.True(t, trace.SpanContextFromContext(r.Context()).IsValid())
+			w.WriteHeader(status)
+		})
+		h := middleware.RoutePattern(mux)(middleware.Tracing(middleware.TracingOptions{
+			TracerProvider: tp,
+			Propagator:     propagation.TraceContext{},
+			ClientIPs:      clientIPs,
+		})(mux))
+		return h, sr
+	}
+
+	t.Run("traceparentのトレースを引き継ぎルートパターンでスパンを記録する", func(t *testing.T) {
+		h, sr := newHandler(t, http.StatusOK)
+		req := httptest.NewRequest(http.MethodGet, "http://example.com:8080/users/123", http.NoBody)
+		req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		req.Header.Set("User-Agent", "test-agent")
+
+		h.ServeHTTP(httptest.NewRecorder(), req)
+
+		spans := sr.Ended()
+		require.Len(t, spans, 1)
+		span := spans[0]
+		assert.Equal(t, "GET /users/{id}", span.Name())
+		assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+		assert.True(t, span.Parent().IsRemote())
+		assert.Equal(t, codes.Unset, span.Status().Code)
+
+		attrs := attribute.NewSet(span.Attributes()...)
+		for key, want := range map[attribute.Key]attribute.Value{
+			"http.request.method":       attribute.StringValue("GET"),
+			"http.route":                attribute.StringValue("/users/{id}"),
+			"http.response.status_code": attribute.IntValue(200),
+			"url.path":                  attribute.StringValue("/users/123"),
+			"url.scheme":                attribute.StringValue("http"),
+			"server.address":            attribute.StringValue("example.com"),
+			"server.port":               attribute.IntValue(8080),
+			"client.address":            attribute.StringValue("192.0.2.1"),
+			"user_agent.original":       attribute.StringValue("test-agent"),
+			"network.protocol.version":  attribute.StringValue("1.1"),
+		} {
+			got, ok := attrs.Value(key)
+			assert.True(t, ok, key)
+			assert.Equal(t, want, got, key)
+		}
+	})
+
+	t.Run("traceparentがない場合は新しいトレースを開始する", func(t *testing.T) {
+		h, sr := newHandler(t, http.StatusOK)
+
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/123", http.NoBody))
+
+		spans := sr.Ended()
+		require.Len(t, spans, 1)
+		assert.False(t, spans[0].Parent().IsValid())
+	})
+
+	t.Run("5xxの場合はスパンをエラーにする", func(t *testing.T) {
+		h, sr := newHandler(t, http.StatusInternalServerError)
+
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/123", http.NoBody))
+
+		spans := sr.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		attrs := attribute.NewSet(spans[0].Attributes()...)
+		errType, _ := attrs.Value("error.type")
+		assert.Equal(t, "500", errType.AsString())
+	})
+
+	t.Run("4xxの場合はスパンをエラーにしない", func(t *testing.T) {
+		h, sr := newHandler(t, http.StatusNotFound)
+
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/123", http.NoBody))
+
+		spans := sr.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	})
+
+	t.Run("ルートにマッチしない場合はメソッドのみをスパン名にする", func(t *testing.T) {
+		h, sr := newHandler(t, http.StatusOK)
+
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown", http.NoBody))
+
+		spans := sr.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "GET", spans[0].Name())
+	})
+}
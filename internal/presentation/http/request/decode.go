@@ -0,0 +1,184 @@
+// Package request はHTTPリクエストボディの読み取りを提供する。
+package request
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// MaxBodyBytes はリクエストボディの上限サイズ。
+const MaxBodyBytes = 1 << 20
+
+// エラーコード（BodyError.Code）。
+const (
+	CodeSyntaxError    = "syntax_error"
+	CodeInvalidType    = "invalid_type"
+	CodeUnknownField   = "unknown_field"
+	CodeEmptyBody      = "empty_body"
+	CodeMultipleValues = "multiple_values"
+)
+
+var (
+	// ErrInvalidBody はリクエストボディのJSONが不正な場合のエラー。BodyError はこのエラーとして判定される。
+	ErrInvalidBody = errors.New("invalid request body")
+
+	// ErrBodyTooLarge はリクエストボディが上限サイズを超えた場合のエラー。
+	ErrBodyTooLarge = fmt.Errorf("request body must not exceed %d bytes", MaxBodyBytes)
+
+	// ErrUnsupportedMediaType は Content-Type がJSONでない場合のエラー。
+	ErrUnsupportedMediaType = errors.New("content type must be application/json")
+)
+
+// BodyError はリクエストボディのJSONのどこが不正かを表すエラー。
+type BodyError struct {
+	Code    string // エラーコード（CodeSyntaxError 等）
+	Field   string // 不正なフィールドのパス（例: "name"）。特定できない場合は空
+	Offset  int64  // エラーを検出した位置（ボディ先頭からのバイト数）
+	Message string // エラーメッセージ
+
+	// 型の不一致（CodeInvalidType）の場合のJSONの型名（"string"・"number"・"integer"・"boolean"・"array"・"object"）
+	Expected string
+	Actual   string
+}
+
+// Error はerrorインターフェースを実装する。
+func (e *BodyError) Error() string {
+	return e.Message
+}
+
+// Is は errors.Is で ErrInvalidBody との比較を可能にする。
+func (e *BodyError) Is(target error) bool {
+	return target == ErrInvalidBody
+}
+
+// DecodeJSON はリクエストボディを1つのJSON値として dst にデコードする。
+// Content-Type がJSONでなければ ErrUnsupportedMediaType、MaxBodyBytes を超えれば ErrBodyTooLarge、
+// 未知のフィールド・型の不一致・構文エラー・複数の値を含む場合は *BodyError を返す。
+func DecodeJSON(w http.ResponseWriter, r *http.Request, dst any) error {
+	if !isJSON(r.Header.Get("Content-Type")) {
+		return ErrUnsupportedMediaType
+	}
+
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxBodyBytes))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(dst); err != nil {
+		return decodeError(err, dec.InputOffset())
+	}
+
+	// 2つ目の値や末尾の不正なデータがあれば拒否する
+	if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return ErrBodyTooLarge
+		}
+		return &BodyError{
+			Code:    CodeMultipleValues,
+			Offset:  dec.InputOffset(),
+			Message: "request body must contain a single JSON value",
+		}
+	}
+	return nil
+}
+
+// isJSON は Content-Type が application/json または application/*+json かを返す。
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" ||
+		(strings.HasPrefix(mediaType, "application/") && strings.HasSuffix(mediaType, "+json"))
+}
+
+// decodeError は encoding/json のエラーを、どのフィールドのどこが不正かを表すエラーに変換する。
+func decodeError(err error, offset int64) error {
+	var (
+		maxBytesErr *http.MaxBytesError
+		syntaxErr   *json.SyntaxError
+		typeErr     *json.UnmarshalTypeError
+	)
+	switch {
+	case errors.As(err, &maxBytesErr):
+		return ErrBodyTooLarge
+	case errors.Is(err, io.EOF):
+		return &BodyError{Code: CodeEmptyBody, Message: "request body must not be empty"}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return &BodyError{
+			Code:    CodeSyntaxError,
+			Offset:  offset,
+			Message: fmt.Sprintf("request body contains malformed JSON (unexpected end at offset %d)", offset),
+		}
+	case errors.As(err, &syntaxErr):
+		return &BodyError{
+			Code:    CodeSyntaxError,
+			Offset:  syntaxErr.Offset,
+			Message: fmt.Sprintf("request body contains malformed JSON at offset %d", syntaxErr.Offset),
+		}
+	case errors.As(err, &typeErr):
+		expected := jsonType(typeErr.Type)
+		actual, _, _ := strings.Cut(typeErr.Value, " ") // "number 1.5" のように値が付く場合がある
+		if actual == "bool" {
+			actual = "boolean"
+		}
+		return &BodyError{
+			Code:     CodeInvalidType,
+			Field:    typeErr.Field,
+			Offset:   typeErr.Offset,
+			Message:  fmt.Sprintf("%s must be %s, got %s (offset %d)", fieldLabel(typeErr.Field), withArticle(expected), actual, typeErr.Offset),
+			Expected: expected,
+			Actual:   actual,
+		}
+	}
+
+	// 未知のフィールドは専用のエラー型がないためメッセージから取り出す
+	if name, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		field := strings.Trim(name, `"`)
+		return &BodyError{
+			Code:    CodeUnknownField,
+			Field:   field,
+			Offset:  offset,
+			Message: fmt.Sprintf("unknown field %q (offset %d)", field, offset),
+		}
+	}
+	return &BodyError{Code: CodeSyntaxError, Offset: offset, Message: "request body contains malformed JSON"}
+}
+
+func fieldLabel(field string) string {
+	if field == "" {
+		return "request body"
+	}
+	return field
+}
+
+// jsonType はGoの型に対応するJSONの型名を返す。
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func withArticle(typeName string) string {
+	if strings.IndexAny(typeName[:1], "aeiou") == 0 {
+		return "an " + typeName
+	}
+	return "a " + typeName
+}
@@ -0,0 +1,145 @@
+package request_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/presentation/http/request"
+)
+
+type testBody struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+	Tags []struct {
+		Label string `json:"label"`
+	} `json:"tags"`
+}
+
+func decode(t *testing.T, contentType, body string) (testBody, error) {
+	t.Helper()
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+	var dst testBody
+	err := request.DecodeJSON(httptest.NewRecorder(), r, &dst)
+	return dst, err
+}
+
+func bodyError(t *testing.T, err error) *request.BodyError {
+	t.Helper()
+	var be *request.BodyError
+	require.ErrorAs(t, err, &be)
+	assert.ErrorIs(t, err, request.ErrInvalidBody)
+	return be
+}
+
+func TestDecodeJSON(t *testing.T) {
+	t.Run("正しいJSONをデコードする", func(t *testing.T) {
+		got, err := decode(t, "application/json; charset=utf-8", `{"name":"test","age":20}`)
+
+		require.NoError(t, err)
+		assert.Equal(t, "test", got.Name)
+		assert.Equal(t, 20, got.Age)
+	})
+
+	t.Run("+json のメディアタイプを受け付ける", func(t *testing.T) {
+		_, err := decode(t, "application/merge-patch+json", `{"name":"test"}`)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Content-Type がJSONでない場合はエラーを返す", func(t *testing.T) {
+		for _, ct := range []string{"", "text/plain", "application/x-www-form-urlencoded", "invalid;;"} {
+			_, err := decode(t, ct, `{"name":"test"}`)
+
+			assert.ErrorIs(t, err, request.ErrUnsupportedMediaType, ct)
+		}
+	})
+
+	t.Run("上限サイズを超える場合はエラーを返す", func(t *testing.T) {
+		body := `{"name":"` + strings.Repeat("a", request.MaxBodyBytes) + `"}`
+
+		_, err := decode(t, "application/json", body)
+
+		assert.ErrorIs(t, err, request.ErrBodyTooLarge)
+	})
+
+	t.Run("値の後ろが上限サイズを超える場合もエラーを返す", func(t *testing.T) {
+		body := `{"name":"test"}` + strings.Repeat(" ", request.MaxBodyBytes)
+
+		_, err := decode(t, "application/json", body)
+
+		assert.ErrorIs(t, err, request.ErrBodyTooLarge)
+	})
+
+	t.Run("未知のフィールドはフィールド名を返す", func(t *testing.T) {
+		_, err := decode(t, "application/json", `{"name":"test","role":"admin"}`)
+
+		be := bodyError(t, err)
+		assert.Equal(t, request.CodeUnknownField, be.Code)
+		assert.Equal(t, "role", be.Field)
+	})
+
+	t.Run("型の不一致はフィールドと位置を返す", func(t *testing.T) {
+		_, err := decode(t, "application/json", `{"name":"test","age":"20"}`)
+
+		be := bodyError(t, err)
+		assert.Equal(t, request.CodeInvalidType, be.Code)
+		assert.Equal(t, "age", be.Field)
+		assert.Equal(t, int64(25), be.Offset)
+		assert.Contains(t, be.Message, "age must be an integer, got string")
+		assert.Equal(t, "integer", be.Expected)
+		assert.Equal(t, "string", be.Actual)
+	})
+
+	t.Run("ネストしたフィールドの型の不一致はパスを返す", func(t *testing.T) {
+		_, err := decode(t, "application/json", `{"tags":[{"label":1}]}`)
+
+		be := bodyError(t, err)
+		assert.Equal(t, "tags.0.label", be.Field)
+		assert.Contains(t, be.Message, "must be a string, got number")
+	})
+
+	t.Run("構文エラーは位置を返す", func(t *testing.T) {
+		_, err := decode(t, "application/json", `{"name":}`)
+
+		be := bodyError(t, err)
+		assert.Equal(t, request.CodeSyntaxError, be.Code)
+		assert.Equal(t, int64(9), be.Offset)
+	})
+
+	t.Run("途中で終わるJSONは構文エラーを返す", func(t *testing.T) {
+		_, err := decode(t, "application/json", `{"name":"test"`)
+
+		be := bodyError(t, err)
+		assert.Equal(t, request.CodeSyntaxError, be.Code)
+	})
+
+	t.Run("空のボディはエラーを返す", func(t *testing.T) {
+		_, err := decode(t, "application/json", ``)
+
+		be := bodyError(t, err)
+		assert.Equal(t, request.CodeEmptyBody, be.Code)
+	})
+
+	t.Run("複数の値や末尾の不正なデータはエラーを返す", func(t *testing.T) {
+		for _, body := range []string{`{"name":"a"}{"name":"b"}`, `{"name":"a"} garbage`, `{"name":"a"}]`} {
+			_, err := decode(t, "application/json", body)
+
+			be := bodyError(t, err)
+			assert.Equal(t, request.CodeMultipleValues, be.Code, body)
+		}
+	})
+
+	t.Run("末尾の空白は許可する", func(t *testing.T) {
+		_, err := decode(t, "application/json", "{\"name\":\"a\"}\n  \n")
+
+		assert.NoError(t, err)
+	})
+}
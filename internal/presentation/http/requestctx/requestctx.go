@@ -0,0 +1,54 @@
+// Package requestctx はリクエストスコープの値をコンテキストで受け渡す。
+// middleware と httperrors の双方から参照するため独立したパッケージにしている。
+package requestctx
+
+import "context"
+
+type (
+	requestIDKey    struct{}
+	routePatternKey struct{}
+	errorInfoKey    struct{}
+)
+
+// WithRequestID はリクエストIDをコンテキストに格納する。
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID はコンテキストからリクエストIDを取り出す。未設定の場合は空文字を返す。
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// WithRoutePattern はマッチしたルートパターンをコンテキストに格納する。
+func WithRoutePattern(ctx context.Context, pattern string) context.Context {
+	return context.WithValue(ctx, routePatternKey{}, pattern)
+}
+
+// RoutePattern はコンテキストからルートパターンを取り出す。未設定の場合は空文字を返す。
+func RoutePattern(ctx context.Context) string {
+	p, _ := ctx.Value(routePatternKey{}).(string)
+	return p
+}
+
+// ErrorInfo はレスポンスとして返したエラーの情報。
+type ErrorInfo struct {
+	Code   string // エラーコード（例: "NOT_FOUND"）
+	Entity string // 失敗した操作のエンティティ名（domain.DomainError の場合）
+	Op     string // 失敗した操作名（domain.DomainError の場合）
+}
+
+// WithErrorInfo はエラーの情報を受け取る入れ物をコンテキストに格納する。
+// 内側のハンドラーが SetErrorInfo で設定した値を、返された関数で参照できる。
+func WithErrorInfo(ctx context.Context) (context.Context, func() ErrorInfo) {
+	info := &ErrorInfo{}
+	return context.WithValue(ctx, errorInfoKey{}, info), func() ErrorInfo { return *info }
+}
+
+// SetErrorInfo はエラーの情報を WithErrorInfo で格納した入れ物に設定する。入れ物がない場合は何もしない。
+func SetErrorInfo(ctx context.Context, info ErrorInfo) {
+	if p, ok := ctx.Value(errorInfoKey{}).(*ErrorInfo); ok {
+		*p = info
+	}
+}
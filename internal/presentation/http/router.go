@@ -1,10 +1,13 @@
 package httpapi
 
 import (
-	"encoding/json"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
 
+	graphqlapi "go-api/internal/presentation/graphql"
+	healthhandler "go-api/internal/presentation/http/handler/health"
 	userhandler "go-api/internal/presentation/http/handler/user"
 	"go-api/internal/presentation/http/middleware"
 )
@@ -17,6 +20,21 @@ type Dependencies interface {
 	GetUserHandler() *userhandler.GetHandler
 	UpdateUserHandler() *userhandler.UpdateHandler
 	DeleteUserHandler() *userhandler.DeleteHandler
+	UserEventsHandler() *userhandler.EventsHandler
+	GraphQLHandler() *graphqlapi.Handler
+	AccessLog() func(http.Handler) http.Handler
+	RateLimit(group string) func(http.Handler) http.Handler
+	Metrics() func(http.Handler) http.Handler
+	Tracing() func(http.Handler) http.Handler
+	CORS() func(http.Handler) http.Handler
+	ErrorFormat() func(http.Handler) http.Handler
+	ReadYourWrites() func(http.Handler) http.Handler
+	MetricsHandler() http.Handler
+	LiveHandler() *healthhandler.LiveHandler
+	ReadyHandler() *healthhandler.ReadyHandler
+	HealthDetailHandler() *healthhandler.DetailHandler
+	AdminAuth() func(http.Handler) http.Handler
+	SeparateAdminListener() bool
 	Logger() *slog.Logger
 }
 
@@ -24,25 +42,84 @@ type Dependencies interface {
 func NewRouter(deps Dependencies) http.Handler {
 	mux := http.NewServeMux()
 
-	// 基本エンドポイント
-	mux.HandleFunc("/health", handleHealth)
+	// ヘルスチェック
+	mux.Handle("GET /livez", deps.LiveHandler())
+	mux.Handle("GET /readyz", deps.ReadyHandler())
+	mux.Handle("GET /health", verbose(deps.ReadyHandler(), deps.AdminAuth()(deps.HealthDetailHandler())))
 
 	// ユーザー
-	mux.Handle("GET /users", deps.ListUserHandler())
-	mux.Handle("POST /users", deps.CreateUserHandler())
-	mux.Handle("GET /users/{id}", deps.GetUserHandler())
-	mux.Handle("PUT /users/{id}", deps.UpdateUserHandler())
-	mux.Handle("DELETE /users/{id}", deps.DeleteUserHandler())
+	usersRead := deps.RateLimit("users_read")
+	usersWrite := deps.RateLimit("users_write")
+	mux.Handle("GET /users", usersRead(deps.ListUserHandler()))
+	mux.Handle("POST /users", usersWrite(deps.CreateUserHandler()))
+	mux.Handle("GET /users/events", usersRead(deps.UserEventsHandler()))
+	mux.Handle("GET /users/{id}", usersRead(deps.GetUserHandler()))
+	mux.Handle("PUT /users/{id}", usersWrite(deps.UpdateUserHandler()))
+	mux.Handle("DELETE /users/{id}", usersWrite(deps.DeleteUserHandler()))
+
+	// GraphQL（GET は問い合わせと GraphiQL、POST は問い合わせと変更）
+	graphql := deps.RateLimit("graphql")(deps.GraphQLHandler())
+	mux.Handle("GET /graphql", graphql)
+	mux.Handle("POST /graphql", graphql)
+
+	// 管理用エンドポイント（別リスナーを使う場合は NewAdminRouter で公開する）
+	if !deps.SeparateAdminListener() {
+		registerAdminRoutes(mux, deps)
+	}
 
 	// ミドルウェア適用
 	var h http.Handler = mux
-	h = middleware.RequestLogger(deps.Logger())(h)
+	h = deps.ReadYourWrites()(h)
+	h = deps.CORS()(h) // プリフライトは ServeMux より先に応答する
 	h = middleware.Recover(deps.Logger())(h)
+	h = deps.Metrics()(h)
+	h = deps.AccessLog()(h)
+	h = deps.Tracing()(h)
+	h = middleware.RoutePattern(mux)(h)
+	h = deps.ErrorFormat()(h)
+	h = middleware.RequestID()(h)
 
 	return h
 }
 
-func handleHealth(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+// NewAdminRouter は管理用リスナー向けのHTTPルーターを生成する。
+func NewAdminRouter(deps Dependencies) http.Handler {
+	mux := http.NewServeMux()
+	registerAdminRoutes(mux, deps)
+
+	var h http.Handler = mux
+	h = middleware.Recover(deps.Logger())(h)
+	h = deps.ErrorFormat()(h)
+	return h
+}
+
+// registerAdminRoutes は運用向けのエンドポイントを登録する。
+func registerAdminRoutes(mux *http.ServeMux, deps Dependencies) {
+	if h := deps.MetricsHandler(); h != nil {
+		mux.Handle("GET /metrics", h)
+	}
+}
+
+// verbose は ?verbose（値なし）または ?verbose=true 等の真の値が指定された場合のみ detail に振り分けるハンドラーを返す。
+// ?verbose=false 等の偽の値や解釈できない値は summary に振り分け、管理者認証を求めない。
+func verbose(summary, detail http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isVerbose(r.URL.Query()) {
+			detail.ServeHTTP(w, r)
+			return
+		}
+		summary.ServeHTTP(w, r)
+	})
+}
+
+func isVerbose(q url.Values) bool {
+	if !q.Has("verbose") {
+		return false
+	}
+	v := q.Get("verbose")
+	if v == "" {
+		return true
+	}
+	b, err := strconv.ParseBool(v)
+	return err == nil && b
 }
@@ -0,0 +1,58 @@
+package httpapi
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-api/internal/presentation/http/middleware"
+)
+
+func TestVerbose(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	summary := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { _, _ = io.WriteString(w, "summary") })
+	detail := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { _, _ = io.WriteString(w, "detail") })
+	h := verbose(summary, middleware.AdminAuth("secret", logger)(detail))
+
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+		wantBody   string
+	}{
+		{"指定がない場合は認証なしで概要を返す", "/health", http.StatusOK, "summary"},
+		{"値なしは詳細として管理者認証を求める", "/health?verbose", http.StatusUnauthorized, ""},
+		{"trueは詳細として管理者認証を求める", "/health?verbose=true", http.StatusUnauthorized, ""},
+		{"1は詳細として管理者認証を求める", "/health?verbose=1", http.StatusUnauthorized, ""},
+		{"falseは認証なしで概要を返す", "/health?verbose=false", http.StatusOK, "summary"},
+		{"0は認証なしで概要を返す", "/health?verbose=0", http.StatusOK, "summary"},
+		{"解釈できない値は認証なしで概要を返す", "/health?verbose=yes", http.StatusOK, "summary"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, http.NoBody))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+
+	t.Run("管理者認証を通過すると詳細を返す", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/health?verbose=true", http.NoBody)
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "detail", rec.Body.String())
+	})
+}
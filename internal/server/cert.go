@@ -0,0 +1,101 @@
+package server
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// CertReloader は証明書と秘密鍵のファイルを監視し、更新されていれば読み込み直す。
+// 証明書のローテーション（cert-manager 等）に再起動なしで追従するために使う。
+type CertReloader struct {
+	certFile string
+	keyFile  string
+	interval time.Duration
+	logger   *slog.Logger
+	now      func() time.Time
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	certMod   time.Time
+	keyMod    time.Time
+	checkedAt time.Time
+}
+
+// NewCertReloader は証明書を読み込んで CertReloader を生成する。
+// interval はファイルの更新日時を確認する最短間隔。
+func NewCertReloader(certFile, keyFile string, interval time.Duration, logger *slog.Logger) (*CertReloader, error) {
+	r := &CertReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		interval: interval,
+		logger:   logger,
+		now:      time.Now,
+	}
+	certMod, keyMod, err := r.modTimes()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.load(certMod, keyMod); err != nil {
+		return nil, err
+	}
+	r.checkedAt = r.now()
+	return r, nil
+}
+
+// GetCertificate は tls.Config.GetCertificate として使用する。
+// 前回の確認から interval 以上経過していればファイルの更新を確認する。
+// 読み込みに失敗した場合はログに記録し、それまでの証明書を使い続ける。
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if now := r.now(); now.Sub(r.checkedAt) >= r.interval {
+		r.checkedAt = now
+		if err := r.reloadIfModified(); err != nil {
+			r.logger.Warn("tls certificate reload failed", "error", err.Error(), "cert_file", r.certFile)
+		}
+	}
+	return r.cert, nil
+}
+
+func (r *CertReloader) reloadIfModified() error {
+	certMod, keyMod, err := r.modTimes()
+	if err != nil {
+		return err
+	}
+	if certMod.Equal(r.certMod) && keyMod.Equal(r.keyMod) {
+		return nil
+	}
+	if err := r.load(certMod, keyMod); err != nil {
+		return err
+	}
+	r.logger.Info("tls certificate reloaded", "cert_file", r.certFile)
+	return nil
+}
+
+func (r *CertReloader) load(certMod, keyMod time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("load tls certificate: %w", err)
+	}
+	r.cert = &cert
+	r.certMod = certMod
+	r.keyMod = keyMod
+	return nil
+}
+
+func (r *CertReloader) modTimes() (certMod, keyMod time.Time, err error) {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("stat tls certificate: %w", err)
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("stat tls key: %w", err)
+	}
+	return certInfo.ModTime(), keyInfo.ModTime(), nil
+}
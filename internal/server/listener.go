@@ -0,0 +1,137 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"go-api/internal/config"
+)
+
+// NewHTTPServer は設定のタイムアウトとプロトコルを適用した http.Server を生成する。
+// 全てのリスナーで同じタイムアウトを使うため、サーバーはこの関数で生成する。
+func NewHTTPServer(h http.Handler, cfg config.ServerConfig) *http.Server {
+	var protocols http.Protocols
+	protocols.SetHTTP1(true)
+	protocols.SetHTTP2(true)
+	protocols.SetUnencryptedHTTP2(cfg.H2C)
+
+	return &http.Server{
+		Handler:      h,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
+		Protocols:    &protocols,
+	}
+}
+
+// Listen は設定に従ってAPIを公開するサーバーを生成する。
+// TCPのポート（TLSが設定されていればTLS）に加え、UnixSocket が設定されていれば
+// Unixドメインソケットでも公開する。
+func Listen(cfg config.ServerConfig, h http.Handler, logger *slog.Logger) ([]Server, error) {
+	ln, err := net.Listen("tcp", ":"+cfg.Port)
+	if err != nil {
+		return nil, err
+	}
+	api := Server{Name: "api", HTTP: NewHTTPServer(h, cfg), Listener: ln}
+	if cfg.TLS.Enabled() {
+		tlsCfg, err := NewTLSConfig(cfg.TLS, logger)
+		if err != nil {
+			_ = ln.Close()
+			return nil, err
+		}
+		api.HTTP.TLSConfig = tlsCfg
+	}
+	servers := []Server{api}
+
+	if cfg.UnixSocket != "" {
+		mode, err := strconv.ParseUint(cfg.UnixSocketMode, 8, 32)
+		if err != nil {
+			_ = ln.Close()
+			return nil, fmt.Errorf("parse unix socket mode: %w", err)
+		}
+		unixLn, err := ListenUnix(cfg.UnixSocket, os.FileMode(mode))
+		if err != nil {
+			_ = ln.Close()
+			return nil, err
+		}
+		servers = append(servers, Server{Name: "api-unix", HTTP: NewHTTPServer(h, cfg), Listener: unixLn})
+	}
+
+	return servers, nil
+}
+
+// NewGRPCCredentials はgRPCサーバーのトランスポートの認証情報を生成する。
+// TLSが設定されていればAPIのTCPリスナーと同じ証明書・クライアント証明書の検証を使い、なければ平文とする。
+func NewGRPCCredentials(cfg config.ServerConfig, logger *slog.Logger) (credentials.TransportCredentials, error) {
+	if !cfg.TLS.Enabled() {
+		return insecure.NewCredentials(), nil
+	}
+	tlsCfg, err := NewTLSConfig(cfg.TLS, logger)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(tlsCfg), nil
+}
+
+// NewTLSConfig は証明書の自動再読み込みと、ClientCAFile が設定されていればクライアント証明書の検証を行う tls.Config を生成する。
+func NewTLSConfig(cfg config.TLSConfig, logger *slog.Logger) (*tls.Config, error) {
+	certs, err := NewCertReloader(cfg.CertFile, cfg.KeyFile, cfg.ReloadInterval, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: certs.GetCertificate,
+	}
+
+	if cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("client CA %s: no certificates found", cfg.ClientCAFile)
+		}
+		tlsCfg.ClientCAs = pool
+		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsCfg, nil
+}
+
+// ListenUnix はUnixドメインソケットをリッスンし、パーミッションを mode に設定する。
+// 前回の起動で残ったソケットファイルは削除するが、ソケット以外のファイルがある場合はエラーにする。
+func ListenUnix(path string, mode os.FileMode) (net.Listener, error) {
+	if fi, err := os.Lstat(path); err == nil {
+		if fi.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("unix socket %s: file exists and is not a socket", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("remove stale unix socket: %w", err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("stat unix socket: %w", err)
+	}
+
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		_ = ln.Close()
+		return nil, fmt.Errorf("chmod unix socket: %w", err)
+	}
+	return ln, nil
+}
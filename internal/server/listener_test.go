@@ -0,0 +1,291 @@
+package server_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log/slog"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/config"
+	"go-api/internal/server"
+)
+
+// testCA はテスト用の証明書を発行する認証局。
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue は CommonName を持つ証明書と秘密鍵をPEMで発行する。
+func (ca *testCA) issue(t *testing.T, cn string, usage x509.ExtKeyUsage) (certPEM, keyPEM []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: cn},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// writeServerCert はサーバー証明書を発行してファイルに書き込む。
+func writeServerCert(t *testing.T, ca *testCA, dir, cn string, modTime time.Time) (certFile, keyFile string) {
+	t.Helper()
+	certPEM, keyPEM := ca.issue(t, cn, x509.ExtKeyUsageServerAuth)
+	certFile = filepath.Join(dir, "tls.crt")
+	keyFile = filepath.Join(dir, "tls.key")
+	require.NoError(t, os.WriteFile(certFile, certPEM, 0o600))
+	require.NoError(t, os.WriteFile(keyFile, keyPEM, 0o600))
+	require.NoError(t, os.Chtimes(certFile, modTime, modTime))
+	require.NoError(t, os.Chtimes(keyFile, modTime, modTime))
+	return certFile, keyFile
+}
+
+// startServers はサーバーを起動し、テスト終了時に停止する。
+func startServers(t *testing.T, servers []server.Server) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- server.Run(ctx, servers, server.Options{
+			ShutdownTimeout: time.Second,
+			Logger:          slog.New(slog.NewTextHandler(io.Discard, nil)),
+		})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-done)
+	})
+}
+
+func okHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.Proto)
+	})
+}
+
+// serverCN はTLSハンドシェイクで提示されたサーバー証明書の CommonName を返す。
+func serverCN(t *testing.T, addr string, roots *x509.CertPool) string {
+	t.Helper()
+	conn, err := tls.Dial("tcp", addr, &tls.Config{RootCAs: roots, ServerName: "localhost"})
+	require.NoError(t, err)
+	defer conn.Close()
+	return conn.ConnectionState().PeerCertificates[0].Subject.CommonName
+}
+
+func TestListenTLS(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ca := newTestCA(t)
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(ca.pem)
+
+	t.Run("TLSとHTTP/2で応答し、更新された証明書を再起動なしで読み込む", func(t *testing.T) {
+		dir := t.TempDir()
+		certFile, keyFile := writeServerCert(t, ca, dir, "v1", time.Now().Add(-time.Minute))
+
+		servers, err := server.Listen(config.ServerConfig{
+			Port: "0",
+			TLS:  config.TLSConfig{CertFile: certFile, KeyFile: keyFile, ReloadInterval: time.Millisecond},
+		}, okHandler(), logger)
+		require.NoError(t, err)
+		require.Len(t, servers, 1)
+		addr := servers[0].Listener.Addr().String()
+		startServers(t, servers)
+
+		client := &http.Client{Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{RootCAs: roots, ServerName: "localhost"},
+			ForceAttemptHTTP2: true,
+		}}
+		resp, err := client.Get("https://" + addr)
+		require.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		assert.Equal(t, "HTTP/2.0", string(body))
+		assert.Equal(t, "v1", serverCN(t, addr, roots))
+
+		writeServerCert(t, ca, dir, "v2", time.Now())
+		assert.Eventually(t, func() bool {
+			return serverCN(t, addr, roots) == "v2"
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("クライアントCAを設定するとクライアント証明書を要求する", func(t *testing.T) {
+		dir := t.TempDir()
+		certFile, keyFile := writeServerCert(t, ca, dir, "server", time.Now())
+		caFile := filepath.Join(dir, "ca.crt")
+		require.NoError(t, os.WriteFile(caFile, ca.pem, 0o600))
+
+		servers, err := server.Listen(config.ServerConfig{
+			Port: "0",
+			TLS: config.TLSConfig{
+				CertFile:       certFile,
+				KeyFile:        keyFile,
+				ClientCAFile:   caFile,
+				ReloadInterval: time.Minute,
+			},
+		}, okHandler(), logger)
+		require.NoError(t, err)
+		url := "https://" + servers[0].Listener.Addr().String()
+		startServers(t, servers)
+
+		noCert := &http.Client{Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: roots, ServerName: "localhost"},
+		}}
+		_, err = noCert.Get(url)
+		assert.Error(t, err)
+
+		clientCertPEM, clientKeyPEM := ca.issue(t, "client", x509.ExtKeyUsageClientAuth)
+		clientCert, err := tls.X509KeyPair(clientCertPEM, clientKeyPEM)
+		require.NoError(t, err)
+		withCert := &http.Client{Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: roots, ServerName: "localhost", Certificates: []tls.Certificate{clientCert}},
+		}}
+		resp, err := withCert.Get(url)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("証明書が読み込めない場合はエラー", func(t *testing.T) {
+		dir := t.TempDir()
+		_, err := server.Listen(config.ServerConfig{
+			Port: "0",
+			TLS: config.TLSConfig{
+				CertFile:       filepath.Join(dir, "missing.crt"),
+				KeyFile:        filepath.Join(dir, "missing.key"),
+				ReloadInterval: time.Minute,
+			},
+		}, okHandler(), logger)
+		assert.Error(t, err)
+	})
+}
+
+func TestListenH2C(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	servers, err := server.Listen(config.ServerConfig{Port: "0", H2C: true}, okHandler(), logger)
+	require.NoError(t, err)
+	addr := servers[0].Listener.Addr().String()
+	startServers(t, servers)
+
+	var protocols http.Protocols
+	protocols.SetUnencryptedHTTP2(true)
+	client := &http.Client{Transport: &http.Transport{Protocols: &protocols}}
+
+	resp, err := client.Get("http://" + addr)
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	assert.Equal(t, "HTTP/2.0", string(body))
+}
+
+func TestListenUnix(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	// macOS ではソケットのパス長の上限が短いため短いディレクトリを使う
+	dir, err := os.MkdirTemp("", "sock")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	path := filepath.Join(dir, "api.sock")
+
+	t.Run("指定したパーミッションでソケットを作成し、残ったソケットは置き換える", func(t *testing.T) {
+		stale, err := net.Listen("unix", path)
+		require.NoError(t, err)
+		stale.(*net.UnixListener).SetUnlinkOnClose(false)
+		require.NoError(t, stale.Close())
+
+		servers, err := server.Listen(config.ServerConfig{
+			Port:           "0",
+			UnixSocket:     path,
+			UnixSocketMode: "0600",
+		}, okHandler(), logger)
+		require.NoError(t, err)
+		require.Len(t, servers, 2)
+		assert.Equal(t, "api-unix", servers[1].Name)
+
+		fi, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), fi.Mode().Perm())
+
+		startServers(t, servers)
+		client := &http.Client{Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", path)
+			},
+		}}
+		resp, err := client.Get("http://unix/")
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("ソケット以外のファイルがある場合はエラー", func(t *testing.T) {
+		regular := filepath.Join(dir, "regular")
+		require.NoError(t, os.WriteFile(regular, nil, 0o600))
+
+		_, err := server.ListenUnix(regular, 0o600)
+		assert.ErrorContains(t, err, "not a socket")
+	})
+}
+
+func TestNewHTTPServer(t *testing.T) {
+	srv := server.NewHTTPServer(okHandler(), config.ServerConfig{
+		ReadTimeout:  time.Second,
+		WriteTimeout: 2 * time.Second,
+		IdleTimeout:  3 * time.Second,
+	})
+
+	assert.Equal(t, time.Second, srv.ReadTimeout)
+	assert.Equal(t, 2*time.Second, srv.WriteTimeout)
+	assert.Equal(t, 3*time.Second, srv.IdleTimeout)
+	assert.False(t, srv.Protocols.UnencryptedHTTP2())
+}
@@ -0,0 +1,147 @@
+// Package server はHTTP・gRPCサーバーの起動とシグナルによる graceful shutdown を提供する。
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"go-api/internal/health"
+)
+
+// Server は名前付きのサーバーとそのリスナーの組。HTTP と GRPC のどちらか一方を設定する。
+type Server struct {
+	Name     string
+	HTTP     *http.Server
+	GRPC     *grpc.Server
+	Listener net.Listener
+}
+
+// Options は Run の設定。
+type Options struct {
+	// Readiness は停止処理の開始時に ShuttingDown に切り替えられる。
+	Readiness *health.Readiness
+	// DrainDelay は準備完了を取り下げてから Shutdown を呼ぶまでの待ち時間。
+	// ロードバランサーが振り分け対象から外すまでの間も新しいリクエストを処理し続ける。
+	DrainDelay time.Duration
+	// ShutdownTimeout は処理中のリクエストの完了と Stop の実行を待つ上限。
+	ShutdownTimeout time.Duration
+	// Stop はサーバー停止後に登録順に実行される停止処理（バックグラウンドワーカーの停止等）。
+	Stop   []func(context.Context) error
+	Logger *slog.Logger
+}
+
+// Run はすべてのサーバーを起動し、ctx がキャンセルされるかいずれかのサーバーが
+// 異常終了するまでブロックする。その後、準備完了の取り下げ、DrainDelay の待機、
+// http.Server.Shutdown・grpc.Server.GracefulStop による処理中リクエストの完了待ち、Stop の実行の順に停止する。
+func Run(ctx context.Context, servers []Server, opts Options) error {
+	serveErr := make(chan error, len(servers))
+	for _, s := range servers {
+		opts.Logger.Info("server starting", "name", s.Name, "addr", s.Listener.Addr().String())
+		go func() {
+			if err := serve(s); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				serveErr <- fmt.Errorf("%s server: %w", s.Name, err)
+			}
+		}()
+	}
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		opts.Logger.Info("shutdown signal received")
+	case runErr = <-serveErr:
+		opts.Logger.Error("server stopped unexpectedly", "error", runErr.Error())
+	}
+
+	if opts.Readiness != nil {
+		opts.Readiness.SetShuttingDown()
+	}
+	// 異常終了時は新しいリクエストを受け付けられないため待機しない
+	if runErr == nil && opts.DrainDelay > 0 {
+		opts.Logger.Info("draining", "delay", opts.DrainDelay)
+		time.Sleep(opts.DrainDelay)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), opts.ShutdownTimeout)
+	defer cancel()
+
+	err := shutdownServers(shutdownCtx, servers)
+	for _, stop := range opts.Stop {
+		err = errors.Join(err, stop(shutdownCtx))
+	}
+	if err != nil {
+		return errors.Join(runErr, fmt.Errorf("shutdown: %w", err))
+	}
+
+	opts.Logger.Info("shutdown completed")
+	return runErr
+}
+
+// serve はリスナーで接続の受付を開始する。TLSConfig が設定されていればTLSで受け付ける。
+// gRPCサーバーのTLSは生成時の grpc.Creds で設定する。
+func serve(s Server) error {
+	if s.GRPC != nil {
+		return s.GRPC.Serve(s.Listener)
+	}
+	if s.HTTP.TLSConfig != nil {
+		// 証明書は TLSConfig.GetCertificate から取得する
+		return s.HTTP.ServeTLS(s.Listener, "", "")
+	}
+	return s.HTTP.Serve(s.Listener)
+}
+
+// shutdownServers はすべてのサーバーを並行して停止し、処理中のリクエストの完了を待つ。
+// ctx の期限までに完了しないサーバーは接続を強制的に閉じる。
+func shutdownServers(ctx context.Context, servers []Server) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for _, s := range servers {
+		wg.Go(func() {
+			err := shutdown(ctx, s)
+			if err != nil {
+				err = fmt.Errorf("%s server: %w", s.Name, err)
+			}
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+		})
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// shutdown はサーバーを停止し、処理中のリクエストの完了を待つ。
+// ctx の期限までに完了しない場合は接続を強制的に閉じる。
+func shutdown(ctx context.Context, s Server) error {
+	if s.GRPC == nil {
+		if err := s.HTTP.Shutdown(ctx); err != nil {
+			return errors.Join(err, s.HTTP.Close())
+		}
+		return nil
+	}
+
+	// GracefulStop は ctx を受け取らないため、期限を過ぎたら Stop で打ち切る
+	done := make(chan struct{})
+	go func() {
+		s.GRPC.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.GRPC.Stop()
+		<-done
+		return ctx.Err()
+	}
+}
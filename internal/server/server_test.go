@@ -0,0 +1,207 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"go-api/internal/health"
+	"go-api/internal/server"
+)
+
+// newTestServer は空きポートをリッスンするサーバーを生成する。
+func newTestServer(t *testing.T, h http.Handler) server.Server {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	return server.Server{Name: "test", HTTP: &http.Server{Handler: h}, Listener: ln}
+}
+
+func TestRun(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("停止時に処理中のリクエストを完了させてから停止処理を実行する", func(t *testing.T) {
+		started := make(chan struct{})
+		release := make(chan struct{})
+		srv := newTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+			_, _ = io.WriteString(w, "done")
+		}))
+		url := "http://" + srv.Listener.Addr().String()
+
+		readiness := health.NewReadiness()
+		var stopped []string
+		ctx, cancel := context.WithCancel(context.Background())
+		runErr := make(chan error, 1)
+		go func() {
+			runErr <- server.Run(ctx, []server.Server{srv}, server.Options{
+				Readiness:       readiness,
+				ShutdownTimeout: 5 * time.Second,
+				Stop: []func(context.Context) error{
+					func(context.Context) error { stopped = append(stopped, "workers"); return nil },
+					func(context.Context) error { stopped = append(stopped, "tracing"); return nil },
+				},
+				Logger: logger,
+			})
+		}()
+
+		type result struct {
+			body string
+			err  error
+		}
+		resCh := make(chan result, 1)
+		go func() {
+			resp, err := http.Get(url)
+			if err != nil {
+				resCh <- result{err: err}
+				return
+			}
+			defer resp.Body.Close()
+			b, err := io.ReadAll(resp.Body)
+			resCh <- result{body: string(b), err: err}
+		}()
+
+		<-started
+		cancel()
+
+		// 停止処理が始まり準備完了が取り下げられるのを待つ
+		require.Eventually(t, readiness.ShuttingDown, time.Second, 10*time.Millisecond)
+		// Shutdown はリスナーを閉じた後、処理中のリクエストの完了を待つ
+		require.Eventually(t, func() bool {
+			_, err := net.Dial("tcp", srv.Listener.Addr().String())
+			return err != nil
+		}, time.Second, 10*time.Millisecond)
+		select {
+		case err := <-runErr:
+			t.Fatalf("Run returned before the in-flight request completed: %v", err)
+		default:
+		}
+		assert.Empty(t, stopped)
+
+		close(release)
+
+		res := <-resCh
+		require.NoError(t, res.err)
+		assert.Equal(t, "done", res.body)
+		require.NoError(t, <-runErr)
+		assert.Equal(t, []string{"workers", "tracing"}, stopped)
+	})
+
+	t.Run("DrainDelayの間は新しいリクエストを処理する", func(t *testing.T) {
+		srv := newTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		url := "http://" + srv.Listener.Addr().String()
+
+		readiness := health.NewReadiness()
+		ctx, cancel := context.WithCancel(context.Background())
+		runErr := make(chan error, 1)
+		go func() {
+			runErr <- server.Run(ctx, []server.Server{srv}, server.Options{
+				Readiness:       readiness,
+				DrainDelay:      300 * time.Millisecond,
+				ShutdownTimeout: 5 * time.Second,
+				Logger:          logger,
+			})
+		}()
+
+		cancel()
+		require.Eventually(t, readiness.ShuttingDown, time.Second, 10*time.Millisecond)
+
+		resp, err := http.Get(url)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		require.NoError(t, <-runErr)
+	})
+
+	t.Run("ShutdownTimeoutを超えた場合は接続を閉じてエラーを返す", func(t *testing.T) {
+		started := make(chan struct{})
+		srv := newTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-r.Context().Done()
+		}))
+		url := "http://" + srv.Listener.Addr().String()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		runErr := make(chan error, 1)
+		go func() {
+			runErr <- server.Run(ctx, []server.Server{srv}, server.Options{
+				ShutdownTimeout: 100 * time.Millisecond,
+				Logger:          logger,
+			})
+		}()
+
+		go func() {
+			resp, err := http.Get(url)
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+		}()
+		<-started
+		cancel()
+
+		err := <-runErr
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+
+	t.Run("サーバーが異常終了した場合はエラーを返す", func(t *testing.T) {
+		srv := newTestServer(t, http.NotFoundHandler())
+		require.NoError(t, srv.Listener.Close())
+
+		err := server.Run(context.Background(), []server.Server{srv}, server.Options{
+			ShutdownTimeout: time.Second,
+			Logger:          logger,
+		})
+
+		require.Error(t, err)
+	})
+
+	t.Run("gRPCサーバーは処理中のストリームを待ち、ShutdownTimeoutを超えたら打ち切る", func(t *testing.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		grpcServer := grpc.NewServer()
+		healthpb.RegisterHealthServer(grpcServer, grpchealth.NewServer())
+		srv := server.Server{Name: "grpc", GRPC: grpcServer, Listener: ln}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		runErr := make(chan error, 1)
+		go func() {
+			runErr <- server.Run(ctx, []server.Server{srv}, server.Options{
+				ShutdownTimeout: 100 * time.Millisecond,
+				Logger:          logger,
+			})
+		}()
+
+		conn, err := grpc.NewClient(ln.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		defer func() { _ = conn.Close() }()
+
+		// Watch はクライアントが切断するまで終わらないストリーム
+		stream, err := healthpb.NewHealthClient(conn).Watch(context.Background(), &healthpb.HealthCheckRequest{})
+		require.NoError(t, err)
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+		cancel()
+
+		err = <-runErr
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.ErrorContains(t, err, "grpc server")
+	})
+}
@@ -15,3 +15,13 @@ type User struct {
 	CreatedAt pgtype.Timestamptz
 	UpdatedAt pgtype.Timestamptz
 }
+
+type UserEvent struct {
+	Seq        int64
+	Type       string
+	UserID     pgtype.UUID
+	Name       string
+	Email      string
+	OccurredAt pgtype.Timestamptz
+	Txid       int64
+}
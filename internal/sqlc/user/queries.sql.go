@@ -11,20 +11,16 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const createUser = `-- name: CreateUser :exec
-INSERT INTO users (id, name, email)
-VALUES ($1, $2, $3)
+const countUsers = `-- name: CountUsers :one
+SELECT COUNT(*)
+FROM users
 `
 
-type CreateUserParams struct {
-	ID    pgtype.UUID
-	Name  string
-	Email string
-}
-
-func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) error {
-	_, err := q.db.Exec(ctx, createUser, arg.ID, arg.Name, arg.Email)
-	return err
+func (q *Queries) CountUsers(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countUsers)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
 const deleteUser = `-- name: DeleteUser :exec
@@ -59,7 +55,7 @@ func (q *Queries) GetUser(ctx context.Context, id pgtype.UUID) (User, error) {
 const listUsers = `-- name: ListUsers :many
 SELECT id, name, email, created_at, updated_at
 FROM users
-ORDER BY created_at DESC
+ORDER BY created_at DESC, id DESC
 `
 
 func (q *Queries) ListUsers(ctx context.Context) ([]User, error) {
@@ -87,3 +83,126 @@ func (q *Queries) ListUsers(ctx context.Context) ([]User, error) {
 	}
 	return items, nil
 }
+
+const listUsersByIDs = `-- name: ListUsersByIDs :many
+SELECT id, name, email, created_at, updated_at
+FROM users
+WHERE id = ANY($1::uuid[])
+`
+
+func (q *Queries) ListUsersByIDs(ctx context.Context, ids []pgtype.UUID) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsersByIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersFirstPage = `-- name: ListUsersFirstPage :many
+SELECT id, name, email, created_at, updated_at
+FROM users
+ORDER BY created_at DESC, id DESC
+LIMIT $1
+`
+
+func (q *Queries) ListUsersFirstPage(ctx context.Context, limit int32) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsersFirstPage, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersPageAfter = `-- name: ListUsersPageAfter :many
+SELECT id, name, email, created_at, updated_at
+FROM users
+WHERE (created_at, id) < ($1::timestamptz, $2::uuid)
+ORDER BY created_at DESC, id DESC
+LIMIT $3
+`
+
+type ListUsersPageAfterParams struct {
+	CreatedAt pgtype.Timestamptz
+	ID        pgtype.UUID
+	MaxRows   int32
+}
+
+func (q *Queries) ListUsersPageAfter(ctx context.Context, arg ListUsersPageAfterParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsersPageAfter, arg.CreatedAt, arg.ID, arg.MaxRows)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertUser = `-- name: UpsertUser :exec
+INSERT INTO users (id, name, email)
+VALUES ($1, $2, $3)
+ON CONFLICT (id) DO UPDATE
+SET name = EXCLUDED.name, email = EXCLUDED.email
+`
+
+type UpsertUserParams struct {
+	ID    pgtype.UUID
+	Name  string
+	Email string
+}
+
+func (q *Queries) UpsertUser(ctx context.Context, arg UpsertUserParams) error {
+	_, err := q.db.Exec(ctx, upsertUser, arg.ID, arg.Name, arg.Email)
+	return err
+}
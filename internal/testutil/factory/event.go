@@ -0,0 +1,18 @@
+package factory
+
+import (
+	"time"
+
+	"go-api/internal/domain/user"
+	"go-api/internal/domain/user/valueobject"
+)
+
+// NewEvent はテスト用のユーザーの変更イベントを生成する
+func NewEvent(seq int64, typ valueobject.EventType, u *user.User) *user.Event {
+	return &user.Event{
+		Seq:        seq,
+		Type:       typ,
+		User:       u,
+		OccurredAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(seq) * time.Second),
+	}
+}
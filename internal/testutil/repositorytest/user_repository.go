@@ -0,0 +1,288 @@
+// Package repositorytest はリポジトリの実装が満たすべき振る舞いのテストを提供する。
+// 各実装のテストから呼び出し、PostgreSQL・インメモリ等の実装の間で振る舞いを揃える。
+package repositorytest
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/domain"
+	"go-api/internal/domain/user"
+	"go-api/internal/domain/user/valueobject"
+	"go-api/internal/testutil/factory"
+)
+
+const testTimeout = 5 * time.Second
+
+// RunUserRepository は user.UserRepository の実装が満たすべき振る舞いをテストする。
+// newRepo はサブテストごとに呼ばれる。他のテストのデータが残っている実装でも通るよう、
+// テストで保存したユーザーだけを検証し、終了時に削除する。
+// 作成順の検証のため、保存はトランザクションで囲まずに確定させること。
+func RunUserRepository(t *testing.T, newRepo func(t *testing.T) user.UserRepository) {
+	setup := func(t *testing.T) (context.Context, user.UserRepository, func(...*user.User)) {
+		t.Helper()
+		ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+		t.Cleanup(cancel)
+		repo := newRepo(t)
+		save := func(users ...*user.User) {
+			t.Helper()
+			for _, u := range users {
+				require.NoError(t, repo.Save(ctx, u), "ユーザーの保存に失敗")
+				t.Cleanup(func() { _ = repo.Delete(context.Background(), u.ID()) })
+			}
+		}
+		return ctx, repo, save
+	}
+
+	t.Run("保存したユーザーをIDで取得できる", func(t *testing.T) {
+		ctx, repo, save := setup(t)
+		u := factory.NewUser(factory.WithName("test"))
+		save(u)
+
+		got, err := repo.FindByID(ctx, u.ID())
+		require.NoError(t, err)
+		assert.Equal(t, u.ID(), got.ID())
+		assert.Equal(t, "test", got.Name().String())
+		assert.Equal(t, u.Email(), got.Email())
+	})
+
+	t.Run("存在しないIDはErrNotFoundを返す", func(t *testing.T) {
+		ctx, repo, _ := setup(t)
+
+		_, err := repo.FindByID(ctx, valueobject.NewUserID())
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("既存のIDで保存すると名前とメールアドレスを更新する", func(t *testing.T) {
+		ctx, repo, save := setup(t)
+		u := factory.NewUser(factory.WithName("before"))
+		save(u)
+
+		name, err := valueobject.NewUserName("after")
+		require.NoError(t, err)
+		email, err := valueobject.NewEmail("after-" + u.Email().String())
+		require.NoError(t, err)
+		u.ChangeName(name)
+		u.ChangeEmail(email)
+		require.NoError(t, repo.Save(ctx, u))
+
+		got, err := repo.FindByID(ctx, u.ID())
+		require.NoError(t, err)
+		assert.Equal(t, "after", got.Name().String())
+		assert.Equal(t, email, got.Email())
+	})
+
+	t.Run("他のユーザーと同じメールアドレスはErrConflictを返し、保存済みのユーザーを変更しない", func(t *testing.T) {
+		ctx, repo, save := setup(t)
+		a := factory.NewUser()
+		b := factory.NewUser(factory.WithName("b"))
+		save(a, b)
+
+		err := repo.Save(ctx, factory.NewUser(factory.WithEmail(a.Email().String())))
+		assert.ErrorIs(t, err, domain.ErrConflict, "新しいユーザー")
+
+		b.ChangeEmail(a.Email())
+		err = repo.Save(ctx, b)
+		assert.ErrorIs(t, err, domain.ErrConflict, "既存のユーザーの更新")
+
+		got, err := repo.FindByID(ctx, b.ID())
+		require.NoError(t, err)
+		assert.NotEqual(t, a.Email(), got.Email())
+	})
+
+	t.Run("同じメールアドレスのまま更新できる", func(t *testing.T) {
+		ctx, repo, save := setup(t)
+		u := factory.NewUser()
+		save(u)
+
+		name, err := valueobject.NewUserName("renamed")
+		require.NoError(t, err)
+		u.ChangeName(name)
+		assert.NoError(t, repo.Save(ctx, u))
+	})
+
+	t.Run("取得したエンティティを変更しても保存するまで反映されない", func(t *testing.T) {
+		ctx, repo, save := setup(t)
+		u := factory.NewUser(factory.WithName("saved"))
+		save(u)
+
+		got, err := repo.FindByID(ctx, u.ID())
+		require.NoError(t, err)
+		name, err := valueobject.NewUserName("unsaved")
+		require.NoError(t, err)
+		got.ChangeName(name)
+		u.ChangeName(name)
+
+		got, err = repo.FindByID(ctx, u.ID())
+		require.NoError(t, err)
+		assert.Equal(t, "saved", got.Name().String())
+	})
+
+	t.Run("FindByIDsは存在するIDのユーザーだけを返す", func(t *testing.T) {
+		ctx, repo, save := setup(t)
+		a, b := factory.NewUser(), factory.NewUser()
+		save(a, b)
+
+		users, err := repo.FindByIDs(ctx, []valueobject.UserID{a.ID(), valueobject.NewUserID(), b.ID()})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []valueobject.UserID{a.ID(), b.ID()}, ids(users))
+	})
+
+	t.Run("FindAllはFindPageと同じ一覧の並び順で返し、更新しても順序は変わらない", func(t *testing.T) {
+		ctx, repo, save := setup(t)
+		first, second, third := factory.NewUser(), factory.NewUser(), factory.NewUser()
+		save(first, second, third)
+		saved := []valueobject.UserID{first.ID(), second.ID(), third.ID()}
+
+		before, err := repo.FindAll(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, only(ids(allPages(t, ctx, repo, nil, 2)), saved...), only(ids(before), saved...))
+
+		name, err := valueobject.NewUserName("updated")
+		require.NoError(t, err)
+		first.ChangeName(name)
+		require.NoError(t, repo.Save(ctx, first))
+
+		after, err := repo.FindAll(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, only(ids(before), saved...), only(ids(after), saved...))
+	})
+
+	t.Run("保存したユーザーは作成日時を持ち、更新しても変わらない", func(t *testing.T) {
+		ctx, repo, save := setup(t)
+		u := factory.NewUser()
+		save(u)
+
+		created, err := repo.FindByID(ctx, u.ID())
+		require.NoError(t, err)
+		assert.False(t, created.CreatedAt().IsZero())
+
+		name, err := valueobject.NewUserName("updated")
+		require.NoError(t, err)
+		created.ChangeName(name)
+		require.NoError(t, repo.Save(ctx, created))
+
+		got, err := repo.FindByID(ctx, u.ID())
+		require.NoError(t, err)
+		assert.True(t, created.CreatedAt().Equal(got.CreatedAt()))
+	})
+
+	t.Run("FindPageは一覧の並び順で重複なく全件を辿れる", func(t *testing.T) {
+		ctx, repo, save := setup(t)
+		saved := []*user.User{factory.NewUser(), factory.NewUser(), factory.NewUser(), factory.NewUser(), factory.NewUser()}
+		save(saved...)
+
+		users := allPages(t, ctx, repo, nil, 2)
+
+		seen := make(map[string]bool, len(users))
+		for i, u := range users {
+			assert.False(t, seen[u.ID().String()], "重複: %s", u.ID())
+			seen[u.ID().String()] = true
+			if i > 0 {
+				assert.True(t, user.CursorOf(users[i-1]).Before(user.CursorOf(u)), "%d番目が並び順に反する", i)
+			}
+		}
+		assert.ElementsMatch(t, ids(saved), only(ids(users), ids(saved)...))
+	})
+
+	t.Run("FindPageはカーソルのユーザーが削除されていても続きから返す", func(t *testing.T) {
+		ctx, repo, save := setup(t)
+		a, b, c := factory.NewUser(), factory.NewUser(), factory.NewUser()
+		save(a, b, c)
+		// 作成日時が同じ場合はIDで並ぶため、保存した順ではなく一覧の並び順で前・中・後を決める
+		users, err := repo.FindByIDs(ctx, []valueobject.UserID{a.ID(), b.ID(), c.ID()})
+		require.NoError(t, err)
+		require.Len(t, users, 3)
+		slices.SortFunc(users, func(x, y *user.User) int {
+			if user.CursorOf(x).Before(user.CursorOf(y)) {
+				return -1
+			}
+			return 1
+		})
+		first, middle, last := users[0], users[1], users[2]
+		cursor := user.CursorOf(middle)
+		require.NoError(t, repo.Delete(ctx, middle.ID()))
+
+		rest := allPages(t, ctx, repo, &cursor, 2)
+		assert.Equal(t, []valueobject.UserID{last.ID()}, only(ids(rest), first.ID(), middle.ID(), last.ID()))
+	})
+
+	t.Run("Countは保存したユーザーの数だけ増え、削除すると減る", func(t *testing.T) {
+		ctx, repo, save := setup(t)
+		before, err := repo.Count(ctx)
+		require.NoError(t, err)
+
+		a, b := factory.NewUser(), factory.NewUser()
+		save(a, b)
+		got, err := repo.Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, before+2, got)
+
+		require.NoError(t, repo.Delete(ctx, a.ID()))
+		got, err = repo.Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, before+1, got)
+	})
+
+	t.Run("削除したユーザーは取得できず、メールアドレスを再利用できる", func(t *testing.T) {
+		ctx, repo, save := setup(t)
+		u := factory.NewUser()
+		save(u)
+
+		require.NoError(t, repo.Delete(ctx, u.ID()))
+
+		_, err := repo.FindByID(ctx, u.ID())
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		save(factory.NewUser(factory.WithEmail(u.Email().String())))
+	})
+
+	t.Run("存在しないIDの削除は成功する", func(t *testing.T) {
+		ctx, repo, _ := setup(t)
+
+		assert.NoError(t, repo.Delete(ctx, valueobject.NewUserID()))
+	})
+}
+
+// allPages は after の続きから最後まで limit 件ずつ FindPage で辿ったユーザーを返す。
+func allPages(t *testing.T, ctx context.Context, repo user.UserRepository, after *user.PageCursor, limit int) []*user.User {
+	t.Helper()
+	var all []*user.User
+	for {
+		page, err := repo.FindPage(ctx, after, limit)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(page), limit)
+		all = append(all, page...)
+		if len(page) < limit {
+			return all
+		}
+		cursor := user.CursorOf(page[len(page)-1])
+		after = &cursor
+	}
+}
+
+func ids(users []*user.User) []valueobject.UserID {
+	out := make([]valueobject.UserID, len(users))
+	for i, u := range users {
+		out[i] = u.ID()
+	}
+	return out
+}
+
+// only は all から want に含まれるIDだけを順序を保って返す。
+func only(all []valueobject.UserID, want ...valueobject.UserID) []valueobject.UserID {
+	var out []valueobject.UserID
+	for _, id := range all {
+		for _, w := range want {
+			if id.Equal(w) {
+				out = append(out, id)
+				break
+			}
+		}
+	}
+	return out
+}
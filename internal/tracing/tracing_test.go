@@ -0,0 +1,104 @@
+package tracing_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"go-api/internal/config"
+	"go-api/internal/tracing"
+)
+
+func TestSetup(t *testing.T) {
+	t.Run("fileエクスポーターでスパンをファイルに出力する", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "traces.jsonl")
+		tp, shutdown, err := tracing.Setup(context.Background(), config.TracingConfig{
+			Exporter:    "file",
+			FilePath:    path,
+			SampleRatio: 1,
+			ServiceName: "go-api-test",
+		})
+		require.NoError(t, err)
+
+		_, span := tp.Tracer("test").Start(context.Background(), "test-span")
+		span.End()
+		require.NoError(t, shutdown(context.Background()))
+
+		f, err := os.Open(path)
+		require.NoError(t, err)
+		defer f.Close()
+
+		var got struct {
+			Name        string
+			SpanContext struct{ TraceID string }
+		}
+		dec := json.NewDecoder(bufio.NewReader(f))
+		require.NoError(t, dec.Decode(&got))
+		assert.Equal(t, "test-span", got.Name)
+		assert.Equal(t, span.SpanContext().TraceID().String(), got.SpanContext.TraceID)
+	})
+
+	t.Run("無効の場合はスパンを記録しない", func(t *testing.T) {
+		tp, shutdown, err := tracing.Setup(context.Background(), config.TracingConfig{Exporter: "none"})
+		require.NoError(t, err)
+
+		_, span := tp.Tracer("test").Start(context.Background(), "test-span")
+		assert.False(t, span.SpanContext().IsValid())
+		require.NoError(t, shutdown(context.Background()))
+	})
+
+	t.Run("未知のエクスポーターはエラー", func(t *testing.T) {
+		_, _, err := tracing.Setup(context.Background(), config.TracingConfig{Exporter: "zipkin"})
+		assert.Error(t, err)
+	})
+}
+
+func TestUsecase(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	u := tracing.NewUsecase(tp, func(error) string { return "NOT_FOUND" })
+
+	ctx, end := u.Start(context.Background(), "GetUser")
+	assert.True(t, trace.SpanContextFromContext(ctx).IsValid())
+	end(nil)
+	_, end = u.Start(context.Background(), "DeleteUser")
+	end(errors.New("not found"))
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "GetUser", spans[0].Name())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, "DeleteUser", spans[1].Name())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+}
+
+func TestLogAttrs(t *testing.T) {
+	t.Run("スパンがある場合はtrace_idとspan_idを返す", func(t *testing.T) {
+		tp := sdktrace.NewTracerProvider()
+		ctx, span := tp.Tracer("test").Start(context.Background(), "test-span")
+		defer span.End()
+
+		attrs := tracing.LogAttrs(ctx)
+
+		require.Len(t, attrs, 2)
+		assert.Equal(t, "trace_id", attrs[0].Key)
+		assert.Equal(t, span.SpanContext().TraceID().String(), attrs[0].Value.String())
+		assert.Equal(t, "span_id", attrs[1].Key)
+		assert.Equal(t, span.SpanContext().SpanID().String(), attrs[1].Value.String())
+	})
+
+	t.Run("スパンがない場合は何も返さない", func(t *testing.T) {
+		assert.Empty(t, tracing.LogAttrs(context.Background()))
+	})
+}
@@ -0,0 +1,37 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Usecase はユースケースの実行ごとにスパンを記録する。application.Observer を実装する。
+type Usecase struct {
+	tracer trace.Tracer
+	codeOf func(error) string
+}
+
+// NewUsecase は Usecase を生成する。
+// codeOf はエラーを error.type 属性の値（エラーコード）に変換する関数。
+func NewUsecase(tp trace.TracerProvider, codeOf func(error) string) *Usecase {
+	return &Usecase{
+		tracer: tp.Tracer(instrumentationName),
+		codeOf: codeOf,
+	}
+}
+
+// Start は application.Observer を実装する。
+func (u *Usecase) Start(ctx context.Context, usecase string) (context.Context, func(error)) {
+	ctx, span := u.tracer.Start(ctx, usecase, trace.WithSpanKind(trace.SpanKindInternal))
+	return ctx, func(err error) {
+		if err != nil {
+			span.SetAttributes(semconv.ErrorTypeKey.String(u.codeOf(err)))
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}